@@ -15,6 +15,10 @@ import (
 // @description SlackCheers API for workspace setup, people management, channel settings, and celebrations.
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Required on /api/workspaces routes when API_KEYS is set.
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	"slackcheers/internal/config"
 	"slackcheers/internal/database"
@@ -22,7 +24,7 @@ func main() {
 	}
 
 	ctx := context.Background()
-	db, err := database.OpenPostgres(ctx, cfg.DB)
+	db, err := database.OpenMigrationPostgres(ctx, cfg.DB)
 	if err != nil {
 		log.Fatalf("connect db: %v", err)
 	}
@@ -32,7 +34,33 @@ func main() {
 	case "up":
 		err = database.UpMigrations(ctx, db, cfg.DB.MigrationsDir)
 	case "down":
-		err = database.DownOneMigration(ctx, db, cfg.DB.MigrationsDir)
+		n, parseErr := parseDownCount(os.Args[2:])
+		if parseErr != nil {
+			log.Fatalf("invalid down count: %v", parseErr)
+		}
+		err = database.DownNMigrations(ctx, db, cfg.DB.MigrationsDir, n)
+		if errors.Is(err, database.ErrNoAppliedMigrations) {
+			fmt.Println(err)
+			err = nil
+		}
+	case "down-to":
+		if len(os.Args) < 3 {
+			log.Fatalf("down-to requires a target version")
+		}
+		target, parseErr := strconv.ParseInt(os.Args[2], 10, 64)
+		if parseErr != nil {
+			log.Fatalf("invalid target version %q: %v", os.Args[2], parseErr)
+		}
+		err = database.DownToVersion(ctx, db, cfg.DB.MigrationsDir, target)
+	case "target":
+		if len(os.Args) < 3 {
+			log.Fatalf("target requires a migration version")
+		}
+		target, parseErr := strconv.ParseInt(os.Args[2], 10, 64)
+		if parseErr != nil {
+			log.Fatalf("invalid target version %q: %v", os.Args[2], parseErr)
+		}
+		err = database.MigrateToVersion(ctx, db, cfg.DB.MigrationsDir, target)
 	case "status":
 		status, statusErr := database.MigrationStatus(ctx, db, cfg.DB.MigrationsDir)
 		if statusErr == nil {
@@ -40,7 +68,7 @@ func main() {
 		}
 		err = statusErr
 	default:
-		log.Fatalf("unsupported command %q (use up|down|status)", cmd)
+		log.Fatalf("unsupported command %q (use up|down [N|all]|down-to VERSION|target VERSION|status)", cmd)
 	}
 
 	if err != nil {
@@ -49,3 +77,15 @@ func main() {
 
 	fmt.Printf("migration command %q completed\n", cmd)
 }
+
+// parseDownCount reads the optional count after "down". It defaults to one and
+// accepts "all" (or -1) to roll back every applied migration.
+func parseDownCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	if args[0] == "all" {
+		return -1, nil
+	}
+	return strconv.Atoi(args[0])
+}
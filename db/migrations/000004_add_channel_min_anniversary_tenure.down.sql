@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    DROP COLUMN IF EXISTS min_anniversary_tenure_months;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    ADD COLUMN IF NOT EXISTS min_anniversary_tenure_months INT NOT NULL DEFAULT 0 CHECK (min_anniversary_tenure_months >= 0);
@@ -0,0 +1,7 @@
+UPDATE people SET reminders_mode = 'day_before' WHERE reminders_mode = 'week_before';
+
+ALTER TABLE people DROP CONSTRAINT IF EXISTS people_reminders_mode_check;
+
+ALTER TABLE people
+    ADD CONSTRAINT people_reminders_mode_check
+    CHECK (reminders_mode IN ('none', 'same_day', 'day_before'));
@@ -0,0 +1,5 @@
+ALTER TABLE people DROP CONSTRAINT IF EXISTS people_reminders_mode_check;
+
+ALTER TABLE people
+    ADD CONSTRAINT people_reminders_mode_check
+    CHECK (reminders_mode IN ('none', 'same_day', 'day_before', 'week_before'));
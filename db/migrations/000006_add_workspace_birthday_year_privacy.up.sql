@@ -0,0 +1,2 @@
+ALTER TABLE workspaces
+    ADD COLUMN IF NOT EXISTS birthday_year_privacy TEXT NOT NULL DEFAULT 'store' CHECK (birthday_year_privacy IN ('store', 'discard'));
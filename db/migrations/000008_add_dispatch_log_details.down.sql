@@ -0,0 +1,6 @@
+ALTER TABLE celebration_dispatch_log
+    DROP COLUMN IF EXISTS message_ts,
+    DROP COLUMN IF EXISTS anniversary_user_ids,
+    DROP COLUMN IF EXISTS birthday_user_ids,
+    DROP COLUMN IF EXISTS anniversary_count,
+    DROP COLUMN IF EXISTS birthday_count;
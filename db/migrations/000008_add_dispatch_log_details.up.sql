@@ -0,0 +1,6 @@
+ALTER TABLE celebration_dispatch_log
+    ADD COLUMN IF NOT EXISTS birthday_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS anniversary_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS birthday_user_ids TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS anniversary_user_ids TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS message_ts TEXT;
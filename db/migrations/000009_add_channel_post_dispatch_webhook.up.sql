@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    ADD COLUMN IF NOT EXISTS post_dispatch_webhook_url TEXT;
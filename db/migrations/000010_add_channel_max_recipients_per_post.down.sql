@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    DROP COLUMN IF EXISTS max_recipients_per_post;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    ADD COLUMN IF NOT EXISTS max_recipients_per_post INT NOT NULL DEFAULT 0 CHECK (max_recipients_per_post >= 0);
@@ -0,0 +1,2 @@
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS announcement_channel_id;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    DROP COLUMN IF EXISTS paused_until;
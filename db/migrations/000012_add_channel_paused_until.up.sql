@@ -0,0 +1,2 @@
+ALTER TABLE workspace_channels
+    ADD COLUMN IF NOT EXISTS paused_until TIMESTAMPTZ;
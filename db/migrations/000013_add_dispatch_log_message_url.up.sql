@@ -0,0 +1,2 @@
+ALTER TABLE celebration_dispatch_log
+    ADD COLUMN IF NOT EXISTS message_url TEXT;
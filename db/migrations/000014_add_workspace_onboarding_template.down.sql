@@ -0,0 +1,2 @@
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS onboarding_message_template;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS celebration_post_log;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS celebration_post_log (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_channel_id UUID NOT NULL REFERENCES workspace_channels(id) ON DELETE CASCADE,
+    celebration_type TEXT NOT NULL CHECK (celebration_type IN ('birthday', 'anniversary')),
+    slack_user_ids TEXT[] NOT NULL DEFAULT '{}',
+    message_text TEXT NOT NULL,
+    dispatched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    slack_message_ts TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_celebration_post_log_channel_dispatched_at ON celebration_post_log(workspace_channel_id, dispatched_at DESC);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS reminder_log (
+    id BIGSERIAL PRIMARY KEY,
+    person_id UUID NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL CHECK (event_type IN ('birthday', 'anniversary')),
+    event_year INT NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (person_id, event_type, event_year)
+);
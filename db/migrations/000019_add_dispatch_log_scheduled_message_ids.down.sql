@@ -0,0 +1,2 @@
+ALTER TABLE celebration_dispatch_log
+    DROP COLUMN IF EXISTS scheduled_message_ids;
@@ -0,0 +1,2 @@
+ALTER TABLE celebration_dispatch_log
+    ADD COLUMN IF NOT EXISTS scheduled_message_ids TEXT[] NOT NULL DEFAULT '{}';
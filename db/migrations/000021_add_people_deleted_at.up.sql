@@ -0,0 +1,2 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS anniversaries_enabled,
+    DROP COLUMN IF EXISTS birthdays_enabled;
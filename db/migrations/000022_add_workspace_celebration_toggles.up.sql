@@ -0,0 +1,3 @@
+ALTER TABLE workspaces
+    ADD COLUMN IF NOT EXISTS birthdays_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS anniversaries_enabled BOOLEAN NOT NULL DEFAULT TRUE;
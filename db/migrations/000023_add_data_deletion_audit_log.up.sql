@@ -0,0 +1,9 @@
+-- No foreign key on purpose: the audit trail must outlive the workspace.
+CREATE TABLE IF NOT EXISTS data_deletion_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    slack_user_id TEXT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_deletion_audit_log_workspace ON data_deletion_audit_log(workspace_id, deleted_at DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS workspace_member_sync_log;
@@ -0,0 +1,6 @@
+-- One row per workspace, overwritten by each Slack member sync.
+CREATE TABLE IF NOT EXISTS workspace_member_sync_log (
+    workspace_id UUID PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    synced_at TIMESTAMPTZ NOT NULL,
+    member_count INTEGER NOT NULL
+);
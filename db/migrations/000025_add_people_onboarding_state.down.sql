@@ -0,0 +1 @@
+ALTER TABLE people DROP COLUMN IF EXISTS onboarding_state;
@@ -0,0 +1,4 @@
+-- NULL means the person is not in the guided onboarding conversation.
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS onboarding_state TEXT
+    CHECK (onboarding_state IN ('awaiting_birthday', 'awaiting_hire_date', 'complete'));
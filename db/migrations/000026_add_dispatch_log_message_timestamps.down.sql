@@ -0,0 +1,2 @@
+ALTER TABLE celebration_dispatch_log
+    DROP COLUMN IF EXISTS message_timestamps;
@@ -0,0 +1,6 @@
+ALTER TABLE celebration_dispatch_log
+    ADD COLUMN IF NOT EXISTS message_timestamps TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE celebration_dispatch_log
+SET message_timestamps = ARRAY[message_ts]
+WHERE message_ts IS NOT NULL AND message_ts <> '';
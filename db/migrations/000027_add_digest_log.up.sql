@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS digest_log (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, week_start)
+);
@@ -15,9 +15,76 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/workspaces/bootstrap": {
-            "post": {
-                "description": "Creates or updates a workspace and its default celebration channel.",
+        "/api/admin/birthdays": {
+            "get": {
+                "description": "Returns every person with a birthday on the given month and day, grouped by workspace ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List birthdays across all workspaces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Birthday month (1-12)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Birthday day (1-31)",
+                        "name": "day",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.AdminBirthdaysResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/log-level": {
+            "put": {
+                "description": "Updates the log level at runtime. The level resets to info on restart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,25 +92,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workspaces"
+                    "admin"
                 ],
-                "summary": "Bootstrap a workspace",
+                "summary": "Change the application log level",
                 "parameters": [
                     {
-                        "description": "Workspace bootstrap payload",
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Log level payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.BootstrapWorkspaceRequest"
+                            "$ref": "#/definitions/internal_http_handlers.UpdateLogLevelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.BootstrapWorkspaceResponse"
+                            "$ref": "#/definitions/internal_http_handlers.LogLevelResponse"
                         }
                     },
                     "400": {
@@ -52,8 +126,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
@@ -61,33 +141,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/channels": {
-            "get": {
+        "/api/scheduler/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Skips celebration and reminder ticks until resumed, without restarting the process. A tick already running finishes. The pause is lost on restart.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "scheduler"
                 ],
-                "summary": "List workspace channels",
-                "parameters": [
+                "summary": "Pause the scheduler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SchedulerStatusResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scheduler/resume": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceID",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Resume the scheduler",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ChannelsResponse"
+                            "$ref": "#/definitions/internal_http_handlers.SchedulerStatusResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
@@ -95,35 +202,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/channels/{channelID}/cleanup-birthday-messages": {
-            "post": {
-                "description": "Deletes bot-authored channel messages matching text (default: happy birthday).",
+        "/api/scheduler/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reports whether the scheduler loop is running, whether dispatch is paused, and how often it polls. cron is set when SCHEDULER_CRON replaces the poll interval. A disabled scheduler reports running false.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "scheduler"
                 ],
-                "summary": "Delete bot birthday messages in a channel",
-                "parameters": [
+                "summary": "Scheduler status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SchedulerStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceID",
-                        "in": "path",
-                        "required": true
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pages through installed workspaces, newest first, with their people and channel counts. Bot tokens are never included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "List workspaces",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Channel UUID or Slack Channel ID",
-                        "name": "channelID",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Case-insensitive text to match (default: happy birthday)",
-                        "name": "match",
+                        "type": "integer",
+                        "description": "Number of workspaces to skip",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
@@ -131,7 +260,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ChannelBirthdayCleanupResponse"
+                            "$ref": "#/definitions/internal_http_handlers.WorkspacesResponse"
                         }
                     },
                     "400": {
@@ -140,8 +269,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
@@ -155,8 +284,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/channels/{channelID}/settings": {
-            "put": {
+        "/api/workspaces/bootstrap": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates or updates a workspace and its default celebration channel. When the workspace already has a bot token, the bot must be able to access (or auto-join) the channel.",
                 "consumes": [
                     "application/json"
                 ],
@@ -164,39 +299,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "workspaces"
                 ],
-                "summary": "Update channel settings",
+                "summary": "Bootstrap a workspace",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "channelID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Channel settings payload",
+                        "description": "Workspace bootstrap payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.UpdateChannelSettingsRequest"
+                            "$ref": "#/definitions/internal_http_handlers.BootstrapWorkspaceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                            "$ref": "#/definitions/internal_http_handlers.BootstrapWorkspaceResponse"
                         }
                     },
                     "400": {
@@ -205,8 +326,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
@@ -214,18 +335,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/channels/{channelID}/templates": {
-            "put": {
-                "consumes": [
-                    "application/json"
+        "/api/workspaces/{workspaceID}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "channels"
+                    "workspaces"
                 ],
-                "summary": "Update channel templates",
+                "summary": "Get a workspace",
                 "parameters": [
                     {
                         "type": "string",
@@ -233,56 +356,40 @@ const docTemplate = `{
                         "name": "workspaceID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "channelID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Channel templates payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.UpdateChannelTemplatesRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                            "$ref": "#/definitions/internal_http_handlers.WorkspaceResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/workspaces/{workspaceID}/dispatch-now": {
-            "post": {
-                "description": "Manually runs birthday and anniversary dispatch now across workspace channels.",
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Permanently deletes the workspace with its channels, people, dispatch and onboarding logs. Requires X-Confirm-Delete: true.",
                 "tags": [
                     "workspaces"
                 ],
-                "summary": "Force run celebrations now for a workspace",
+                "summary": "Delete a workspace",
                 "parameters": [
                     {
                         "type": "string",
@@ -290,14 +397,18 @@ const docTemplate = `{
                         "name": "workspaceID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Must be true",
+                        "name": "X-Confirm-Delete",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ManualCelebrationDispatchResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -318,18 +429,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/workspaces/{workspaceID}/onboarding/dm": {
-            "post": {
-                "description": "Sends one onboarding DM per member (once only), asking for birthday and work start date.",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the workspace name and/or timezone. Omitted fields are unchanged. Channel timezones are independent and are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "onboarding"
+                    "workspaces"
                 ],
-                "summary": "Send onboarding DMs to workspace members",
+                "summary": "Update a workspace",
                 "parameters": [
                     {
                         "type": "string",
@@ -339,17 +456,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Set true to resend DMs to everyone, including previously messaged users",
-                        "name": "force",
-                        "in": "query"
+                        "description": "Workspace fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PatchWorkspaceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.OnboardingDMDispatchResponse"
+                            "$ref": "#/definitions/internal_http_handlers.WorkspaceResponse"
                         }
                     },
                     "400": {
@@ -373,16 +493,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/onboarding/dm/cleanup": {
-            "post": {
-                "description": "Deletes past messages authored by SlackCheers bot in the DM with the selected user.",
+        "/api/workspaces/{workspaceID}/announcement-channel": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets the channel where the workspace-wide weekly digest is posted. The bot must be a member of the channel. An empty slack_channel_id clears it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "onboarding"
+                    "workspaces"
                 ],
-                "summary": "Delete bot-authored DM history for a user",
+                "summary": "Set the workspace announcement channel",
                 "parameters": [
                     {
                         "type": "string",
@@ -392,18 +520,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Slack User ID",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Announcement channel payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpdateAnnouncementChannelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.DMCleanupResponse"
+                            "$ref": "#/definitions/internal_http_handlers.AnnouncementChannelResponse"
                         }
                     },
                     "400": {
@@ -412,6 +542,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -427,16 +563,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/overview": {
-            "get": {
-                "description": "Returns upcoming birthdays and/or anniversaries for a workspace.",
+        "/api/workspaces/{workspaceID}/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Posts the celebrations each channel missed on the given days (inclusive, at most 90). Channel/day pairs already in the dispatch log, today and later, days before the channel existed and currently paused channels are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "workspaces"
                 ],
-                "summary": "List upcoming celebrations",
+                "summary": "Backfill missed celebrations",
                 "parameters": [
                     {
                         "type": "string",
@@ -446,23 +590,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of days to include (default 30)",
-                        "name": "days",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter: all|birthdays|anniversaries",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Date range (YYYY-MM-DD)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.BackfillRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.OverviewResponse"
+                            "$ref": "#/definitions/internal_http_handlers.BackfillResponse"
                         }
                     },
                     "400": {
@@ -471,6 +612,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -480,15 +633,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/people": {
+        "/api/workspaces/{workspaceID}/celebration-history": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns every birthday and anniversary message posted in the workspace, newest first, including the Slack message timestamp.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people"
+                    "workspaces"
                 ],
-                "summary": "List people in a workspace",
+                "summary": "List posted celebration messages",
                 "parameters": [
                     {
                         "type": "string",
@@ -496,13 +655,25 @@ const docTemplate = `{
                         "name": "workspaceID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.PeopleResponse"
+                            "$ref": "#/definitions/internal_http_handlers.CelebrationHistoryResponse"
                         }
                     },
                     "400": {
@@ -511,12 +682,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -526,18 +691,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/people/{slackUserID}": {
-            "put": {
-                "consumes": [
-                    "application/json"
+        "/api/workspaces/{workspaceID}/channels": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people"
+                    "channels"
                 ],
-                "summary": "Create or update a person",
+                "summary": "List workspace channels",
                 "parameters": [
                     {
                         "type": "string",
@@ -545,33 +712,58 @@ const docTemplate = `{
                         "name": "workspaceID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ChannelsResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the channel configuration and its dispatch history. The workspace's last channel can be removed too.",
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Remove a celebration channel",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Slack User ID",
-                        "name": "slackUserID",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Person payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.UpsertPersonRequest"
-                        }
+                        "type": "string",
+                        "description": "Channel ID or Slack channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/slackcheers_internal_domain.Person"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
@@ -585,16 +777,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/workspaces/{workspaceID}/slack/channels": {
-            "get": {
-                "description": "Fetches channels directly from Slack using the workspace-installed bot token.",
+        "/api/workspaces/{workspaceID}/channels/{channelID}/cleanup-birthday-messages": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes bot-authored channel messages matching text (default: happy birthday).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "channels"
                 ],
-                "summary": "List Slack channels for workspace connection",
+                "summary": "Delete bot birthday messages in a channel",
                 "parameters": [
                     {
                         "type": "string",
@@ -602,13 +799,26 @@ const docTemplate = `{
                         "name": "workspaceID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel UUID or Slack Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive text to match (default: happy birthday)",
+                        "name": "match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.SlackChannelsResponse"
+                            "$ref": "#/definitions/internal_http_handlers.ChannelBirthdayCleanupResponse"
                         }
                     },
                     "400": {
@@ -623,6 +833,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -632,34 +848,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/slack/callback": {
+        "/api/workspaces/{workspaceID}/channels/{channelID}/dispatch-log": {
             "get": {
-                "description": "Exchanges OAuth code, stores workspace install metadata, and returns connected workspace details.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one page of a channel's dispatch history, newest first, with the total entry count. An empty log is returned as an empty list. Pass format=csv to download the full history as CSV.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "auth"
+                    "channels"
                 ],
-                "summary": "Slack OAuth callback",
+                "summary": "List channel dispatch log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slack OAuth code",
-                        "name": "code",
-                        "in": "query",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Opaque CSRF state",
-                        "name": "state",
+                        "description": "Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json (default) or csv",
+                        "name": "format",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Slack OAuth error",
-                        "name": "error",
+                        "description": "Earliest dispatch date (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest dispatch date (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 30, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
@@ -667,7 +914,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.SlackConnectResponse"
+                            "$ref": "#/definitions/internal_http_handlers.DispatchLogResponse"
                         }
                     },
                     "400": {
@@ -676,6 +923,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -685,27 +938,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/slack/install": {
-            "get": {
-                "description": "Redirects to Slack OAuth consent page. Use mode=json to return URL without redirect.",
-                "produces": [
-                    "application/json"
+        "/api/workspaces/{workspaceID}/channels/{channelID}/dispatch-log/{date}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Deletes the channel's dispatch log entry for a date so the channel is picked up again by dispatch-now or the scheduler the same day, without waiting for tomorrow. Requires X-Confirm-Delete: true.",
                 "tags": [
-                    "auth"
+                    "channels"
                 ],
-                "summary": "Start Slack install",
+                "summary": "Clear a channel dispatch log entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Opaque CSRF state",
-                        "name": "state",
-                        "in": "query"
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Set to json to return install URL",
-                        "name": "mode",
+                        "description": "Channel ID or Slack channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dispatch date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Must be true",
+                        "name": "X-Confirm-Delete",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns who was celebrated in the channel and when, newest first. The total number of entries is also sent in the X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "List channel celebration history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel UUID or Slack Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page (default 30, max 100)",
+                        "name": "per_page",
                         "in": "query"
                     }
                 ],
@@ -713,13 +1052,146 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.SlackInstallURLResponse"
+                            "$ref": "#/definitions/internal_http_handlers.ChannelHistoryResponse"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of history entries"
+                            }
                         }
                     },
-                    "307": {
-                        "description": "Temporary Redirect",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Skips scheduled celebrations for the channel until the given time. until must be RFC3339, in the future and at most 90 days away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Pause celebrations for a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel UUID or Slack Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pause payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PauseChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Resume celebrations for a paused channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel UUID or Slack Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -731,130 +1203,2808 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthz": {
+        "/api/workspaces/{workspaceID}/channels/{channelID}/people": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "people"
+                ],
+                "summary": "List people celebrated in a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 100, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of people to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.HealthResponse"
+                            "$ref": "#/definitions/internal_http_handlers.PeopleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/preview-ephemeral": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
+                ],
+                "description": "Builds today's celebration messages for the channel and posts them as ephemeral messages visible only to the admin user. Nothing is recorded as dispatched.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Preview today's celebration posts privately",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel UUID or Slack Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID that receives the preview",
+                        "name": "admin_user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ChannelPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/settings": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Update channel settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel settings payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpdateChannelSettingsRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Send a test ping to post_dispatch_webhook_url and require a 2xx response",
+                        "name": "validate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Skip checking that the Slack channel exists, is not archived, and has the bot as a member",
+                        "name": "skip_channel_validation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/templates": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Templates may hold several alternatives separated by |||. Every alternative must be non-empty and use only known variables.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Update channel templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel templates payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpdateChannelTemplatesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/channels/{channelID}/templates/preview": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Renders birthday and anniversary templates for a sample person without posting to Slack. Omitted fields fall back to the channel's saved templates and emoji; without slack_user_id the first saved person is used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Preview channel templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Templates to preview",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PreviewTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PreviewTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/connection-status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Calls Slack auth.test with the stored bot token to report whether it still works. Results are cached for 60 seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Check the workspace Slack connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ConnectionStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/dispatch-now": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Manually runs birthday and anniversary dispatch now across workspace channels. Send X-Idempotency-Key to make retries safe: a repeated key within 24 hours returns the first response without dispatching again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Force run celebrations now for a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency key",
+                        "name": "X-Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Render messages without posting or recording the dispatch",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ManualCelebrationDispatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Downloads people.csv (the people import schema), channels.json (every channel with its settings) and templates.json (channel ID to birthday_template, anniversary_template and branding_emoji). The archive can be loaded into another instance with POST /import.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Export a workspace as a ZIP archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns per-day birthday and anniversary counts for the next N days. Days without celebrations are omitted. Results are cached for up to one hour per workspace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Forecast celebration counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days to include (default 90, 1 to 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Loads an archive from GET /export into this workspace. People are upserted like the CSV import; channels are created or updated by Slack channel ID and get their settings and templates from the archive. Invalid people rows and channels are skipped and reported in errors.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Import a workspace ZIP archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Workspace export ZIP",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_service.WorkspaceImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/member-sync/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reports when the workspace's Slack members were last copied into people and how many there were. last_synced_at is null before the first sync.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Get the Slack member sync status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.MemberSyncStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/onboarding/dm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends one onboarding DM per member (once only), asking for birthday and work start date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Send onboarding DMs to workspace members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set true to resend DMs to everyone, including previously messaged users",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.OnboardingDMDispatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/onboarding/dm/cleanup": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes past messages authored by SlackCheers bot in the DM with the selected user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Delete bot-authored DM history for a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.DMCleanupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/onboarding/progress": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns how many onboarding DMs were sent and how many recipients have shared their birthday, work start date, or both. Members not yet sent a DM are counted against the last Slack member sync.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Get onboarding DM progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.OnboardingProgressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/onboarding/template": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Customizes the onboarding DM sent to workspace members. {name} is replaced with the member's display name. An empty template restores the built-in message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Set the onboarding DM template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Onboarding template payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpdateOnboardingTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.OnboardingTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/overview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns upcoming birthdays and/or anniversaries for a workspace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "List upcoming celebrations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days to include (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter: all|birthdays|anniversaries",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.OverviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "With missing set, only saved people lacking that data are listed and the body is a MissingDataPeopleResponse.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "List people in a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive filter on display name or Slack handle",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "birthday",
+                            "hire_date",
+                            "any"
+                        ],
+                        "type": "string",
+                        "description": "Only people missing this data",
+                        "name": "missing",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 100, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of people to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PeopleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/bulk-reminders-mode": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets reminders_mode for the listed Slack users, or for every person in the workspace when user_ids is omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Bulk update people reminders mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bulk reminders mode payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.BulkUpdateRemindersModeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.BulkUpdateRemindersModeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns groups of two or more people with the same birthday month and day.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "List people who share a birthday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.BirthdayDuplicatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Downloads every saved person in the workspace. The CSV uses the same columns as the import endpoint.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Export people",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: csv (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_http_handlers.PersonExportItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upserts one person per CSV row. The header row is required and must include slack_user_id; display_name, slack_handle, birthday_day, birthday_month, birthday_year and hire_date (YYYY-MM-DD) are optional. Invalid rows are skipped and reported in errors.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Import people from a CSV file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "People CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping CSV header names to people fields",
+                        "name": "column_map",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.BulkImportPeopleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Create or update a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack User ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Person payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpsertPersonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hides the person from listings and celebrations. The record, including their opt-out choice, is kept so they can be restored; saving the person again also restores them.",
+                "tags": [
+                    "people"
+                ],
+                "summary": "Soft-delete a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}/birthday": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Remove a person's birthday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}/data": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hard-deletes the person, their reminder and onboarding DM logs, and celebration post log entries naming them. Pending scheduled celebrations naming them are cancelled first on a best-effort basis. Unlike DELETE /people/{slackUserID} nothing can be restored; only an audit row (workspace, Slack user ID, time) is kept. Requires X-Confirm-Delete: true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Permanently erase a person's data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Must be true",
+                        "name": "X-Confirm-Delete",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PersonErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Downloads every stored field for one person plus when onboarding DMs were sent to them, for data access requests.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Export everything stored about a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PersonDataExportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}/reminders": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Computes when the person's next birthday and work anniversary reminders fire based on their reminders_mode. Returns an empty list when reminders_mode is none.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "List a person's scheduled reminders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/slackcheers_internal_service.ScheduledReminder"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/people/{slackUserID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Restore a soft-deleted person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "slackUserID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/privacy": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Get workspace privacy settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PrivacySettingsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "birthday_year_privacy=discard stops storing birth years for new and updated people.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Update workspace privacy settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Privacy settings payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.UpdatePrivacySettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.PrivacySettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspaceID}/slack/channels": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetches channels directly from Slack using the workspace-installed bot token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "List Slack channels for workspace connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackChannelsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/slack/callback": {
+            "get": {
+                "description": "Exchanges OAuth code, stores workspace install metadata, and returns connected workspace details.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Slack OAuth callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slack OAuth code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State issued by /auth/slack/install; must match the state cookie",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack OAuth error",
+                        "name": "error",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackConnectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/slack/install": {
+            "get": {
+                "description": "Redirects to Slack OAuth consent page. Use mode=json to return URL without redirect. Sets a signed, HttpOnly state cookie that the callback requires.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start Slack install",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Set to json to return install URL",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackInstallURLResponse"
+                        }
+                    },
+                    "307": {
+                        "description": "Temporary Redirect",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz/live": {
+            "get": {
+                "description": "Returns 200 while the process is running. /healthz is an alias.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz/ready": {
+            "get": {
+                "description": "Returns 200 when the database answers a ping within 2 seconds and, if enabled, the scheduler has started.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/actions": {
+            "post": {
+                "description": "Verifies Slack signatures and handles onboarding DM buttons (opening a date picker modal) and the modal submissions that save birthdays/hire dates.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack interactivity webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slack interaction payload JSON",
+                        "name": "payload",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackEventAckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/commands": {
+            "post": {
+                "description": "Verifies Slack signatures and handles /birthday. \"status\" replies with the saved birthday and hire date; any other text is parsed like a DM reply and saved. The reply is posted to response_url after an immediate ephemeral acknowledgement.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack slash command webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slack team ID",
+                        "name": "team_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack user ID",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command text",
+                        "name": "text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slack response URL",
+                        "name": "response_url",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackCommandAckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slack/events": {
+            "post": {
+                "description": "Verifies Slack signatures, handles URL verification, and processes DM replies to save birthdays/hire dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slack"
+                ],
+                "summary": "Slack events webhook",
+                "parameters": [
+                    {
+                        "description": "Slack event payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackEventEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.SlackEventAckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_http_handlers.AdminBirthdaysResponse": {
+            "type": "object",
+            "properties": {
+                "workspaces": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/slackcheers_internal_domain.Person"
+                        }
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.AnnouncementChannelResponse": {
+            "type": "object",
+            "properties": {
+                "announcement_channel_id": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.BackfillDayItem": {
+            "type": "object",
+            "properties": {
+                "anniversary_count": {
+                    "type": "integer"
+                },
+                "birthday_count": {
+                    "type": "integer"
+                },
+                "channels_dispatched": {
+                    "type": "integer"
+                },
+                "channels_skipped": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.BackfillRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-07"
+                }
+            }
+        },
+        "internal_http_handlers.BackfillResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.BackfillDayItem"
+                    }
+                },
+                "workspace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.BirthdayDuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "people": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slackcheers_internal_domain.Person"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.BirthdayDuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.BirthdayDuplicateGroup"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.BootstrapWorkspaceRequest": {
+            "type": "object",
+            "required": [
+                "channel_id",
+                "channel_name",
+                "name",
+                "posting_time",
+                "slack_team_id",
+                "timezone"
+            ],
+            "properties": {
+                "channel_id": {
+                    "type": "string"
+                },
+                "channel_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "posting_time": {
+                    "type": "string"
+                },
+                "slack_team_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.BootstrapWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                },
+                "workspace": {
+                    "$ref": "#/definitions/slackcheers_internal_domain.Workspace"
+                }
+            }
+        },
+        "internal_http_handlers.BulkImportPeopleResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.BulkUpdateRemindersModeRequest": {
+            "type": "object",
+            "required": [
+                "reminders_mode"
+            ],
+            "properties": {
+                "reminders_mode": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.BulkUpdateRemindersModeResponse": {
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.CelebratedPersonItem": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "slack_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.CelebrationHistoryItem": {
+            "type": "object",
+            "properties": {
+                "celebration_type": {
+                    "type": "string"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "dispatched_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message_text": {
+                    "type": "string"
+                },
+                "slack_channel_id": {
+                    "type": "string"
+                },
+                "slack_message_ts": {
+                    "type": "string"
+                },
+                "slack_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.CelebrationHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.CelebrationHistoryItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.ChannelBirthdayCleanupResponse": {
+            "type": "object",
+            "properties": {
+                "channel_id": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "failed_details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "failed_ts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "match": {
+                    "type": "string"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "scanned": {
+                    "type": "integer"
+                },
+                "slack_channel_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.ChannelHistoryItem": {
+            "type": "object",
+            "properties": {
+                "anniversaries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.CelebratedPersonItem"
+                    }
+                },
+                "birthdays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.CelebratedPersonItem"
+                    }
+                },
+                "dispatch_date": {
+                    "type": "string"
+                },
+                "dispatched_at": {
+                    "type": "string"
+                },
+                "message_ts": {
+                    "type": "string"
+                },
+                "message_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.ChannelHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.ChannelHistoryItem"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.ChannelPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "admin_user_id": {
+                    "type": "string"
+                },
+                "anniversary_count": {
+                    "type": "integer"
+                },
+                "birthday_count": {
+                    "type": "integer"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "messages_sent": {
+                    "type": "integer"
+                },
+                "preview_date": {
+                    "type": "string"
+                },
+                "slack_channel_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.ChannelsResponse": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.ConnectionStatusResponse": {
+            "type": "object",
+            "properties": {
+                "bot_user_id": {
+                    "type": "string"
+                },
+                "connected": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.DMCleanupResponse": {
+            "type": "object",
+            "properties": {
+                "bot_messages": {
+                    "type": "integer"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "failed_details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "failed_ts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_messages": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.DispatchLogItem": {
+            "type": "object",
+            "properties": {
+                "anniversary_count": {
+                    "type": "integer"
+                },
+                "anniversary_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "birthday_count": {
+                    "type": "integer"
+                },
+                "birthday_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dispatch_date": {
+                    "type": "string"
+                },
+                "message_timestamps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message_ts": {
+                    "type": "string"
+                },
+                "message_url": {
+                    "type": "string"
+                },
+                "scheduled_message_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "slack_channel_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.DispatchLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.DispatchLogItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.ForecastDayItem": {
+            "type": "object",
+            "properties": {
+                "anniversary_count": {
+                    "type": "integer"
+                },
+                "birthday_count": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.ForecastResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.ForecastDayItem"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "/slack/events": {
-            "post": {
-                "description": "Verifies Slack signatures, handles URL verification, and processes DM replies to save birthdays/hire dates.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "slack"
-                ],
-                "summary": "Slack events webhook",
-                "parameters": [
-                    {
-                        "description": "Slack event payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.SlackEventEnvelope"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.SlackEventAckResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.ErrorResponse"
-                        }
-                    }
+        "internal_http_handlers.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "previous_level": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_http_handlers.BootstrapWorkspaceRequest": {
+        },
+        "internal_http_handlers.ManualCelebrationChannelDispatches": {
             "type": "object",
-            "required": [
-                "channel_id",
-                "channel_name",
-                "name",
-                "posting_time",
-                "slack_team_id",
-                "timezone"
-            ],
             "properties": {
+                "anniversary_count": {
+                    "type": "integer"
+                },
+                "anniversary_posted": {
+                    "type": "boolean"
+                },
+                "birthday_count": {
+                    "type": "integer"
+                },
+                "birthday_posted": {
+                    "type": "boolean"
+                },
                 "channel_id": {
                     "type": "string"
                 },
-                "channel_name": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "name": {
+                "error": {
                     "type": "string"
                 },
-                "posting_time": {
+                "message_url": {
                     "type": "string"
                 },
-                "slack_team_id": {
+                "preview_message": {
                     "type": "string"
                 },
-                "timezone": {
+                "slack_channel_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_http_handlers.BootstrapWorkspaceResponse": {
+        "internal_http_handlers.ManualCelebrationDispatchResponse": {
             "type": "object",
             "properties": {
-                "channel": {
-                    "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                "anniversary_posts": {
+                    "type": "integer"
                 },
-                "workspace": {
-                    "$ref": "#/definitions/slackcheers_internal_domain.Workspace"
+                "birthday_posts": {
+                    "type": "integer"
+                },
+                "channel_dispatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.ManualCelebrationChannelDispatches"
+                    }
+                },
+                "channels_processed": {
+                    "type": "integer"
+                },
+                "channels_with_errors": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "internal_http_handlers.ChannelBirthdayCleanupResponse": {
+        "internal_http_handlers.MemberSyncStatusResponse": {
             "type": "object",
             "properties": {
-                "channel_id": {
+                "last_synced_at": {
                     "type": "string"
                 },
-                "deleted": {
+                "member_count": {
                     "type": "integer"
-                },
+                }
+            }
+        },
+        "internal_http_handlers.OnboardingDMDispatchResponse": {
+            "type": "object",
+            "properties": {
                 "failed": {
                     "type": "integer"
                 },
@@ -864,188 +4014,269 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "failed_ts": {
+                "failed_users": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "match": {
-                    "type": "string"
+                "sent": {
+                    "type": "integer"
                 },
-                "matched": {
+                "skipped": {
                     "type": "integer"
                 },
-                "scanned": {
+                "total_members": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.OnboardingProgressResponse": {
+            "type": "object",
+            "properties": {
+                "not_sent": {
                     "type": "integer"
                 },
-                "slack_channel_id": {
+                "pending": {
+                    "type": "integer"
+                },
+                "responded_birthday": {
+                    "type": "integer"
+                },
+                "responded_both": {
+                    "type": "integer"
+                },
+                "responded_hire_date": {
+                    "type": "integer"
+                },
+                "total_sent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http_handlers.OnboardingTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "template": {
+                    "type": "string"
+                },
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_http_handlers.ChannelsResponse": {
+        "internal_http_handlers.OverviewResponse": {
             "type": "object",
             "properties": {
-                "channels": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/slackcheers_internal_domain.WorkspaceChannel"
+                        "$ref": "#/definitions/slackcheers_internal_domain.UpcomingCelebration"
                     }
                 }
             }
         },
-        "internal_http_handlers.DMCleanupResponse": {
+        "internal_http_handlers.PatchWorkspaceRequest": {
             "type": "object",
             "properties": {
-                "bot_messages": {
-                    "type": "integer"
-                },
-                "channel_id": {
+                "name": {
                     "type": "string"
                 },
-                "deleted": {
+                "timezone": {
+                    "type": "string",
+                    "example": "Africa/Lagos"
+                }
+            }
+        },
+        "internal_http_handlers.PauseChannelRequest": {
+            "type": "object",
+            "required": [
+                "until"
+            ],
+            "properties": {
+                "until": {
+                    "type": "string",
+                    "example": "2026-01-05T00:00:00Z"
+                }
+            }
+        },
+        "internal_http_handlers.PeopleResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
                     "type": "integer"
                 },
-                "failed": {
+                "offset": {
                     "type": "integer"
                 },
-                "failed_details": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "failed_ts": {
+                "people": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/slackcheers_internal_domain.Person"
                     }
                 },
-                "total_messages": {
+                "total_count": {
                     "type": "integer"
-                },
-                "user_id": {
-                    "type": "string"
                 }
             }
         },
-        "internal_http_handlers.ErrorResponse": {
+        "internal_http_handlers.PersonDataExportResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "birthday_day": {
+                    "type": "integer"
+                },
+                "birthday_month": {
+                    "type": "integer"
+                },
+                "birthday_year": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "hire_date": {
+                    "type": "string",
+                    "example": "2021-03-15"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "onboarding_dm_sent_at": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "public_celebration_opt_in": {
+                    "type": "boolean"
+                },
+                "reminders_mode": {
+                    "type": "string"
+                },
+                "slack_handle": {
+                    "type": "string"
+                },
+                "slack_user_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workspace_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_http_handlers.HealthResponse": {
+        "internal_http_handlers.PersonErasureResponse": {
             "type": "object",
-            "properties": {
-                "status": {
-                    "type": "string"
+            "properties": {
+                "deleted_tables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows_affected": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_http_handlers.ManualCelebrationChannelDispatches": {
+        "internal_http_handlers.PersonExportItem": {
             "type": "object",
             "properties": {
-                "anniversary_count": {
+                "birthday_day": {
                     "type": "integer"
                 },
-                "anniversary_posted": {
-                    "type": "boolean"
+                "birthday_month": {
+                    "type": "integer"
                 },
-                "birthday_count": {
+                "birthday_year": {
                     "type": "integer"
                 },
-                "birthday_posted": {
+                "display_name": {
+                    "type": "string"
+                },
+                "hire_date": {
+                    "type": "string"
+                },
+                "public_celebration_opt_in": {
                     "type": "boolean"
                 },
-                "channel_id": {
+                "reminders_mode": {
                     "type": "string"
                 },
-                "error": {
+                "slack_handle": {
                     "type": "string"
                 },
-                "slack_channel_id": {
+                "slack_user_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_http_handlers.ManualCelebrationDispatchResponse": {
+        "internal_http_handlers.PreviewTemplateRequest": {
             "type": "object",
             "properties": {
-                "anniversary_posts": {
-                    "type": "integer"
-                },
-                "birthday_posts": {
-                    "type": "integer"
-                },
-                "channel_dispatches": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_http_handlers.ManualCelebrationChannelDispatches"
-                    }
+                "anniversary_template": {
+                    "type": "string",
+                    "example": "Happy {years} year work anniversary {users}!"
                 },
-                "channels_processed": {
-                    "type": "integer"
+                "birthday_template": {
+                    "type": "string",
+                    "example": "Happy birthday {users}!"
                 },
-                "channels_with_errors": {
-                    "type": "integer"
+                "branding_emoji": {
+                    "type": "string"
                 },
-                "workspace_id": {
+                "slack_user_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_http_handlers.OnboardingDMDispatchResponse": {
+        "internal_http_handlers.PreviewTemplateResponse": {
             "type": "object",
             "properties": {
-                "failed": {
-                    "type": "integer"
-                },
-                "failed_details": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "failed_users": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "sent": {
-                    "type": "integer"
+                "anniversary_preview": {
+                    "type": "string"
                 },
-                "skipped": {
-                    "type": "integer"
+                "birthday_preview": {
+                    "type": "string"
                 },
-                "total_members": {
-                    "type": "integer"
+                "sample_user_id": {
+                    "type": "string"
                 }
             }
         },
-        "internal_http_handlers.OverviewResponse": {
+        "internal_http_handlers.PrivacySettingsResponse": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/slackcheers_internal_domain.UpcomingCelebration"
-                    }
+                "birthday_year_privacy": {
+                    "type": "string"
                 }
             }
         },
-        "internal_http_handlers.PeopleResponse": {
+        "internal_http_handlers.SchedulerStatusResponse": {
             "type": "object",
             "properties": {
-                "people": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/slackcheers_internal_domain.Person"
-                    }
+                "cron": {
+                    "type": "string",
+                    "example": "0 * * * *"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "poll_interval": {
+                    "type": "string",
+                    "example": "1m"
+                },
+                "running": {
+                    "type": "boolean"
                 }
             }
         },
@@ -1074,6 +4305,17 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_http_handlers.SlackCommandAckResponse": {
+            "type": "object",
+            "properties": {
+                "response_type": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_http_handlers.SlackConnectResponse": {
             "type": "object",
             "properties": {
@@ -1148,6 +4390,15 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_http_handlers.UpdateAnnouncementChannelRequest": {
+            "type": "object",
+            "properties": {
+                "slack_channel_id": {
+                    "type": "string",
+                    "example": "C0123456789"
+                }
+            }
+        },
         "internal_http_handlers.UpdateChannelSettingsRequest": {
             "type": "object",
             "required": [
@@ -1160,12 +4411,33 @@ const docTemplate = `{
                 "anniversaries_enabled": {
                     "type": "boolean"
                 },
+                "anniversary_milestones": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "birthdays_enabled": {
                     "type": "boolean"
                 },
+                "max_recipients_per_post": {
+                    "type": "integer"
+                },
+                "min_anniversary_tenure_months": {
+                    "type": "integer"
+                },
+                "post_dispatch_webhook_url": {
+                    "type": "string"
+                },
                 "posting_time": {
                     "type": "string"
                 },
+                "reaction_emoji": {
+                    "type": "string"
+                },
+                "skip_weekends": {
+                    "type": "boolean"
+                },
                 "timezone": {
                     "type": "string"
                 }
@@ -1189,6 +4461,37 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_http_handlers.UpdateLogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.UpdateOnboardingTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "template": {
+                    "type": "string",
+                    "example": "Hi {name}! Reply with your birthday as month day."
+                }
+            }
+        },
+        "internal_http_handlers.UpdatePrivacySettingsRequest": {
+            "type": "object",
+            "required": [
+                "birthday_year_privacy"
+            ],
+            "properties": {
+                "birthday_year_privacy": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_http_handlers.UpsertPersonRequest": {
             "type": "object",
             "required": [
@@ -1225,6 +4528,81 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_http_handlers.WorkspaceListItem": {
+            "type": "object",
+            "properties": {
+                "channels_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "people_count": {
+                    "type": "integer"
+                },
+                "slack_team_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.WorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "anniversaries_enabled": {
+                    "type": "boolean"
+                },
+                "birthdays_enabled": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slack_team_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http_handlers.WorkspacesResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers.WorkspaceListItem"
+                    }
+                }
+            }
+        },
         "slackcheers_internal_domain.Person": {
             "type": "object",
             "properties": {
@@ -1301,6 +4679,12 @@ const docTemplate = `{
                 "anniversariesEnabled": {
                     "type": "boolean"
                 },
+                "announcementChannelID": {
+                    "type": "string"
+                },
+                "birthdayYearPrivacy": {
+                    "type": "string"
+                },
                 "birthdaysEnabled": {
                     "type": "boolean"
                 },
@@ -1316,6 +4700,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "onboardingMessageTemplate": {
+                    "type": "string"
+                },
                 "slackTeamID": {
                     "type": "string"
                 },
@@ -1333,6 +4720,12 @@ const docTemplate = `{
                 "anniversariesEnabled": {
                     "type": "boolean"
                 },
+                "anniversaryMilestones": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "anniversaryTemplate": {
                     "type": "string"
                 },
@@ -1351,9 +4744,27 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "maxRecipientsPerPost": {
+                    "type": "integer"
+                },
+                "minAnniversaryTenureMonths": {
+                    "type": "integer"
+                },
+                "pausedUntil": {
+                    "type": "string"
+                },
+                "postDispatchWebhookURL": {
+                    "type": "string"
+                },
                 "postingTime": {
                     "type": "string"
                 },
+                "reactionEmoji": {
+                    "type": "string"
+                },
+                "skipWeekends": {
+                    "type": "boolean"
+                },
                 "slackChannelID": {
                     "type": "string"
                 },
@@ -1370,6 +4781,51 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "slackcheers_internal_service.ScheduledReminder": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "fires_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "slackcheers_internal_service.WorkspaceImportResult": {
+            "type": "object",
+            "properties": {
+                "channels_imported": {
+                    "type": "integer"
+                },
+                "channels_skipped": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "people_imported": {
+                    "type": "integer"
+                },
+                "people_skipped": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Required on /api/workspaces routes when API_KEYS is set.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -7,24 +7,32 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"slackcheers/internal/config"
 	"slackcheers/internal/database"
 	apphttp "slackcheers/internal/http"
 	"slackcheers/internal/http/handlers"
+	"slackcheers/internal/http/middleware"
 	"slackcheers/internal/repository"
 	"slackcheers/internal/scheduler"
 	"slackcheers/internal/service"
 	"slackcheers/internal/slack"
+
+	"github.com/gin-gonic/gin"
 )
 
 type App struct {
 	cfg       config.Config
 	logger    *slog.Logger
+	logLevel  *slog.LevelVar
 	db        *sql.DB
 	httpSrv   *http.Server
 	scheduler *scheduler.Scheduler
+	// schedulerStarted is nil when the scheduler is disabled.
+	schedulerStarted *atomic.Bool
 }
 
 func New(ctx context.Context) (*App, error) {
@@ -33,48 +41,126 @@ func New(ctx context.Context) (*App, error) {
 		return nil, err
 	}
 
-	logger := newLogger(cfg.App.Environment)
+	logger, logLevel := newLogger(cfg.App.LogPretty)
 
-	db, err := database.OpenPostgres(ctx, cfg.DB)
-	if err != nil {
-		return nil, err
+	if !strings.EqualFold(cfg.App.Environment, "development") {
+		gin.SetMode(gin.ReleaseMode)
 	}
 
+	applied := 0
 	if cfg.DB.AutoMigrate {
-		if err := database.UpMigrations(ctx, db, cfg.DB.MigrationsDir); err != nil {
-			_ = db.Close()
+		if applied, err = autoMigrate(ctx, cfg.DB); err != nil {
 			return nil, err
 		}
-		logger.Info("database migrations applied", slog.String("dir", cfg.DB.MigrationsDir))
 	}
 
-	workspaceRepo := repository.NewWorkspaceRepository(db)
-	peopleRepo := repository.NewPeopleRepository(db)
-	onboardingRepo := repository.NewOnboardingRepository(db)
+	db, err := database.OpenPostgres(ctx, cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := database.PendingCount(ctx, db, cfg.DB.MigrationsDir)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	version, err := database.CurrentVersion(ctx, db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	logger.Info("database ready",
+		slog.Int64("current_version", version),
+		slog.Int("pending_migrations", pending),
+		slog.Int("migrations_applied", applied),
+		slog.Bool("auto_migrate", cfg.DB.AutoMigrate),
+	)
+	if pending > 0 && !cfg.DB.AutoMigrate {
+		_ = db.Close()
+		return nil, fmt.Errorf("database has %d pending migrations and MIGRATIONS_AUTO_APPLY is disabled; run make migrate-up", pending)
+	}
+
+	workspaceRepo := repository.NewWorkspaceRepository(db, cfg.DB.DBQueryTimeout)
+	peopleRepo := repository.NewPeopleRepository(db, cfg.DB.DBQueryTimeout)
+	onboardingRepo := repository.NewOnboardingRepository(db, cfg.DB.DBQueryTimeout)
+	idempotencyRepo := repository.NewIdempotencyRepository(db, cfg.DB.DBQueryTimeout)
+	dispatchLogRepo := repository.NewDispatchLogRepository(db, cfg.DB.DBQueryTimeout)
+	postLogRepo := repository.NewCelebrationPostLogRepository(db, cfg.DB.DBQueryTimeout)
+	reminderLogRepo := repository.NewReminderLogRepository(db, cfg.DB.DBQueryTimeout)
+	digestLogRepo := repository.NewDigestLogRepository(db, cfg.DB.DBQueryTimeout)
 	slackClient, err := slack.NewClient(workspaceRepo, cfg.Slack.BotToken, logger)
 	if err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("build slack client: %w", err)
 	}
 
-	celebrationSvc := service.NewCelebrationService(workspaceRepo, peopleRepo, slackClient, logger)
-	dashboardSvc := service.NewDashboardService(workspaceRepo, peopleRepo)
-	inboundSvc := service.NewSlackInboundService(workspaceRepo, peopleRepo, slackClient, logger)
-	onboardingSvc := service.NewSlackOnboardingService(workspaceRepo, onboardingRepo)
-	dmCleanupSvc := service.NewSlackDMCleanupService(workspaceRepo)
-	channelCleanupSvc := service.NewSlackChannelCleanupService(workspaceRepo)
-	slackChannelsSvc := service.NewSlackChannelsService(workspaceRepo)
-	authSvc := service.NewSlackAuthService(cfg.Slack, workspaceRepo)
+	celebrationSvc := service.NewCelebrationService(workspaceRepo, peopleRepo, postLogRepo, slackClient, logger, cfg.Scheduler.UseScheduledMessages)
+	onboardingSvc := service.NewSlackOnboardingService(workspaceRepo, onboardingRepo, cfg.Slack.SlackAPIPageSize, cfg.Slack.SlackAPIMaxPages, logger)
+	onboardingProgressSvc := service.NewOnboardingProgressService(workspaceRepo, onboardingRepo, peopleRepo)
+	reminderSvc := service.NewReminderService(workspaceRepo, peopleRepo, reminderLogRepo, slackClient, logger)
+	digestSvc := service.NewDigestService(workspaceRepo, peopleRepo, digestLogRepo, slackClient, logger)
+	dmCleanupSvc := service.NewSlackDMCleanupService(workspaceRepo, logger)
+	channelCleanupSvc := service.NewSlackChannelCleanupService(workspaceRepo, logger)
+	slackChannelsSvc := service.NewSlackChannelsService(workspaceRepo, cfg.Slack.SlackAPIPageSize, cfg.Slack.SlackAPIMaxPages, logger)
+	slackConnectionSvc := service.NewSlackConnectionService(workspaceRepo, logger)
+	dashboardSvc := service.NewDashboardService(workspaceRepo, peopleRepo, dispatchLogRepo, postLogRepo, onboardingRepo, slackChannelsSvc, slackClient, logger)
+	inboundSvc := service.NewSlackInboundService(workspaceRepo, peopleRepo, onboardingRepo, dashboardSvc, slackClient, logger)
+	memberSyncSvc := service.NewSlackMemberSyncService(workspaceRepo, peopleRepo, cfg.Slack.SlackAPIPageSize, cfg.Slack.SlackAPIMaxPages, logger)
+	authSvc := service.NewSlackAuthService(cfg.Slack, workspaceRepo, service.NewMemoryStateStore(), memberSyncSvc, slackClient, logger)
+	idempotencySvc := service.NewIdempotencyService(workspaceRepo, idempotencyRepo)
+	dataErasureSvc := service.NewDataErasureService(peopleRepo, dispatchLogRepo, slackClient, logger)
+
+	var schedulerStarted *atomic.Bool
+	var sched *scheduler.Scheduler
+	if cfg.Scheduler.Enabled {
+		schedulerStarted = new(atomic.Bool)
+		sched, err = scheduler.New(celebrationSvc, reminderSvc, digestSvc, idempotencySvc, memberSyncSvc, cfg.Scheduler.PollInterval, cfg.Scheduler.JitterMax, cfg.Scheduler.JitterRange, cfg.Scheduler.MemberSyncInterval, cfg.Scheduler.CronExpression, logger)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
 
-	healthHandler := handlers.NewHealthHandler()
+	healthHandler := handlers.NewHealthHandler(db, schedulerStarted)
 	authHandler := handlers.NewAuthHandler(authSvc, inboundSvc, cfg.Slack.SigningSecret)
-	workspaceHandler := handlers.NewWorkspaceHandler(celebrationSvc, dashboardSvc, onboardingSvc, dmCleanupSvc, channelCleanupSvc, slackChannelsSvc, workspaceRepo)
+	interactiveHandler := handlers.NewSlackInteractiveHandler(inboundSvc, cfg.Slack.SigningSecret, logger)
+	commandHandler := handlers.NewSlackCommandHandler(inboundSvc, cfg.Slack.SigningSecret, logger)
+	workspaceHandler := handlers.NewWorkspaceHandler(handlers.WorkspaceHandlerDependencies{
+		CelebrationService:        celebrationSvc,
+		DashboardService:          dashboardSvc,
+		OnboardingService:         onboardingSvc,
+		OnboardingProgressService: onboardingProgressSvc,
+		ReminderService:           reminderSvc,
+		DMCleanupService:          dmCleanupSvc,
+		ChannelCleanupService:     channelCleanupSvc,
+		SlackChannelsService:      slackChannelsSvc,
+		SlackConnectionService:    slackConnectionSvc,
+		IdempotencyService:        idempotencySvc,
+		DataErasureService:        dataErasureSvc,
+		MemberSyncService:         memberSyncSvc,
+		WorkspaceRepository:       workspaceRepo,
+		Logger:                    logger,
+	})
+	adminHandler := handlers.NewAdminHandler(logLevel, dashboardSvc, logger)
+	schedulerHandler := handlers.NewSchedulerHandler(sched, logger)
 
 	router := apphttp.NewRouter(apphttp.RouterDependencies{
-		Logger:           logger,
-		HealthHandler:    healthHandler,
-		AuthHandler:      authHandler,
-		WorkspaceHandler: workspaceHandler,
+		Logger:               logger,
+		SlowRequestThreshold: cfg.Server.SlowRequestThreshold,
+		MaxRequestBodyBytes:  cfg.Server.MaxRequestBodyBytes,
+		MaxImportBodyBytes:   cfg.Server.MaxImportBodyBytes,
+		AdminAPIKey:          cfg.Server.AdminAPIKey,
+		APIKeys:              cfg.Server.APIKeys,
+		CORSAllowedOrigins:   cfg.Server.CORSAllowedOrigins,
+		WorkspaceRateLimiter: middleware.NewWorkspaceRateLimiter(ctx),
+		MetricsEnabled:       cfg.Server.MetricsEnabled,
+		HealthHandler:        healthHandler,
+		AuthHandler:          authHandler,
+		InteractiveHandler:   interactiveHandler,
+		CommandHandler:       commandHandler,
+		WorkspaceHandler:     workspaceHandler,
+		AdminHandler:         adminHandler,
+		SchedulerHandler:     schedulerHandler,
 	})
 
 	httpSrv := &http.Server{
@@ -86,17 +172,14 @@ func New(ctx context.Context) (*App, error) {
 		IdleTimeout:       60 * time.Second,
 	}
 
-	var sched *scheduler.Scheduler
-	if cfg.Scheduler.Enabled {
-		sched = scheduler.New(celebrationSvc, cfg.Scheduler.PollInterval, logger)
-	}
-
 	return &App{
-		cfg:       cfg,
-		logger:    logger,
-		db:        db,
-		httpSrv:   httpSrv,
-		scheduler: sched,
+		cfg:              cfg,
+		logger:           logger,
+		logLevel:         logLevel,
+		db:               db,
+		httpSrv:          httpSrv,
+		scheduler:        sched,
+		schedulerStarted: schedulerStarted,
 	}, nil
 }
 
@@ -105,7 +188,10 @@ func (a *App) Run(ctx context.Context) error {
 	defer cancel()
 
 	if a.scheduler != nil {
-		go a.scheduler.Run(ctx)
+		go func() {
+			a.schedulerStarted.Store(true)
+			a.scheduler.Run(ctx)
+		}()
 	}
 
 	errCh := make(chan error, 1)
@@ -142,3 +228,22 @@ func (a *App) shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+// autoMigrate applies pending migrations over a separate pool that has no
+// statement_timeout, returning how many were pending.
+func autoMigrate(ctx context.Context, cfg config.DBConfig) (int, error) {
+	db, err := database.OpenMigrationPostgres(ctx, cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	pending, err := database.PendingCount(ctx, db, cfg.MigrationsDir)
+	if err != nil {
+		return 0, err
+	}
+	if err := database.UpMigrations(ctx, db, cfg.MigrationsDir); err != nil {
+		return 0, err
+	}
+	return pending, nil
+}
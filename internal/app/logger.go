@@ -1,17 +1,95 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"os"
-	"strings"
+	"sync"
+
+	"slackcheers/internal/http/middleware"
 )
 
-func newLogger(env string) *slog.Logger {
-	level := slog.LevelInfo
-	if strings.EqualFold(env, "development") {
-		level = slog.LevelDebug
+// newLogger starts at info in every environment; PUT /api/admin/log-level
+// changes the level until the next restart.
+func newLogger(pretty bool) (*slog.Logger, *slog.LevelVar) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler = slog.NewJSONHandler(os.Stdout, opts)
+	if pretty {
+		h = newPrettyJSONHandler(os.Stdout, opts)
+	}
+	return slog.New(requestIDHandler{h}), level
+}
+
+// requestIDHandler adds the HTTP request ID to records logged with a request
+// context, so service logs can be correlated with the request that caused them.
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := middleware.RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
+
+// prettyJSONHandler renders each record with the standard JSON handler and
+// indents it before writing, for readable local logs.
+type prettyJSONHandler struct {
+	slog.Handler
+	mu  *sync.Mutex
+	buf *bytes.Buffer
+	out io.Writer
+}
+
+func newPrettyJSONHandler(out io.Writer, opts *slog.HandlerOptions) *prettyJSONHandler {
+	buf := new(bytes.Buffer)
+	return &prettyJSONHandler{
+		Handler: slog.NewJSONHandler(buf, opts),
+		mu:      new(sync.Mutex),
+		buf:     buf,
+		out:     out,
+	}
+}
+
+func (h *prettyJSONHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buf.Reset()
+	if err := h.Handler.Handle(ctx, r); err != nil {
+		return err
 	}
 
-	h := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
-	return slog.New(h)
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimSpace(h.buf.Bytes()), "", "  "); err != nil {
+		_, err = h.out.Write(h.buf.Bytes())
+		return err
+	}
+	indented.WriteByte('\n')
+
+	_, err := h.out.Write(indented.Bytes())
+	return err
+}
+
+func (h *prettyJSONHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &prettyJSONHandler{Handler: h.Handler.WithAttrs(attrs), mu: h.mu, buf: h.buf, out: h.out}
+}
+
+func (h *prettyJSONHandler) WithGroup(name string) slog.Handler {
+	return &prettyJSONHandler{Handler: h.Handler.WithGroup(name), mu: h.mu, buf: h.buf, out: h.out}
 }
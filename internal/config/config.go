@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,24 +22,46 @@ type Config struct {
 type AppConfig struct {
 	Name        string
 	Environment string
+	LogPretty   bool
 }
 
 type ServerConfig struct {
-	Port string
+	Port                 string
+	AdminAPIKey          string
+	APIKeys              []string
+	CORSAllowedOrigins   []string
+	SlowRequestThreshold time.Duration
+	MaxRequestBodyBytes  int64
+	MaxImportBodyBytes   int64
+	MetricsEnabled       bool
 }
 
 type DBConfig struct {
-	URL             string
-	MaxOpenConns    int
-	MaxIdleConns    int
-	ConnMaxLifetime time.Duration
-	MigrationsDir   string
-	AutoMigrate     bool
+	URL                string
+	MaxOpenConns       int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	DBQueryTimeout     time.Duration
+	DBStatementTimeout time.Duration
+	MigrationsDir      string
+	AutoMigrate        bool
 }
 
 type SchedulerConfig struct {
 	Enabled      bool
 	PollInterval time.Duration
+	JitterMax    time.Duration
+	// JitterRange delays each tick's work by a random amount below it, so
+	// posts may go out up to that long after a channel's posting time.
+	JitterRange time.Duration
+	// CronExpression, a standard 5-field cron expression in UTC, replaces
+	// PollInterval when set.
+	CronExpression string
+	// MemberSyncInterval is how often Slack members are copied into people.
+	MemberSyncInterval time.Duration
+	// UseScheduledMessages hands celebration posts to Slack's
+	// chat.scheduleMessage ahead of the posting time.
+	UseScheduledMessages bool
 }
 
 type SlackConfig struct {
@@ -49,40 +72,67 @@ type SlackConfig struct {
 	UserScopes    string
 	BotToken      string
 	SigningSecret string
+
+	// SlackAPIPageSize is the limit sent on paginated list calls;
+	// SlackAPIMaxPages caps how many pages are followed.
+	SlackAPIPageSize int
+	SlackAPIMaxPages int
 }
 
 func Load() (Config, error) {
 	// Load .env file if it exists (ignore error for production where env vars are set directly)
 	_ = godotenv.Load()
 
+	environment := getEnv("APP_ENV", "development")
+	// The server-side statement_timeout backs up the client-side query
+	// deadline, so by default it matches it rather than cutting queries short.
+	queryTimeout := getDuration("DB_QUERY_TIMEOUT", 10*time.Second)
 	cfg := Config{
 		App: AppConfig{
 			Name:        getEnv("APP_NAME", "slackcheers"),
-			Environment: getEnv("APP_ENV", "development"),
+			Environment: environment,
+			LogPretty:   getBool("APP_LOG_PRETTY", strings.EqualFold(environment, "development")),
 		},
 		Server: ServerConfig{
-			Port: getEnv("APP_PORT", "9060"),
+			Port:                 getEnv("APP_PORT", "9060"),
+			AdminAPIKey:          strings.TrimSpace(os.Getenv("ADMIN_API_KEY")),
+			APIKeys:              getList("API_KEYS"),
+			CORSAllowedOrigins:   getList("CORS_ALLOWED_ORIGINS"),
+			SlowRequestThreshold: time.Duration(getInt("SLOW_REQUEST_THRESHOLD_MS", 2000)) * time.Millisecond,
+			MaxRequestBodyBytes:  int64(getInt("MAX_REQUEST_BODY_BYTES", 1<<20)),
+			MaxImportBodyBytes:   int64(getInt("MAX_IMPORT_BODY_BYTES", 32<<20)),
+			MetricsEnabled:       getBool("METRICS_ENABLED", false),
 		},
 		DB: DBConfig{
-			URL:             strings.TrimSpace(os.Getenv("DATABASE_URL")),
-			MaxOpenConns:    getInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getInt("DB_MAX_IDLE_CONNS", 25),
-			ConnMaxLifetime: getDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
-			MigrationsDir:   getEnv("MIGRATIONS_DIR", "db/migrations"),
-			AutoMigrate:     getBool("MIGRATIONS_AUTO_APPLY", true),
+			URL:                strings.TrimSpace(os.Getenv("DATABASE_URL")),
+			MaxOpenConns:       getInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:       getInt("DB_MAX_IDLE_CONNS", 25),
+			ConnMaxLifetime:    getDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			DBQueryTimeout:     queryTimeout,
+			DBStatementTimeout: getDuration("DB_STATEMENT_TIMEOUT", queryTimeout),
+			MigrationsDir:      getEnv("MIGRATIONS_DIR", "db/migrations"),
+			AutoMigrate:        getBool("MIGRATIONS_AUTO_APPLY", true),
 		},
 		Scheduler: SchedulerConfig{
-			Enabled:      getBool("SCHEDULER_ENABLED", true),
-			PollInterval: getDuration("SCHEDULER_POLL_INTERVAL", time.Minute),
+			Enabled:              getBool("SCHEDULER_ENABLED", true),
+			PollInterval:         getDuration("SCHEDULER_POLL_INTERVAL", time.Minute),
+			JitterMax:            getDuration("SCHEDULER_JITTER_MAX", 30*time.Second),
+			JitterRange:          getDuration("SCHEDULER_JITTER_RANGE", 10*time.Second),
+			CronExpression:       strings.TrimSpace(os.Getenv("SCHEDULER_CRON")),
+			MemberSyncInterval:   getDuration("MEMBER_SYNC_INTERVAL", time.Hour),
+			UseScheduledMessages: getBool("USE_SCHEDULED_MESSAGES", false),
 		},
 		Slack: SlackConfig{
 			ClientID:      strings.TrimSpace(os.Getenv("SLACK_CLIENT_ID")),
 			ClientSecret:  strings.TrimSpace(os.Getenv("SLACK_CLIENT_SECRET")),
 			RedirectURL:   strings.TrimSpace(os.Getenv("SLACK_REDIRECT_URL")),
-			BotScopes:     getEnv("SLACK_BOT_SCOPES", "chat:write,channels:read,channels:join,channels:history,users:read,im:write,im:history"),
+			BotScopes:     getEnv("SLACK_BOT_SCOPES", "chat:write,channels:read,channels:join,channels:history,users:read,im:write,im:history,commands,reactions:write,app_mentions:read"),
 			UserScopes:    strings.TrimSpace(os.Getenv("SLACK_USER_SCOPES")),
 			BotToken:      strings.TrimSpace(os.Getenv("SLACK_BOT_TOKEN")),
 			SigningSecret: strings.TrimSpace(os.Getenv("SLACK_SIGNING_SECRET")),
+
+			SlackAPIPageSize: getInt("SLACK_API_PAGE_SIZE", 200),
+			SlackAPIMaxPages: getInt("SLACK_API_MAX_PAGES", 50),
 		},
 	}
 
@@ -90,6 +140,25 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("DATABASE_URL is required")
 	}
 
+	if cfg.Scheduler.JitterRange >= cfg.Scheduler.PollInterval {
+		if _, set := os.LookupEnv("SCHEDULER_JITTER_RANGE"); set {
+			return Config{}, fmt.Errorf("SCHEDULER_JITTER_RANGE must be shorter than SCHEDULER_POLL_INTERVAL")
+		}
+		cfg.Scheduler.JitterRange = cfg.Scheduler.PollInterval / 2
+	}
+
+	if _, set := os.LookupEnv("CORS_ALLOWED_ORIGINS"); !set && strings.EqualFold(environment, "development") {
+		cfg.Server.CORSAllowedOrigins = []string{"*"}
+	}
+	// APIKeyAuth lets every request through without keys, which would expose
+	// the workspace list and every workspace's data.
+	if !strings.EqualFold(environment, "development") && len(cfg.Server.APIKeys) == 0 {
+		return Config{}, fmt.Errorf("API_KEYS is required unless APP_ENV=development")
+	}
+	if strings.EqualFold(environment, "production") && slices.Contains(cfg.Server.CORSAllowedOrigins, "*") {
+		return Config{}, fmt.Errorf("CORS_ALLOWED_ORIGINS cannot be * in production")
+	}
+
 	return cfg, nil
 }
 
@@ -127,6 +196,17 @@ func getBool(key string, fallback bool) bool {
 	return parsed
 }
 
+// getList splits a comma-separated variable, dropping blank entries.
+func getList(key string) []string {
+	var out []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func getDuration(key string, fallback time.Duration) time.Duration {
 	val := strings.TrimSpace(os.Getenv(key))
 	if val == "" {
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,9 @@ import (
 	"strings"
 )
 
+// ErrNoAppliedMigrations is returned by a rollback that finds nothing to undo.
+var ErrNoAppliedMigrations = errors.New("no applied migrations to roll back")
+
 type migrationFile struct {
 	Version  int64
 	Name     string
@@ -37,46 +41,229 @@ func UpMigrations(ctx context.Context, db *sql.DB, migrationsDir string) error {
 		if applied[m.Version] {
 			continue
 		}
+		if err := applyUpMigration(ctx, db, m); err != nil {
+			return err
+		}
+	}
 
-		content, err := os.ReadFile(m.UpPath)
-		if err != nil {
-			return fmt.Errorf("read up migration %s: %w", m.UpPath, err)
+	return nil
+}
+
+// MigrateToVersion moves the schema up or down until it is exactly at target.
+// Target must match a migration file, or be 0 to roll everything back.
+func MigrateToVersion(ctx context.Context, db *sql.DB, migrationsDir string, target int64) error {
+	stepper, err := newSQLMigrationStepper(ctx, db, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	current, err := stepper.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planMigrateToVersion(stepper.migrations, applied, current, target)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range plan.up {
+		if err := applyUpMigration(ctx, db, m); err != nil {
+			return err
 		}
+	}
+	if plan.down {
+		return downTo(ctx, stepper, target)
+	}
+	return nil
+}
 
-		tx, err := db.BeginTx(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("begin tx for migration %d: %w", m.Version, err)
+type migrateToPlan struct {
+	up   []migrationFile
+	down bool
+}
+
+func planMigrateToVersion(migrations []migrationFile, applied map[int64]bool, current, target int64) (migrateToPlan, error) {
+	if target == current {
+		return migrateToPlan{}, nil
+	}
+	if target < 0 {
+		return migrateToPlan{}, fmt.Errorf("target migration version must not be negative, got %d", target)
+	}
+	if target != 0 && !hasMigrationVersion(migrations, target) {
+		return migrateToPlan{}, fmt.Errorf("no migration file found for target version %d", target)
+	}
+
+	if target < current {
+		return migrateToPlan{down: true}, nil
+	}
+
+	plan := migrateToPlan{}
+	for _, m := range migrations {
+		if m.Version > target {
+			break
 		}
+		if !applied[m.Version] {
+			plan.up = append(plan.up, m)
+		}
+	}
+	return plan, nil
+}
 
-		if _, err := tx.ExecContext(ctx, string(content)); err != nil {
-			_ = tx.Rollback()
-			return fmt.Errorf("apply up migration %d: %w", m.Version, err)
+func hasMigrationVersion(migrations []migrationFile, version int64) bool {
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
 		}
+	}
+	return false
+}
+
+func applyUpMigration(ctx context.Context, db *sql.DB, m migrationFile) error {
+	content, err := os.ReadFile(m.UpPath)
+	if err != nil {
+		return fmt.Errorf("read up migration %s: %w", m.UpPath, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx for migration %d: %w", m.Version, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("apply up migration %d: %w", m.Version, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("record migration %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit migration %d: %w", m.Version, err)
+	}
+
+	return nil
+}
 
-		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
-			_ = tx.Rollback()
-			return fmt.Errorf("record migration %d: %w", m.Version, err)
+func DownOneMigration(ctx context.Context, db *sql.DB, migrationsDir string) error {
+	return DownNMigrations(ctx, db, migrationsDir, 1)
+}
+
+// DownNMigrations rolls back the last n applied migrations, newest first. An n
+// of -1 rolls back every applied migration. It returns ErrNoAppliedMigrations
+// when no migration is applied.
+func DownNMigrations(ctx context.Context, db *sql.DB, migrationsDir string, n int) error {
+	stepper, err := newSQLMigrationStepper(ctx, db, migrationsDir)
+	if err != nil {
+		return err
+	}
+	return downN(ctx, stepper, n)
+}
+
+// DownToVersion rolls back migrations until the current version is at or below
+// targetVersion.
+func DownToVersion(ctx context.Context, db *sql.DB, migrationsDir string, targetVersion int64) error {
+	stepper, err := newSQLMigrationStepper(ctx, db, migrationsDir)
+	if err != nil {
+		return err
+	}
+	return downTo(ctx, stepper, targetVersion)
+}
+
+type migrationStepper interface {
+	CurrentVersion(ctx context.Context) (int64, error)
+	DownOne(ctx context.Context) error
+}
+
+func downN(ctx context.Context, stepper migrationStepper, n int) error {
+	if n == 0 || n < -1 {
+		return fmt.Errorf("down migration count must be positive or -1 for all, got %d", n)
+	}
+
+	for i := 0; n == -1 || i < n; i++ {
+		version, err := stepper.CurrentVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			if i == 0 {
+				return ErrNoAppliedMigrations
+			}
+			return nil
 		}
+		if err := downStep(ctx, stepper, version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func downTo(ctx context.Context, stepper migrationStepper, targetVersion int64) error {
+	if targetVersion < 0 {
+		return fmt.Errorf("target migration version must not be negative, got %d", targetVersion)
+	}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("commit migration %d: %w", m.Version, err)
+	for {
+		version, err := stepper.CurrentVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if version <= targetVersion {
+			return nil
+		}
+		if err := downStep(ctx, stepper, version); err != nil {
+			return err
 		}
 	}
+}
+
+func downStep(ctx context.Context, stepper migrationStepper, version int64) error {
+	if err := stepper.DownOne(ctx); err != nil {
+		return err
+	}
 
+	after, err := stepper.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if after >= version {
+		return fmt.Errorf("down migration %d did not lower the current version", version)
+	}
 	return nil
 }
 
-func DownOneMigration(ctx context.Context, db *sql.DB, migrationsDir string) error {
+type sqlMigrationStepper struct {
+	db         *sql.DB
+	migrations []migrationFile
+}
+
+func newSQLMigrationStepper(ctx context.Context, db *sql.DB, migrationsDir string) (*sqlMigrationStepper, error) {
 	if err := ensureMigrationsTable(ctx, db); err != nil {
-		return err
+		return nil, err
 	}
 
 	migrations, err := loadMigrations(migrationsDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	version, err := currentVersion(ctx, db)
+	return &sqlMigrationStepper{db: db, migrations: migrations}, nil
+}
+
+func (s *sqlMigrationStepper) CurrentVersion(ctx context.Context) (int64, error) {
+	return CurrentVersion(ctx, s.db)
+}
+
+func (s *sqlMigrationStepper) DownOne(ctx context.Context) error {
+	version, err := CurrentVersion(ctx, s.db)
 	if err != nil {
 		return err
 	}
@@ -85,9 +272,9 @@ func DownOneMigration(ctx context.Context, db *sql.DB, migrationsDir string) err
 	}
 
 	var target *migrationFile
-	for i := range migrations {
-		if migrations[i].Version == version {
-			target = &migrations[i]
+	for i := range s.migrations {
+		if s.migrations[i].Version == version {
+			target = &s.migrations[i]
 			break
 		}
 	}
@@ -103,7 +290,7 @@ func DownOneMigration(ctx context.Context, db *sql.DB, migrationsDir string) err
 		return fmt.Errorf("read down migration %s: %w", target.DownPath, err)
 	}
 
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin tx for down migration %d: %w", target.Version, err)
 	}
@@ -135,7 +322,7 @@ func MigrationStatus(ctx context.Context, db *sql.DB, migrationsDir string) (str
 		return "", err
 	}
 
-	version, err := currentVersion(ctx, db)
+	version, err := CurrentVersion(ctx, db)
 	if err != nil {
 		return "", err
 	}
@@ -148,6 +335,36 @@ func MigrationStatus(ctx context.Context, db *sql.DB, migrationsDir string) (str
 	return fmt.Sprintf("current=%d latest=%d", version, latest), nil
 }
 
+// PendingCount returns how many migrations in migrationsDir have not been
+// applied yet.
+func PendingCount(ctx context.Context, db *sql.DB, migrationsDir string) (int, error) {
+	if err := ensureMigrationsTable(ctx, db); err != nil {
+		return 0, err
+	}
+
+	migrations, err := loadMigrations(migrationsDir)
+	if err != nil {
+		return 0, err
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	return countPending(migrations, applied), nil
+}
+
+func countPending(migrations []migrationFile, applied map[int64]bool) int {
+	pending := 0
+	for _, m := range migrations {
+		if !applied[m.Version] {
+			pending++
+		}
+	}
+	return pending
+}
+
 func ensureMigrationsTable(ctx context.Context, db *sql.DB) error {
 	const q = `
 CREATE TABLE IF NOT EXISTS schema_migrations (
@@ -162,7 +379,7 @@ CREATE TABLE IF NOT EXISTS schema_migrations (
 	return nil
 }
 
-func currentVersion(ctx context.Context, db *sql.DB) (int64, error) {
+func CurrentVersion(ctx context.Context, db *sql.DB) (int64, error) {
 	const q = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
 	var version int64
 	if err := db.QueryRowContext(ctx, q).Scan(&version); err != nil {
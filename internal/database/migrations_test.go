@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type fakeStepper struct {
+	applied    []int64
+	rolledBack []int64
+	failAt     int64
+}
+
+func (f *fakeStepper) CurrentVersion(context.Context) (int64, error) {
+	if len(f.applied) == 0 {
+		return 0, nil
+	}
+	return f.applied[len(f.applied)-1], nil
+}
+
+func (f *fakeStepper) DownOne(context.Context) error {
+	if len(f.applied) == 0 {
+		return nil
+	}
+	version := f.applied[len(f.applied)-1]
+	if version == f.failAt {
+		return errors.New("boom")
+	}
+	f.applied = f.applied[:len(f.applied)-1]
+	f.rolledBack = append(f.rolledBack, version)
+	return nil
+}
+
+func TestDownN(t *testing.T) {
+	tests := []struct {
+		name          string
+		applied       []int64
+		n             int
+		wantRolled    []int64
+		wantVersion   int64
+		wantErr       bool
+		wantNoApplied bool
+		failAtVersion int64
+	}{
+		{name: "down one", applied: []int64{1, 2, 3}, n: 1, wantRolled: []int64{3}, wantVersion: 2},
+		{name: "down three", applied: []int64{1, 2, 3, 4}, n: 3, wantRolled: []int64{4, 3, 2}, wantVersion: 1},
+		{name: "more than applied stops at zero", applied: []int64{1, 2}, n: 5, wantRolled: []int64{2, 1}, wantVersion: 0},
+		{name: "minus one rolls back all", applied: []int64{1, 2, 3}, n: -1, wantRolled: []int64{3, 2, 1}, wantVersion: 0},
+		{name: "max int rolls back all", applied: []int64{1, 2, 3}, n: math.MaxInt, wantRolled: []int64{3, 2, 1}, wantVersion: 0},
+		{name: "nothing applied", applied: nil, n: 2, wantRolled: nil, wantVersion: 0, wantErr: true, wantNoApplied: true},
+		{name: "nothing applied with max int", applied: nil, n: math.MaxInt, wantRolled: nil, wantVersion: 0, wantErr: true, wantNoApplied: true},
+		{name: "zero is rejected", applied: []int64{1}, n: 0, wantVersion: 1, wantErr: true},
+		{name: "below minus one is rejected", applied: []int64{1}, n: -2, wantVersion: 1, wantErr: true},
+		{name: "failure stops rollback", applied: []int64{1, 2, 3}, n: 3, failAtVersion: 2, wantRolled: []int64{3}, wantVersion: 2, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stepper := &fakeStepper{applied: tc.applied, failAt: tc.failAtVersion}
+			err := downN(context.Background(), stepper, tc.n)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("downN error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := errors.Is(err, ErrNoAppliedMigrations); got != tc.wantNoApplied {
+				t.Fatalf("downN error = %v, want ErrNoAppliedMigrations %v", err, tc.wantNoApplied)
+			}
+			if !reflect.DeepEqual(stepper.rolledBack, tc.wantRolled) {
+				t.Fatalf("rolled back %v, want %v", stepper.rolledBack, tc.wantRolled)
+			}
+			if got, _ := stepper.CurrentVersion(context.Background()); got != tc.wantVersion {
+				t.Fatalf("current version %d, want %d", got, tc.wantVersion)
+			}
+		})
+	}
+}
+
+func TestDownTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		applied     []int64
+		target      int64
+		wantRolled  []int64
+		wantVersion int64
+		wantErr     bool
+	}{
+		{name: "rolls back to target", applied: []int64{1, 2, 3, 4}, target: 2, wantRolled: []int64{4, 3}, wantVersion: 2},
+		{name: "already at target", applied: []int64{1, 2}, target: 2, wantRolled: nil, wantVersion: 2},
+		{name: "already below target", applied: []int64{1}, target: 5, wantRolled: nil, wantVersion: 1},
+		{name: "target between versions", applied: []int64{10, 20, 30}, target: 15, wantRolled: []int64{30, 20}, wantVersion: 10},
+		{name: "target zero rolls back all", applied: []int64{1, 2}, target: 0, wantRolled: []int64{2, 1}, wantVersion: 0},
+		{name: "negative target is rejected", applied: []int64{1}, target: -1, wantVersion: 1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stepper := &fakeStepper{applied: tc.applied}
+			err := downTo(context.Background(), stepper, tc.target)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("downTo error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(stepper.rolledBack, tc.wantRolled) {
+				t.Fatalf("rolled back %v, want %v", stepper.rolledBack, tc.wantRolled)
+			}
+			if got, _ := stepper.CurrentVersion(context.Background()); got != tc.wantVersion {
+				t.Fatalf("current version %d, want %d", got, tc.wantVersion)
+			}
+		})
+	}
+}
+
+func TestPlanMigrateToVersion(t *testing.T) {
+	migrations := []migrationFile{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+
+	tests := []struct {
+		name     string
+		applied  map[int64]bool
+		current  int64
+		target   int64
+		wantUp   []int64
+		wantDown bool
+		wantErr  bool
+	}{
+		{name: "up to target", applied: map[int64]bool{1: true}, current: 1, target: 3, wantUp: []int64{2, 3}},
+		{name: "up fills gaps below target", applied: map[int64]bool{1: true, 3: true}, current: 3, target: 4, wantUp: []int64{2, 4}},
+		{name: "down to target", applied: map[int64]bool{1: true, 2: true, 3: true}, current: 3, target: 1, wantDown: true},
+		{name: "down to zero", applied: map[int64]bool{1: true}, current: 1, target: 0, wantDown: true},
+		{name: "already at target", applied: map[int64]bool{1: true, 2: true}, current: 2, target: 2},
+		{name: "unknown target", applied: map[int64]bool{1: true}, current: 1, target: 7, wantErr: true},
+		{name: "negative target", applied: map[int64]bool{}, current: 0, target: -1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := planMigrateToVersion(migrations, tc.applied, tc.current, tc.target)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("planMigrateToVersion error = %v, wantErr %v", err, tc.wantErr)
+			}
+			var gotUp []int64
+			for _, m := range plan.up {
+				gotUp = append(gotUp, m.Version)
+			}
+			if !reflect.DeepEqual(gotUp, tc.wantUp) {
+				t.Fatalf("up migrations %v, want %v", gotUp, tc.wantUp)
+			}
+			if plan.down != tc.wantDown {
+				t.Fatalf("down = %v, want %v", plan.down, tc.wantDown)
+			}
+		})
+	}
+}
+
+type stuckStepper struct{}
+
+func (stuckStepper) CurrentVersion(context.Context) (int64, error) { return 3, nil }
+func (stuckStepper) DownOne(context.Context) error                 { return nil }
+
+func TestDownStepRejectsNoProgress(t *testing.T) {
+	if err := downN(context.Background(), stuckStepper{}, -1); err == nil {
+		t.Fatal("expected an error when a down migration does not lower the version")
+	}
+	if err := downTo(context.Background(), stuckStepper{}, 0); err == nil {
+		t.Fatal("expected an error when a down migration does not lower the version")
+	}
+}
+
+func TestCountPending(t *testing.T) {
+	migrations := []migrationFile{{Version: 1}, {Version: 2}, {Version: 3}}
+
+	tests := []struct {
+		name    string
+		applied map[int64]bool
+		want    int
+	}{
+		{name: "none applied", applied: map[int64]bool{}, want: 3},
+		{name: "some applied", applied: map[int64]bool{1: true, 2: true}, want: 1},
+		{name: "all applied", applied: map[int64]bool{1: true, 2: true, 3: true}, want: 0},
+		{name: "gap in applied", applied: map[int64]bool{1: true, 3: true}, want: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := countPending(migrations, tc.applied); got != tc.want {
+				t.Fatalf("countPending = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
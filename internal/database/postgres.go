@@ -4,17 +4,32 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"slackcheers/internal/config"
 )
 
+// OpenPostgres opens the application pool. Every connection gets the
+// configured statement_timeout.
 func OpenPostgres(ctx context.Context, cfg config.DBConfig) (*sql.DB, error) {
-	db, err := sql.Open("pgx", cfg.URL)
+	return openPostgres(ctx, cfg, cfg.DBStatementTimeout)
+}
+
+// OpenMigrationPostgres opens a pool for running migrations. It leaves
+// statement_timeout at the server default so long DDL is not cut off.
+func OpenMigrationPostgres(ctx context.Context, cfg config.DBConfig) (*sql.DB, error) {
+	return openPostgres(ctx, cfg, 0)
+}
+
+func openPostgres(ctx context.Context, cfg config.DBConfig, statementTimeout time.Duration) (*sql.DB, error) {
+	connConfig, err := postgresConnConfig(cfg.URL, statementTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("open postgres: %w", err)
+		return nil, err
 	}
+	db := stdlib.OpenDB(*connConfig)
 
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -30,3 +45,19 @@ func OpenPostgres(ctx context.Context, cfg config.DBConfig) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// postgresConnConfig parses the DSN and sets statement_timeout as a startup
+// parameter so it applies to every pooled connection, not just the first one.
+// A zero timeout keeps the server default.
+func postgresConnConfig(url string, statementTimeout time.Duration) (*pgx.ConnConfig, error) {
+	connConfig, err := pgx.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse postgres url: %w", err)
+	}
+
+	if statementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(statementTimeout.Milliseconds(), 10)
+	}
+
+	return connConfig, nil
+}
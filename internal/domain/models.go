@@ -2,32 +2,60 @@ package domain
 
 import "time"
 
+const (
+	BirthdayYearPrivacyStore   = "store"
+	BirthdayYearPrivacyDiscard = "discard"
+)
+
+// Steps of the guided onboarding DM conversation, stored in
+// people.onboarding_state.
+const (
+	OnboardingStateAwaitingBirthday = "awaiting_birthday"
+	OnboardingStateAwaitingHireDate = "awaiting_hire_date"
+	OnboardingStateComplete         = "complete"
+)
+
+const (
+	CelebrationTypeBirthday    = "birthday"
+	CelebrationTypeAnniversary = "anniversary"
+)
+
 type Workspace struct {
-	ID                   string
-	SlackTeamID          string
-	Name                 string
-	Timezone             string
-	BirthdaysEnabled     bool
-	AnniversariesEnabled bool
-	DefaultTemplateStyle string
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	ID                        string
+	SlackTeamID               string
+	Name                      string
+	Timezone                  string
+	BirthdaysEnabled          bool
+	AnniversariesEnabled      bool
+	DefaultTemplateStyle      string
+	BirthdayYearPrivacy       string
+	AnnouncementChannelID     string
+	OnboardingMessageTemplate string
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
 }
 
 type WorkspaceChannel struct {
-	ID                   string
-	WorkspaceID          string
-	SlackChannelID       string
-	SlackChannelName     string
-	PostingTime          string
-	Timezone             string
-	BirthdaysEnabled     bool
-	AnniversariesEnabled bool
-	BirthdayTemplate     string
-	AnniversaryTemplate  string
-	BrandingEmoji        string
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	ID                         string
+	WorkspaceID                string
+	SlackChannelID             string
+	SlackChannelName           string
+	PostingTime                string
+	Timezone                   string
+	BirthdaysEnabled           bool
+	AnniversariesEnabled       bool
+	BirthdayTemplate           string
+	AnniversaryTemplate        string
+	BrandingEmoji              string
+	MinAnniversaryTenureMonths int
+	PostDispatchWebhookURL     string
+	MaxRecipientsPerPost       int
+	AnniversaryMilestones      []int
+	SkipWeekends               bool
+	ReactionEmoji              string
+	PausedUntil                *time.Time
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
 }
 
 type Person struct {
@@ -66,3 +94,30 @@ type AnniversaryPerson struct {
 	Person
 	Years int
 }
+
+type DispatchLogEntry struct {
+	ID                  int64
+	WorkspaceChannelID  string
+	SlackChannelID      string
+	DispatchDate        time.Time
+	BirthdayCount       int
+	AnniversaryCount    int
+	BirthdayUserIDs     []string
+	AnniversaryUserIDs  []string
+	MessageTS           string
+	MessageTimestamps   []string
+	MessageURL          string
+	ScheduledMessageIDs []string
+	CreatedAt           time.Time
+}
+
+type CelebrationPostLogEntry struct {
+	ID                 int64
+	WorkspaceChannelID string
+	SlackChannelID     string
+	CelebrationType    string
+	SlackUserIDs       []string
+	MessageText        string
+	DispatchedAt       time.Time
+	SlackMessageTS     string
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"slackcheers/internal/service"
+)
+
+type AdminHandler struct {
+	logLevel  *slog.LevelVar
+	dashboard *service.DashboardService
+	logger    *slog.Logger
+}
+
+func NewAdminHandler(logLevel *slog.LevelVar, dashboard *service.DashboardService, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		logLevel:  logLevel,
+		dashboard: dashboard,
+		logger:    logger,
+	}
+}
+
+// UpdateLogLevel godoc
+// @Summary Change the application log level
+// @Description Updates the log level at runtime. The level resets to info on restart.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-API-Key header string true "Admin API key"
+// @Param request body UpdateLogLevelRequest true "Log level payload"
+// @Success 200 {object} LogLevelResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/admin/log-level [put]
+func (h *AdminHandler) UpdateLogLevel(c *gin.Context) {
+	var req UpdateLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	level, ok := parseLogLevel(req.Level)
+	if !ok {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "level must be debug|info|warn|error"})
+		return
+	}
+
+	previous := h.logLevel.Level()
+	h.logLevel.Set(level)
+	h.logger.WarnContext(c.Request.Context(), "log level changed",
+		slog.String("previous_level", strings.ToLower(previous.String())),
+		slog.String("level", strings.ToLower(level.String())),
+	)
+
+	c.JSON(http.StatusOK, LogLevelResponse{
+		PreviousLevel: strings.ToLower(previous.String()),
+		Level:         strings.ToLower(level.String()),
+	})
+}
+
+// Birthdays godoc
+// @Summary List birthdays across all workspaces
+// @Description Returns every person with a birthday on the given month and day, grouped by workspace ID.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-API-Key header string true "Admin API key"
+// @Param month query int true "Birthday month (1-12)"
+// @Param day query int true "Birthday day (1-31)"
+// @Success 200 {object} AdminBirthdaysResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/admin/birthdays [get]
+func (h *AdminHandler) Birthdays(c *gin.Context) {
+	month, err := strconv.Atoi(c.Query("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "month must be an integer"})
+		return
+	}
+	day, err := strconv.Atoi(c.Query("day"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "day must be an integer"})
+		return
+	}
+
+	workspaces, err := h.dashboard.BirthdaysAcrossWorkspaces(c.Request.Context(), month, day)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
+}
+
+func parseLogLevel(raw string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
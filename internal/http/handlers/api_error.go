@@ -0,0 +1,19 @@
+package handlers
+
+// APIError is the body of every error response. Message keeps the "error" key
+// so clients that only read the message keep working.
+type APIError struct {
+	Code    string         `json:"code"`
+	Message string         `json:"error"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+const (
+	ErrCodeBadRequest    = "bad_request"
+	ErrCodeUnauthorized  = "unauthorized"
+	ErrCodeNotFound      = "not_found"
+	ErrCodeConflict      = "conflict"
+	ErrCodeNotConnected  = "not_connected"
+	ErrCodeSlackAPIError = "slack_api_error"
+	ErrCodeInternalError = "internal_error"
+)
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -16,6 +17,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// oauthStateCookie carries the signed OAuth state from /auth/slack/install to
+// the callback so the flow can only be completed by the browser that began it.
+const oauthStateCookie = "slackcheers_oauth_state"
+
+const oauthStateCookiePath = "/auth/slack"
+
 type AuthHandler struct {
 	authService    *service.SlackAuthService
 	inboundService *service.SlackInboundService
@@ -36,23 +43,26 @@ func NewAuthHandler(
 
 // SlackInstall godoc
 // @Summary Start Slack install
-// @Description Redirects to Slack OAuth consent page. Use mode=json to return URL without redirect.
+// @Description Redirects to Slack OAuth consent page. Use mode=json to return URL without redirect. Sets a signed, HttpOnly state cookie that the callback requires.
 // @Tags auth
 // @Produce json
-// @Param state query string false "Opaque CSRF state"
 // @Param mode query string false "Set to json to return install URL"
 // @Success 200 {object} SlackInstallURLResponse
 // @Success 307 {string} string "Temporary Redirect"
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/slack/install [get]
 func (h *AuthHandler) SlackInstall(c *gin.Context) {
-	state := strings.TrimSpace(c.Query("state"))
-	installURL, err := h.authService.InstallURL(state)
+	installURL, state, err := h.authService.InstallURL()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, h.authService.StateCookie(state), int(service.OAuthStateTTL.Seconds()),
+		oauthStateCookiePath, "", strings.HasPrefix(h.authService.RedirectURL(), "https://"), true)
+
 	if strings.EqualFold(strings.TrimSpace(c.Query("mode")), "json") {
 		c.JSON(http.StatusOK, SlackInstallURLResponse{
 			InstallURL: installURL,
@@ -70,7 +80,7 @@ func (h *AuthHandler) SlackInstall(c *gin.Context) {
 // @Tags auth
 // @Produce json
 // @Param code query string true "Slack OAuth code"
-// @Param state query string false "Opaque CSRF state"
+// @Param state query string true "State issued by /auth/slack/install; must match the state cookie"
 // @Param error query string false "Slack OAuth error"
 // @Success 200 {object} SlackConnectResponse
 // @Failure 400 {object} ErrorResponse
@@ -78,19 +88,27 @@ func (h *AuthHandler) SlackInstall(c *gin.Context) {
 // @Router /auth/slack/callback [get]
 func (h *AuthHandler) SlackOAuthCallback(c *gin.Context) {
 	if oauthErr := strings.TrimSpace(c.Query("error")); oauthErr != "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "slack oauth denied: " + oauthErr})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "slack oauth denied: " + oauthErr})
 		return
 	}
 
 	code := strings.TrimSpace(c.Query("code"))
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing oauth code"})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "missing oauth code"})
 		return
 	}
 
-	result, err := h.authService.ExchangeCode(c.Request.Context(), code)
+	stateCookie, _ := c.Cookie(oauthStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, "", -1, oauthStateCookiePath, "", strings.HasPrefix(h.authService.RedirectURL(), "https://"), true)
+
+	result, err := h.authService.ExchangeCode(c.Request.Context(), code, c.Query("state"), stateCookie)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrInvalidOAuthState) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -121,25 +139,25 @@ func (h *AuthHandler) SlackOAuthCallback(c *gin.Context) {
 func (h *AuthHandler) SlackEvents(c *gin.Context) {
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "failed to read request body"})
 		return
 	}
 
 	if strings.TrimSpace(h.signingSecret) == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "SLACK_SIGNING_SECRET is required for events endpoint"})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "SLACK_SIGNING_SECRET is required for events endpoint"})
 		return
 	}
 
 	timestamp := c.GetHeader("X-Slack-Request-Timestamp")
 	signature := c.GetHeader("X-Slack-Signature")
 	if !isValidSlackSignature(h.signingSecret, timestamp, signature, body) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid slack signature"})
+		c.JSON(http.StatusUnauthorized, APIError{Code: ErrCodeUnauthorized, Message: "invalid slack signature"})
 		return
 	}
 
 	var payload SlackEventEnvelope
 	if err := json.Unmarshal(body, &payload); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid json payload"})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid json payload"})
 		return
 	}
 
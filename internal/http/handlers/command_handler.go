@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"slackcheers/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slashCommandTimeout bounds the background work for a command. Slack accepts
+// response_url posts for up to 30 minutes, but a command should finish quickly.
+const slashCommandTimeout = 30 * time.Second
+
+type SlackCommandHandler struct {
+	inboundService *service.SlackInboundService
+	signingSecret  string
+	logger         *slog.Logger
+}
+
+func NewSlackCommandHandler(inboundService *service.SlackInboundService, signingSecret string, logger *slog.Logger) *SlackCommandHandler {
+	return &SlackCommandHandler{
+		inboundService: inboundService,
+		signingSecret:  strings.TrimSpace(signingSecret),
+		logger:         logger,
+	}
+}
+
+// SlackCommands godoc
+// @Summary Slack slash command webhook
+// @Description Verifies Slack signatures and handles /birthday. "status" replies with the saved birthday and hire date; any other text is parsed like a DM reply and saved. The reply is posted to response_url after an immediate ephemeral acknowledgement.
+// @Tags slack
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param team_id formData string true "Slack team ID"
+// @Param user_id formData string true "Slack user ID"
+// @Param text formData string false "Command text"
+// @Param response_url formData string true "Slack response URL"
+// @Success 200 {object} SlackCommandAckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /slack/commands [post]
+func (h *SlackCommandHandler) SlackCommands(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "failed to read request body"})
+		return
+	}
+
+	if h.signingSecret == "" {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "SLACK_SIGNING_SECRET is required for commands endpoint"})
+		return
+	}
+
+	timestamp := c.GetHeader("X-Slack-Request-Timestamp")
+	signature := c.GetHeader("X-Slack-Signature")
+	if !isValidSlackSignature(h.signingSecret, timestamp, signature, body) {
+		c.JSON(http.StatusUnauthorized, APIError{Code: ErrCodeUnauthorized, Message: "invalid slack signature"})
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid form payload"})
+		return
+	}
+
+	teamID := form.Get("team_id")
+	userID := form.Get("user_id")
+	text := form.Get("text")
+	responseURL := form.Get("response_url")
+	if strings.TrimSpace(responseURL) == "" {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "missing response_url"})
+		return
+	}
+
+	// Slack expects an answer within three seconds, so the lookup or save runs
+	// in the background and replies through response_url.
+	ctx := context.WithoutCancel(c.Request.Context())
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, slashCommandTimeout)
+		defer cancel()
+		if err := h.inboundService.HandleSlashCommand(ctx, teamID, userID, text, responseURL); err != nil {
+			h.logger.ErrorContext(ctx, "slash command response failed", slog.String("user_id", userID), slog.String("error", err.Error()))
+		}
+	}()
+
+	c.JSON(http.StatusOK, SlackCommandAckResponse{
+		ResponseType: "ephemeral",
+		Text:         "Working on it…",
+	})
+}
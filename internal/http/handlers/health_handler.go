@@ -1,19 +1,58 @@
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync/atomic"
+	"time"
 
-type HealthHandler struct{}
+	"github.com/gin-gonic/gin"
+)
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+const readinessDBTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db *sql.DB
+	// schedulerStarted is nil when the scheduler is disabled.
+	schedulerStarted *atomic.Bool
+}
+
+func NewHealthHandler(db *sql.DB, schedulerStarted *atomic.Bool) *HealthHandler {
+	return &HealthHandler{db: db, schedulerStarted: schedulerStarted}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Returns 200 while the process is running. /healthz is an alias.
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /healthz/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
 }
 
-// Healthz godoc
-// @Summary Health check
+// Ready godoc
+// @Summary Readiness probe
+// @Description Returns 200 when the database answers a ping within 2 seconds and, if enabled, the scheduler has started.
 // @Tags health
 // @Produce json
 // @Success 200 {object} HealthResponse
-// @Router /healthz [get]
-func (h *HealthHandler) Healthz(c *gin.Context) {
-	c.JSON(200, gin.H{"status": "ok"})
+// @Failure 503 {object} HealthResponse
+// @Router /healthz/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessDBTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "not_ready", Reason: "db_unavailable"})
+		return
+	}
+	if h.schedulerStarted != nil && !h.schedulerStarted.Load() {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "not_ready", Reason: "scheduler_not_started"})
+		return
+	}
+
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
 }
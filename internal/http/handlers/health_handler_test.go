@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubDriver opens connections that do nothing, or fails every open when
+// down is set, which is enough for PingContext.
+type stubDriver struct{ down bool }
+
+func (d stubDriver) Open(string) (driver.Conn, error) {
+	if d.down {
+		return nil, errors.New("connection refused")
+	}
+	return stubConn{}, nil
+}
+
+type stubConn struct{}
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (stubConn) Close() error                        { return nil }
+func (stubConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("health-stub-up", stubDriver{})
+	sql.Register("health-stub-down", stubDriver{down: true})
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	started, notStarted := new(atomic.Bool), new(atomic.Bool)
+	started.Store(true)
+
+	tests := []struct {
+		name       string
+		driver     string
+		scheduler  *atomic.Bool
+		wantStatus int
+		wantReason string
+	}{
+		{name: "ready", driver: "health-stub-up", scheduler: started, wantStatus: http.StatusOK},
+		{name: "scheduler disabled", driver: "health-stub-up", scheduler: nil, wantStatus: http.StatusOK},
+		{name: "database down", driver: "health-stub-down", scheduler: started, wantStatus: http.StatusServiceUnavailable, wantReason: "db_unavailable"},
+		{name: "scheduler not started", driver: "health-stub-up", scheduler: notStarted, wantStatus: http.StatusServiceUnavailable, wantReason: "scheduler_not_started"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := sql.Open(tc.driver, "")
+			if err != nil {
+				t.Fatalf("open stub db: %v", err)
+			}
+			defer db.Close()
+
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/healthz/ready", nil)
+			NewHealthHandler(db, tc.scheduler).Ready(c)
+
+			var body HealthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if rec.Code != tc.wantStatus || body.Reason != tc.wantReason {
+				t.Fatalf("got %d %+v, want %d with reason %q", rec.Code, body, tc.wantStatus, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestHealthHandler_LiveIgnoresDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := sql.Open("health-stub-down", "")
+	if err != nil {
+		t.Fatalf("open stub db: %v", err)
+	}
+	defer db.Close()
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/healthz/live", nil)
+	NewHealthHandler(db, new(atomic.Bool)).Live(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"slackcheers/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SlackInteractiveHandler struct {
+	inboundService *service.SlackInboundService
+	signingSecret  string
+	logger         *slog.Logger
+}
+
+func NewSlackInteractiveHandler(inboundService *service.SlackInboundService, signingSecret string, logger *slog.Logger) *SlackInteractiveHandler {
+	return &SlackInteractiveHandler{
+		inboundService: inboundService,
+		signingSecret:  strings.TrimSpace(signingSecret),
+		logger:         logger,
+	}
+}
+
+type slackInteractionPayload struct {
+	Type      string `json:"type"`
+	TriggerID string `json:"trigger_id"`
+	Team      struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+	} `json:"actions"`
+	View struct {
+		CallbackID string `json:"callback_id"`
+		State      struct {
+			Values map[string]map[string]struct {
+				SelectedDate string `json:"selected_date"`
+			} `json:"values"`
+		} `json:"state"`
+	} `json:"view"`
+}
+
+// SlackActions godoc
+// @Summary Slack interactivity webhook
+// @Description Verifies Slack signatures and handles onboarding DM buttons (opening a date picker modal) and the modal submissions that save birthdays/hire dates.
+// @Tags slack
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param payload formData string true "Slack interaction payload JSON"
+// @Success 200 {object} SlackEventAckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /slack/actions [post]
+func (h *SlackInteractiveHandler) SlackActions(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "failed to read request body"})
+		return
+	}
+
+	if h.signingSecret == "" {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "SLACK_SIGNING_SECRET is required for actions endpoint"})
+		return
+	}
+
+	timestamp := c.GetHeader("X-Slack-Request-Timestamp")
+	signature := c.GetHeader("X-Slack-Signature")
+	if !isValidSlackSignature(h.signingSecret, timestamp, signature, body) {
+		c.JSON(http.StatusUnauthorized, APIError{Code: ErrCodeUnauthorized, Message: "invalid slack signature"})
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid form payload"})
+		return
+	}
+
+	var payload slackInteractionPayload
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid json payload"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	switch payload.Type {
+	case "block_actions":
+		for _, action := range payload.Actions {
+			if err := h.inboundService.HandleInteractiveAction(ctx, payload.Team.ID, payload.User.ID, action.ActionID, payload.TriggerID); err != nil {
+				h.logger.WarnContext(ctx, "slack interactive action failed",
+					slog.String("action_id", action.ActionID),
+					slog.String("user_id", payload.User.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	case "view_submission":
+		selected := payload.View.State.Values[service.ProfileDateBlockID][service.ProfileDateActionID].SelectedDate
+		if err := h.inboundService.HandleViewSubmission(ctx, payload.Team.ID, payload.User.ID, payload.View.CallbackID, selected); err != nil {
+			if errors.Is(err, service.ErrInvalidInput) {
+				// Slack shows these next to the input and keeps the modal open.
+				c.JSON(http.StatusOK, gin.H{
+					"response_action": "errors",
+					"errors": gin.H{
+						service.ProfileDateBlockID: err.Error(),
+					},
+				})
+				return
+			}
+			h.logger.ErrorContext(ctx, "slack view submission failed",
+				slog.String("callback_id", payload.View.CallbackID),
+				slog.String("user_id", payload.User.ID),
+				slog.String("error", err.Error()),
+			)
+			c.JSON(http.StatusOK, gin.H{
+				"response_action": "errors",
+				"errors": gin.H{
+					service.ProfileDateBlockID: "Sorry, we couldn't save that right now. Please try again.",
+				},
+			})
+			return
+		}
+		// An empty 200 closes the modal.
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.JSON(http.StatusOK, SlackEventAckResponse{OK: true})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"slackcheers/internal/scheduler"
+)
+
+type SchedulerHandler struct {
+	// scheduler is nil when the scheduler is disabled.
+	scheduler *scheduler.Scheduler
+	logger    *slog.Logger
+}
+
+func NewSchedulerHandler(sched *scheduler.Scheduler, logger *slog.Logger) *SchedulerHandler {
+	return &SchedulerHandler{scheduler: sched, logger: logger}
+}
+
+// Status godoc
+// @Summary Scheduler status
+// @Description Reports whether the scheduler loop is running, whether dispatch is paused, and how often it polls. cron is set when SCHEDULER_CRON replaces the poll interval. A disabled scheduler reports running false.
+// @Tags scheduler
+// @Produce json
+// @Success 200 {object} SchedulerStatusResponse
+// @Security ApiKeyAuth
+// @Router /api/scheduler/status [get]
+func (h *SchedulerHandler) Status(c *gin.Context) {
+	c.JSON(http.StatusOK, h.status())
+}
+
+// Pause godoc
+// @Summary Pause the scheduler
+// @Description Skips celebration and reminder ticks until resumed, without restarting the process. A tick already running finishes. The pause is lost on restart.
+// @Tags scheduler
+// @Produce json
+// @Success 200 {object} SchedulerStatusResponse
+// @Failure 409 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/scheduler/pause [post]
+func (h *SchedulerHandler) Pause(c *gin.Context) {
+	if h.scheduler == nil {
+		c.JSON(http.StatusConflict, APIError{Code: ErrCodeConflict, Message: "scheduler is disabled"})
+		return
+	}
+
+	h.scheduler.Pause()
+	h.logger.WarnContext(c.Request.Context(), "scheduler paused")
+	c.JSON(http.StatusOK, h.status())
+}
+
+// Resume godoc
+// @Summary Resume the scheduler
+// @Tags scheduler
+// @Produce json
+// @Success 200 {object} SchedulerStatusResponse
+// @Failure 409 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/scheduler/resume [post]
+func (h *SchedulerHandler) Resume(c *gin.Context) {
+	if h.scheduler == nil {
+		c.JSON(http.StatusConflict, APIError{Code: ErrCodeConflict, Message: "scheduler is disabled"})
+		return
+	}
+
+	h.scheduler.Resume()
+	h.logger.WarnContext(c.Request.Context(), "scheduler resumed")
+	c.JSON(http.StatusOK, h.status())
+}
+
+func (h *SchedulerHandler) status() SchedulerStatusResponse {
+	if h.scheduler == nil {
+		return SchedulerStatusResponse{}
+	}
+	return SchedulerStatusResponse{
+		Running:      h.scheduler.Running(),
+		Paused:       h.scheduler.Paused(),
+		PollInterval: formatPollInterval(h.scheduler.PollInterval()),
+		Cron:         h.scheduler.CronExpression(),
+	}
+}
+
+// formatPollInterval drops the zero units time.Duration.String adds, so one
+// minute reads "1m" rather than "1m0s".
+func formatPollInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatPollInterval(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Minute:                    "1m",
+		30 * time.Second:               "30s",
+		90 * time.Second:               "1m30s",
+		time.Hour:                      "1h",
+		time.Hour + 30*time.Minute:     "1h30m",
+		10*time.Minute + 5*time.Second: "10m5s",
+	}
+	for d, want := range tests {
+		if got := formatPollInterval(d); got != want {
+			t.Fatalf("formatPollInterval(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"slackcheers/internal/slack"
+)
+
+// slackErrorResponse maps a Slack API error from the services layer to an
+// HTTP status and a body whose message is safe to show to API consumers. The
+// Slack error code and scope hints go in details.
+func slackErrorResponse(err error) (int, APIError) {
+	resp := APIError{Code: ErrCodeSlackAPIError, Message: err.Error()}
+
+	var apiErr *slack.SlackAPIError
+	if !errors.As(err, &apiErr) {
+		return http.StatusBadRequest, resp
+	}
+
+	resp.Details = map[string]any{"slack_error": apiErr.Code}
+	if apiErr.Needed != "" {
+		resp.Details["needed"] = apiErr.Needed
+	}
+	if apiErr.Provided != "" {
+		resp.Details["provided"] = apiErr.Provided
+	}
+
+	switch apiErr.Code {
+	case "missing_scope":
+		resp.Message = "The Slack app is missing a required permission. Reinstall the app to grant it."
+		if apiErr.Needed != "" {
+			resp.Message += " (needed=" + apiErr.Needed + ")"
+		}
+		return http.StatusBadRequest, resp
+	case "token_revoked", "invalid_auth", "account_inactive", "not_authed":
+		resp.Message = "The Slack connection for this workspace is no longer valid. Reinstall the app."
+		return http.StatusUnauthorized, resp
+	case "channel_not_found":
+		resp.Message = "Slack channel not found. Check the channel ID and that the bot can see it."
+		return http.StatusNotFound, resp
+	default:
+		return http.StatusBadRequest, resp
+	}
+}
+
+func respondSlackError(c *gin.Context, err error) {
+	status, resp := slackErrorResponse(err)
+	c.JSON(status, resp)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"slackcheers/internal/slack"
+)
+
+func TestSlackErrorResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantSlack  string
+		wantInMsg  string
+	}{
+		{
+			name:       "missing scope keeps needed scope hint",
+			err:        &slack.SlackAPIError{Code: "missing_scope", Needed: "channels:read", Provided: "chat:write"},
+			wantStatus: http.StatusBadRequest,
+			wantSlack:  "missing_scope",
+			wantInMsg:  "needed=channels:read",
+		},
+		{
+			name:       "revoked token",
+			err:        fmt.Errorf("call slack: %w", &slack.SlackAPIError{Code: "token_revoked"}),
+			wantStatus: http.StatusUnauthorized,
+			wantSlack:  "token_revoked",
+			wantInMsg:  "Reinstall",
+		},
+		{
+			name:       "channel not found",
+			err:        &slack.SlackAPIError{Code: "channel_not_found"},
+			wantStatus: http.StatusNotFound,
+			wantSlack:  "channel_not_found",
+			wantInMsg:  "channel not found",
+		},
+		{
+			name:       "unknown slack error passes through",
+			err:        &slack.SlackAPIError{Code: "ratelimited"},
+			wantStatus: http.StatusBadRequest,
+			wantSlack:  "ratelimited",
+			wantInMsg:  "ratelimited",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := slackErrorResponse(tc.err)
+			if status != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tc.wantStatus)
+			}
+			if resp.Code != ErrCodeSlackAPIError {
+				t.Fatalf("code = %q, want %q", resp.Code, ErrCodeSlackAPIError)
+			}
+			if resp.Details["slack_error"] != tc.wantSlack {
+				t.Fatalf("details.slack_error = %v, want %q", resp.Details["slack_error"], tc.wantSlack)
+			}
+			if !strings.Contains(resp.Message, tc.wantInMsg) {
+				t.Fatalf("message = %q, want it to contain %q", resp.Message, tc.wantInMsg)
+			}
+		})
+	}
+}
+
+func TestSlackErrorResponse_WithoutSlackCode(t *testing.T) {
+	err := fmt.Errorf("%w: missing dm channel id", slack.ErrAPIError)
+
+	status, resp := slackErrorResponse(err)
+	if status != http.StatusBadRequest || resp.Code != ErrCodeSlackAPIError || resp.Details != nil {
+		t.Fatalf("got status %d and %#v", status, resp)
+	}
+	if !errors.Is(err, slack.ErrAPIError) || resp.Message != err.Error() {
+		t.Fatalf("message = %q, want %q", resp.Message, err.Error())
+	}
+}
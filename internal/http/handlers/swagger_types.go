@@ -1,9 +1,15 @@
 package handlers
 
-import "slackcheers/internal/domain"
+import (
+	"time"
+
+	"slackcheers/internal/domain"
+)
 
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error   string         `json:"error"`
+	Code    string         `json:"code" example:"not_found"`
+	Details map[string]any `json:"details,omitempty"`
 }
 
 type MessageResponse struct {
@@ -12,6 +18,23 @@ type MessageResponse struct {
 
 type HealthResponse struct {
 	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type SchedulerStatusResponse struct {
+	Running      bool   `json:"running"`
+	Paused       bool   `json:"paused"`
+	PollInterval string `json:"poll_interval" example:"1m"`
+	Cron         string `json:"cron,omitempty" example:"0 * * * *"`
+}
+
+type UpdateLogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+type LogLevelResponse struct {
+	PreviousLevel string `json:"previous_level"`
+	Level         string `json:"level"`
 }
 
 type BootstrapWorkspaceRequest struct {
@@ -23,6 +46,12 @@ type BootstrapWorkspaceRequest struct {
 	PostingTime string `json:"posting_time" binding:"required"`
 }
 
+// PatchWorkspaceRequest updates only the fields that are present.
+type PatchWorkspaceRequest struct {
+	Name     *string `json:"name"`
+	Timezone *string `json:"timezone" example:"Africa/Lagos"`
+}
+
 type BootstrapWorkspaceResponse struct {
 	Workspace domain.Workspace        `json:"workspace"`
 	Channel   domain.WorkspaceChannel `json:"channel"`
@@ -40,11 +69,97 @@ type UpsertPersonRequest struct {
 	RemindersMode          string `json:"reminders_mode"`
 }
 
+type BulkUpdateRemindersModeRequest struct {
+	RemindersMode string   `json:"reminders_mode" binding:"required"`
+	UserIDs       []string `json:"user_ids"`
+}
+
+type BulkUpdateRemindersModeResponse struct {
+	Updated int `json:"updated"`
+}
+
+type BulkImportPeopleResponse struct {
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors"`
+}
+
+type PersonExportItem struct {
+	SlackUserID            string `json:"slack_user_id"`
+	DisplayName            string `json:"display_name"`
+	SlackHandle            string `json:"slack_handle"`
+	BirthdayDay            *int   `json:"birthday_day"`
+	BirthdayMonth          *int   `json:"birthday_month"`
+	BirthdayYear           *int   `json:"birthday_year"`
+	HireDate               string `json:"hire_date"`
+	PublicCelebrationOptIn bool   `json:"public_celebration_opt_in"`
+	RemindersMode          string `json:"reminders_mode"`
+}
+
+type PersonDataExportResponse struct {
+	ID                     string      `json:"id"`
+	WorkspaceID            string      `json:"workspace_id"`
+	SlackUserID            string      `json:"slack_user_id"`
+	SlackHandle            string      `json:"slack_handle"`
+	DisplayName            string      `json:"display_name"`
+	AvatarURL              string      `json:"avatar_url"`
+	BirthdayDay            *int        `json:"birthday_day"`
+	BirthdayMonth          *int        `json:"birthday_month"`
+	BirthdayYear           *int        `json:"birthday_year"`
+	HireDate               *string     `json:"hire_date" example:"2021-03-15"`
+	PublicCelebrationOptIn bool        `json:"public_celebration_opt_in"`
+	RemindersMode          string      `json:"reminders_mode"`
+	CreatedAt              time.Time   `json:"created_at"`
+	UpdatedAt              time.Time   `json:"updated_at"`
+	OnboardingDMSentAt     []time.Time `json:"onboarding_dm_sent_at"`
+}
+
+type PersonErasureResponse struct {
+	DeletedTables []string `json:"deleted_tables"`
+	RowsAffected  int64    `json:"rows_affected"`
+}
+
+type PauseChannelRequest struct {
+	Until string `json:"until" binding:"required" example:"2026-01-05T00:00:00Z"`
+}
+
+type UpdateOnboardingTemplateRequest struct {
+	Template string `json:"template" example:"Hi {name}! Reply with your birthday as month day."`
+}
+
+type OnboardingTemplateResponse struct {
+	WorkspaceID string `json:"workspace_id"`
+	Template    string `json:"template"`
+}
+
+type UpdateAnnouncementChannelRequest struct {
+	SlackChannelID string `json:"slack_channel_id" example:"C0123456789"`
+}
+
+type AnnouncementChannelResponse struct {
+	WorkspaceID           string `json:"workspace_id"`
+	AnnouncementChannelID string `json:"announcement_channel_id"`
+}
+
+type UpdatePrivacySettingsRequest struct {
+	BirthdayYearPrivacy string `json:"birthday_year_privacy" binding:"required"`
+}
+
+type PrivacySettingsResponse struct {
+	BirthdayYearPrivacy string `json:"birthday_year_privacy"`
+}
+
 type UpdateChannelSettingsRequest struct {
-	PostingTime          string `json:"posting_time" binding:"required"`
-	Timezone             string `json:"timezone" binding:"required"`
-	BirthdaysEnabled     *bool  `json:"birthdays_enabled" binding:"required"`
-	AnniversariesEnabled *bool  `json:"anniversaries_enabled" binding:"required"`
+	PostingTime                string  `json:"posting_time" binding:"required"`
+	Timezone                   string  `json:"timezone" binding:"required"`
+	BirthdaysEnabled           *bool   `json:"birthdays_enabled" binding:"required"`
+	AnniversariesEnabled       *bool   `json:"anniversaries_enabled" binding:"required"`
+	MinAnniversaryTenureMonths *int    `json:"min_anniversary_tenure_months"`
+	PostDispatchWebhookURL     *string `json:"post_dispatch_webhook_url"`
+	MaxRecipientsPerPost       *int    `json:"max_recipients_per_post"`
+	AnniversaryMilestones      []int   `json:"anniversary_milestones"`
+	SkipWeekends               *bool   `json:"skip_weekends"`
+	ReactionEmoji              *string `json:"reaction_emoji"`
 }
 
 type UpdateChannelTemplatesRequest struct {
@@ -53,14 +168,164 @@ type UpdateChannelTemplatesRequest struct {
 	BrandingEmoji       string `json:"branding_emoji"`
 }
 
+type PreviewTemplateRequest struct {
+	BirthdayTemplate    string `json:"birthday_template" example:"Happy birthday {users}!"`
+	AnniversaryTemplate string `json:"anniversary_template" example:"Happy {years} year work anniversary {users}!"`
+	BrandingEmoji       string `json:"branding_emoji"`
+	SlackUserID         string `json:"slack_user_id"`
+}
+
+type PreviewTemplateResponse struct {
+	BirthdayPreview    string `json:"birthday_preview"`
+	AnniversaryPreview string `json:"anniversary_preview"`
+	SampleUserID       string `json:"sample_user_id"`
+}
+
 type OverviewResponse struct {
 	Items []domain.UpcomingCelebration `json:"items"`
 }
 
+type ForecastDayItem struct {
+	Date             string `json:"date"`
+	BirthdayCount    int    `json:"birthday_count"`
+	AnniversaryCount int    `json:"anniversary_count"`
+}
+
+type ForecastResponse struct {
+	Days []ForecastDayItem `json:"days"`
+}
+
+type DispatchLogItem struct {
+	DispatchDate        string    `json:"dispatch_date"`
+	ChannelID           string    `json:"channel_id"`
+	SlackChannelID      string    `json:"slack_channel_id"`
+	BirthdayCount       int       `json:"birthday_count"`
+	AnniversaryCount    int       `json:"anniversary_count"`
+	BirthdayUserIDs     []string  `json:"birthday_user_ids"`
+	AnniversaryUserIDs  []string  `json:"anniversary_user_ids"`
+	MessageTS           string    `json:"message_ts"`
+	MessageTimestamps   []string  `json:"message_timestamps"`
+	MessageURL          string    `json:"message_url"`
+	ScheduledMessageIDs []string  `json:"scheduled_message_ids"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type DispatchLogResponse struct {
+	Entries    []DispatchLogItem `json:"entries"`
+	TotalCount int               `json:"total_count"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+}
+
+type CelebratedPersonItem struct {
+	SlackUserID string `json:"slack_user_id"`
+	DisplayName string `json:"display_name"`
+}
+
+type ChannelHistoryItem struct {
+	DispatchDate  string                 `json:"dispatch_date"`
+	Birthdays     []CelebratedPersonItem `json:"birthdays"`
+	Anniversaries []CelebratedPersonItem `json:"anniversaries"`
+	MessageTS     string                 `json:"message_ts"`
+	MessageURL    string                 `json:"message_url"`
+	DispatchedAt  time.Time              `json:"dispatched_at"`
+}
+
+type ChannelHistoryResponse struct {
+	Entries []ChannelHistoryItem `json:"entries"`
+	Page    int                  `json:"page"`
+	PerPage int                  `json:"per_page"`
+	Total   int                  `json:"total"`
+}
+
+type CelebrationHistoryItem struct {
+	ID              int64     `json:"id"`
+	ChannelID       string    `json:"channel_id"`
+	SlackChannelID  string    `json:"slack_channel_id"`
+	CelebrationType string    `json:"celebration_type"`
+	SlackUserIDs    []string  `json:"slack_user_ids"`
+	MessageText     string    `json:"message_text"`
+	SlackMessageTS  string    `json:"slack_message_ts"`
+	DispatchedAt    time.Time `json:"dispatched_at"`
+}
+
+type CelebrationHistoryResponse struct {
+	Entries    []CelebrationHistoryItem `json:"entries"`
+	TotalCount int                      `json:"total_count"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+}
+
+type WorkspaceListItem struct {
+	ID            string    `json:"id"`
+	SlackTeamID   string    `json:"slack_team_id"`
+	Name          string    `json:"name"`
+	Timezone      string    `json:"timezone"`
+	PeopleCount   int       `json:"people_count"`
+	ChannelsCount int       `json:"channels_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ConnectionStatusResponse struct {
+	Connected bool   `json:"connected"`
+	BotUserID string `json:"bot_user_id"`
+	TeamID    string `json:"team_id"`
+	Error     string `json:"error,omitempty"`
+}
+
+type MemberSyncStatusResponse struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+	MemberCount  int        `json:"member_count"`
+}
+
+type WorkspaceResponse struct {
+	ID                   string    `json:"id"`
+	SlackTeamID          string    `json:"slack_team_id"`
+	Name                 string    `json:"name"`
+	Timezone             string    `json:"timezone"`
+	BirthdaysEnabled     bool      `json:"birthdays_enabled"`
+	AnniversariesEnabled bool      `json:"anniversaries_enabled"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type WorkspacesResponse struct {
+	Workspaces []WorkspaceListItem `json:"workspaces"`
+	TotalCount int                 `json:"total_count"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+}
+
+type AdminBirthdaysResponse struct {
+	Workspaces map[string][]domain.Person `json:"workspaces"`
+}
+
 type PeopleResponse struct {
+	People     []domain.Person `json:"people"`
+	TotalCount int             `json:"total_count"`
+	Limit      int             `json:"limit"`
+	Offset     int             `json:"offset"`
+}
+
+type MissingDataPeopleResponse struct {
+	Missing    string          `json:"missing"`
+	People     []domain.Person `json:"people"`
+	TotalCount int             `json:"total_count"`
+	Limit      int             `json:"limit"`
+	Offset     int             `json:"offset"`
+}
+
+type BirthdayDuplicateGroup struct {
+	Month  int             `json:"month"`
+	Day    int             `json:"day"`
+	Count  int             `json:"count"`
 	People []domain.Person `json:"people"`
 }
 
+type BirthdayDuplicatesResponse struct {
+	Groups []BirthdayDuplicateGroup `json:"groups"`
+}
+
 type ChannelsResponse struct {
 	Channels []domain.WorkspaceChannel `json:"channels"`
 }
@@ -96,6 +361,11 @@ type SlackEventAckResponse struct {
 	Challenge string `json:"challenge,omitempty"`
 }
 
+type SlackCommandAckResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
 type SlackChannelItem struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
@@ -115,6 +385,15 @@ type OnboardingDMDispatchResponse struct {
 	FailedDetails map[string]string `json:"failed_details"`
 }
 
+type OnboardingProgressResponse struct {
+	TotalSent         int `json:"total_sent"`
+	RespondedBirthday int `json:"responded_birthday"`
+	RespondedHireDate int `json:"responded_hire_date"`
+	RespondedBoth     int `json:"responded_both"`
+	Pending           int `json:"pending"`
+	NotSent           int `json:"not_sent"`
+}
+
 type DMCleanupResponse struct {
 	UserID        string            `json:"user_id"`
 	ChannelID     string            `json:"channel_id"`
@@ -126,6 +405,35 @@ type DMCleanupResponse struct {
 	FailedDetails map[string]string `json:"failed_details"`
 }
 
+type ChannelPreviewResponse struct {
+	ChannelID        string `json:"channel_id"`
+	SlackChannelID   string `json:"slack_channel_id"`
+	AdminUserID      string `json:"admin_user_id"`
+	PreviewDate      string `json:"preview_date"`
+	BirthdayCount    int    `json:"birthday_count"`
+	AnniversaryCount int    `json:"anniversary_count"`
+	MessagesSent     int    `json:"messages_sent"`
+}
+
+type BackfillRequest struct {
+	From string `json:"from" binding:"required" example:"2024-01-01"`
+	To   string `json:"to" binding:"required" example:"2024-01-07"`
+}
+
+type BackfillDayItem struct {
+	Date               string   `json:"date"`
+	ChannelsDispatched int      `json:"channels_dispatched"`
+	ChannelsSkipped    int      `json:"channels_skipped"`
+	BirthdayCount      int      `json:"birthday_count"`
+	AnniversaryCount   int      `json:"anniversary_count"`
+	Errors             []string `json:"errors"`
+}
+
+type BackfillResponse struct {
+	WorkspaceID string            `json:"workspace_id"`
+	Days        []BackfillDayItem `json:"days"`
+}
+
 type ManualCelebrationDispatchResponse struct {
 	WorkspaceID        string                               `json:"workspace_id"`
 	ChannelsProcessed  int                                  `json:"channels_processed"`
@@ -142,6 +450,9 @@ type ManualCelebrationChannelDispatches struct {
 	AnniversaryCount  int    `json:"anniversary_count"`
 	BirthdayPosted    bool   `json:"birthday_posted"`
 	AnniversaryPosted bool   `json:"anniversary_posted"`
+	MessageURL        string `json:"message_url,omitempty"`
+	DryRun            bool   `json:"dry_run,omitempty"`
+	PreviewMessage    string `json:"preview_message,omitempty"`
 	Error             string `json:"error,omitempty"`
 }
 
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"slackcheers/internal/domain"
 	"slackcheers/internal/repository"
 	"slackcheers/internal/service"
 
@@ -14,65 +19,134 @@ import (
 )
 
 type WorkspaceHandler struct {
-	celebrationSvc *service.CelebrationService
-	dashboardSvc   *service.DashboardService
-	onboardingSvc  *service.SlackOnboardingService
-	dmCleanupSvc   *service.SlackDMCleanupService
-	channelCleanup *service.SlackChannelCleanupService
-	slackChannels  *service.SlackChannelsService
-	workspaceRepo  *repository.WorkspaceRepository
-}
-
-func NewWorkspaceHandler(
-	celebrationSvc *service.CelebrationService,
-	dashboardSvc *service.DashboardService,
-	onboardingSvc *service.SlackOnboardingService,
-	dmCleanupSvc *service.SlackDMCleanupService,
-	channelCleanup *service.SlackChannelCleanupService,
-	slackChannels *service.SlackChannelsService,
-	workspaceRepo *repository.WorkspaceRepository,
-) *WorkspaceHandler {
+	celebrationSvc     *service.CelebrationService
+	dashboardSvc       *service.DashboardService
+	onboardingSvc      *service.SlackOnboardingService
+	onboardingProgress *service.OnboardingProgressService
+	reminders          *service.ReminderService
+	dmCleanupSvc       *service.SlackDMCleanupService
+	channelCleanup     *service.SlackChannelCleanupService
+	slackChannels      *service.SlackChannelsService
+	slackConnection    *service.SlackConnectionService
+	idempotencySvc     *service.IdempotencyService
+	dataErasure        *service.DataErasureService
+	memberSync         *service.SlackMemberSyncService
+	workspaceRepo      *repository.WorkspaceRepository
+	logger             *slog.Logger
+}
+
+type WorkspaceHandlerDependencies struct {
+	CelebrationService        *service.CelebrationService
+	DashboardService          *service.DashboardService
+	OnboardingService         *service.SlackOnboardingService
+	OnboardingProgressService *service.OnboardingProgressService
+	ReminderService           *service.ReminderService
+	DMCleanupService          *service.SlackDMCleanupService
+	ChannelCleanupService     *service.SlackChannelCleanupService
+	SlackChannelsService      *service.SlackChannelsService
+	SlackConnectionService    *service.SlackConnectionService
+	IdempotencyService        *service.IdempotencyService
+	DataErasureService        *service.DataErasureService
+	MemberSyncService         *service.SlackMemberSyncService
+	WorkspaceRepository       *repository.WorkspaceRepository
+	Logger                    *slog.Logger
+}
+
+func NewWorkspaceHandler(deps WorkspaceHandlerDependencies) *WorkspaceHandler {
 	return &WorkspaceHandler{
-		celebrationSvc: celebrationSvc,
-		dashboardSvc:   dashboardSvc,
-		onboardingSvc:  onboardingSvc,
-		dmCleanupSvc:   dmCleanupSvc,
-		channelCleanup: channelCleanup,
-		slackChannels:  slackChannels,
-		workspaceRepo:  workspaceRepo,
+		celebrationSvc:     deps.CelebrationService,
+		dashboardSvc:       deps.DashboardService,
+		onboardingSvc:      deps.OnboardingService,
+		onboardingProgress: deps.OnboardingProgressService,
+		reminders:          deps.ReminderService,
+		dmCleanupSvc:       deps.DMCleanupService,
+		channelCleanup:     deps.ChannelCleanupService,
+		slackChannels:      deps.SlackChannelsService,
+		slackConnection:    deps.SlackConnectionService,
+		idempotencySvc:     deps.IdempotencyService,
+		dataErasure:        deps.DataErasureService,
+		memberSync:         deps.MemberSyncService,
+		workspaceRepo:      deps.WorkspaceRepository,
+		logger:             deps.Logger,
 	}
 }
 
 // DispatchCelebrationsNow godoc
 // @Summary Force run celebrations now for a workspace
-// @Description Manually runs birthday and anniversary dispatch now across workspace channels.
+// @Description Manually runs birthday and anniversary dispatch now across workspace channels. Send X-Idempotency-Key to make retries safe: a repeated key within 24 hours returns the first response without dispatching again.
 // @Tags workspaces
 // @Produce json
 // @Param workspaceID path string true "Workspace ID"
+// @Param X-Idempotency-Key header string false "Idempotency key"
+// @Param dry_run query bool false "Render messages without posting or recording the dispatch"
 // @Success 200 {object} ManualCelebrationDispatchResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/dispatch-now [post]
 func (h *WorkspaceHandler) DispatchCelebrationsNow(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
 	if h.celebrationSvc == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "celebration service is not configured"})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "celebration service is not configured"})
 		return
 	}
 
-	result, err := h.celebrationSvc.RunWorkspaceNow(c.Request.Context(), workspaceID, time.Now().UTC())
+	dryRun := false
+	if raw := strings.TrimSpace(c.Query("dry_run")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "dry_run must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	// A dry run never posts, so there is nothing for an idempotency key to guard.
+	idempotencyKey := strings.TrimSpace(c.GetHeader("X-Idempotency-Key"))
+	if idempotencyKey != "" && h.idempotencySvc != nil && !dryRun {
+		cached, err := h.idempotencySvc.Begin(c.Request.Context(), workspaceID, idempotencyKey)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+				return
+			}
+			if errors.Is(err, service.ErrIdempotencyKeyInProgress) {
+				c.JSON(http.StatusConflict, APIError{Code: ErrCodeConflict, Message: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+			return
+		}
+		if cached != nil {
+			c.Header("X-Idempotency-Replayed", "true")
+			c.Data(http.StatusOK, "application/json; charset=utf-8", cached)
+			return
+		}
+	} else {
+		idempotencyKey = ""
+	}
+
+	result, err := h.celebrationSvc.RunWorkspaceNow(c.Request.Context(), workspaceID, time.Now().UTC(), dryRun)
 	if err != nil {
+		if idempotencyKey != "" {
+			_ = h.idempotencySvc.Release(c.Request.Context(), workspaceID, idempotencyKey)
+		}
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
 			return
 		}
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "not connected") || strings.Contains(msg, "slack api error") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -85,18 +159,270 @@ func (h *WorkspaceHandler) DispatchCelebrationsNow(c *gin.Context) {
 			AnniversaryCount:  item.AnniversaryCount,
 			BirthdayPosted:    item.BirthdayPosted,
 			AnniversaryPosted: item.AnniversaryPosted,
+			MessageURL:        item.MessageURL,
+			DryRun:            item.DryRun,
+			PreviewMessage:    item.PreviewMessage,
 			Error:             item.Error,
 		})
 	}
 
-	c.JSON(http.StatusOK, ManualCelebrationDispatchResponse{
+	response := ManualCelebrationDispatchResponse{
 		WorkspaceID:        result.WorkspaceID,
 		ChannelsProcessed:  result.ChannelsProcessed,
 		BirthdayPosts:      result.BirthdayPosts,
 		AnniversaryPosts:   result.AnniversaryPosts,
 		ChannelsWithErrors: result.ChannelsWithErrors,
 		ChannelDispatches:  dispatches,
-	})
+	}
+
+	if idempotencyKey != "" {
+		completeIdempotencyKey(c.Request.Context(), h.idempotencySvc, h.logger, workspaceID, idempotencyKey, response)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type idempotencyCompleter interface {
+	Complete(ctx context.Context, workspaceID, key string, response any) error
+}
+
+// completeIdempotencyKey stores the response for replay. The dispatch has
+// already posted, so a failure is only logged and the caller still gets the
+// result; the key stays in flight until it expires, which keeps a retry from
+// posting twice.
+func completeIdempotencyKey(ctx context.Context, svc idempotencyCompleter, logger *slog.Logger, workspaceID, key string, response any) {
+	if err := svc.Complete(ctx, workspaceID, key, response); err != nil {
+		logger.ErrorContext(ctx, "store idempotent response failed",
+			slog.String("workspace_id", workspaceID),
+			slog.String("idempotency_key", key),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// BackfillCelebrations godoc
+// @Summary Backfill missed celebrations
+// @Description Posts the celebrations each channel missed on the given days (inclusive, at most 90). Channel/day pairs already in the dispatch log, today and later, days before the channel existed and currently paused channels are skipped.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param request body BackfillRequest true "Date range (YYYY-MM-DD)"
+// @Success 200 {object} BackfillResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/backfill [post]
+func (h *WorkspaceHandler) BackfillCelebrations(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	if h.celebrationSvc == nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "celebration service is not configured"})
+		return
+	}
+
+	var req BackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", strings.TrimSpace(req.From))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "from must use YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", strings.TrimSpace(req.To))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "to must use YYYY-MM-DD"})
+		return
+	}
+
+	result, err := h.celebrationSvc.BackfillCelebrations(c.Request.Context(), workspaceID, from, to, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	days := make([]BackfillDayItem, 0, len(result.Days))
+	for _, day := range result.Days {
+		days = append(days, BackfillDayItem{
+			Date:               day.Date.Format("2006-01-02"),
+			ChannelsDispatched: day.ChannelsDispatched,
+			ChannelsSkipped:    day.ChannelsSkipped,
+			BirthdayCount:      day.BirthdayCount,
+			AnniversaryCount:   day.AnniversaryCount,
+			Errors:             day.Errors,
+		})
+	}
+
+	c.JSON(http.StatusOK, BackfillResponse{WorkspaceID: result.WorkspaceID, Days: days})
+}
+
+// CelebrationHistory godoc
+// @Summary List posted celebration messages
+// @Description Returns every birthday and anniversary message posted in the workspace, newest first, including the Slack message timestamp.
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} CelebrationHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/celebration-history [get]
+func (h *WorkspaceHandler) CelebrationHistory(c *gin.Context) {
+	limit, offset, err := parseLimitOffset(c, defaultCelebrationHistoryLimit, maxCelebrationHistoryLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	entries, total, err := h.dashboardSvc.ListCelebrationHistory(c.Request.Context(), c.Param("workspaceID"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	items := make([]CelebrationHistoryItem, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, CelebrationHistoryItem{
+			ID:              entry.ID,
+			ChannelID:       entry.WorkspaceChannelID,
+			SlackChannelID:  entry.SlackChannelID,
+			CelebrationType: entry.CelebrationType,
+			SlackUserIDs:    entry.SlackUserIDs,
+			MessageText:     entry.MessageText,
+			SlackMessageTS:  entry.SlackMessageTS,
+			DispatchedAt:    entry.DispatchedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, CelebrationHistoryResponse{Entries: items, TotalCount: total, Limit: limit, Offset: offset})
+}
+
+// PauseChannel godoc
+// @Summary Pause celebrations for a channel
+// @Description Skips scheduled celebrations for the channel until the given time. until must be RFC3339, in the future and at most 90 days away.
+// @Tags channels
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel UUID or Slack Channel ID"
+// @Param request body PauseChannelRequest true "Pause payload"
+// @Success 200 {object} slackcheers_internal_domain.WorkspaceChannel
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/pause [post]
+func (h *WorkspaceHandler) PauseChannel(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	channelID := c.Param("channelID")
+
+	var req PauseChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(req.Until))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "until must be an RFC3339 timestamp"})
+		return
+	}
+
+	channel, err := h.dashboardSvc.PauseChannel(c.Request.Context(), workspaceID, channelID, until, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// UnpauseChannel godoc
+// @Summary Resume celebrations for a paused channel
+// @Tags channels
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel UUID or Slack Channel ID"
+// @Success 200 {object} slackcheers_internal_domain.WorkspaceChannel
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/pause [delete]
+func (h *WorkspaceHandler) UnpauseChannel(c *gin.Context) {
+	channel, err := h.dashboardSvc.UnpauseChannel(c.Request.Context(), c.Param("workspaceID"), c.Param("channelID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// PreviewChannelEphemeral godoc
+// @Summary Preview today's celebration posts privately
+// @Description Builds today's celebration messages for the channel and posts them as ephemeral messages visible only to the admin user. Nothing is recorded as dispatched.
+// @Tags channels
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel UUID or Slack Channel ID"
+// @Param admin_user_id query string true "Slack user ID that receives the preview"
+// @Success 200 {object} ChannelPreviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/preview-ephemeral [post]
+func (h *WorkspaceHandler) PreviewChannelEphemeral(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	channelID := c.Param("channelID")
+
+	adminUserID := strings.TrimSpace(c.Query("admin_user_id"))
+	if adminUserID == "" {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "admin_user_id is required"})
+		return
+	}
+
+	result, err := h.celebrationSvc.PreviewEphemeral(c.Request.Context(), workspaceID, channelID, adminUserID, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
 // CleanupBirthdayMessages godoc
@@ -111,6 +437,8 @@ func (h *WorkspaceHandler) DispatchCelebrationsNow(c *gin.Context) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/channels/{channelID}/cleanup-birthday-messages [post]
 func (h *WorkspaceHandler) CleanupBirthdayMessages(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
@@ -118,22 +446,29 @@ func (h *WorkspaceHandler) CleanupBirthdayMessages(c *gin.Context) {
 	match := strings.TrimSpace(c.Query("match"))
 
 	if h.channelCleanup == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "channel cleanup service is not configured"})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "channel cleanup service is not configured"})
 		return
 	}
 
 	result, err := h.channelCleanup.CleanupBirthdayMessages(c.Request.Context(), workspaceID, channelID, match)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
 			return
 		}
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "not connected") || strings.Contains(msg, "slack api error") || strings.Contains(msg, "required") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -150,186 +485,1243 @@ func (h *WorkspaceHandler) CleanupBirthdayMessages(c *gin.Context) {
 	})
 }
 
-// BootstrapWorkspace godoc
-// @Summary Bootstrap a workspace
-// @Description Creates or updates a workspace and its default celebration channel.
+// ListWorkspaces godoc
+// @Summary List workspaces
+// @Description Pages through installed workspaces, newest first, with their people and channel counts. Bot tokens are never included.
 // @Tags workspaces
-// @Accept json
 // @Produce json
-// @Param request body BootstrapWorkspaceRequest true "Workspace bootstrap payload"
-// @Success 201 {object} BootstrapWorkspaceResponse
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of workspaces to skip"
+// @Success 200 {object} WorkspacesResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/workspaces/bootstrap [post]
-func (h *WorkspaceHandler) BootstrapWorkspace(c *gin.Context) {
-	var req BootstrapWorkspaceRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+// @Security ApiKeyAuth
+// @Router /api/workspaces [get]
+func (h *WorkspaceHandler) ListWorkspaces(c *gin.Context) {
+	limit, offset, err := parseLimitOffset(c, defaultWorkspacesPageSize, maxWorkspacesPageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
-	if _, err := time.LoadLocation(req.Timezone); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+	workspaces, total, err := h.workspaceRepo.ListAll(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
-	if _, err := time.Parse("15:04", req.PostingTime); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "posting_time must use HH:MM"})
-		return
+	items := make([]WorkspaceListItem, 0, len(workspaces))
+	for _, w := range workspaces {
+		items = append(items, WorkspaceListItem{
+			ID:            w.ID,
+			SlackTeamID:   w.SlackTeamID,
+			Name:          w.Name,
+			Timezone:      w.Timezone,
+			PeopleCount:   w.PeopleCount,
+			ChannelsCount: w.ChannelsCount,
+			CreatedAt:     w.CreatedAt,
+		})
 	}
 
-	workspace, err := h.workspaceRepo.EnsureWorkspace(c.Request.Context(), req.SlackTeamID, req.Name, req.Timezone)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
+	c.JSON(http.StatusOK, WorkspacesResponse{Workspaces: items, TotalCount: total, Limit: limit, Offset: offset})
+}
 
-	channel, err := h.workspaceRepo.CreateDefaultChannel(c.Request.Context(), workspace.ID, req.ChannelID, req.ChannelName, req.Timezone, req.PostingTime)
+// GetWorkspace godoc
+// @Summary Get a workspace
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} WorkspaceResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID} [get]
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	workspace, err := h.workspaceRepo.GetByID(c.Request.Context(), c.Param("workspaceID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"workspace": workspace,
-		"channel":   channel,
-	})
+	c.JSON(http.StatusOK, workspaceResponse(workspace))
 }
 
-// Overview godoc
-// @Summary List upcoming celebrations
-// @Description Returns upcoming birthdays and/or anniversaries for a workspace.
+// UpdateWorkspace godoc
+// @Summary Update a workspace
+// @Description Updates the workspace name and/or timezone. Omitted fields are unchanged. Channel timezones are independent and are not changed.
 // @Tags workspaces
+// @Accept json
 // @Produce json
 // @Param workspaceID path string true "Workspace ID"
-// @Param days query int false "Number of days to include (default 30)"
-// @Param type query string false "Filter: all|birthdays|anniversaries"
-// @Success 200 {object} OverviewResponse
+// @Param request body PatchWorkspaceRequest true "Workspace fields to update"
+// @Success 200 {object} WorkspaceResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/workspaces/{workspaceID}/overview [get]
-func (h *WorkspaceHandler) Overview(c *gin.Context) {
-	workspaceID := c.Param("workspaceID")
-	days := 30
-	if rawDays := strings.TrimSpace(c.Query("days")); rawDays != "" {
-		parsed, err := strconv.Atoi(rawDays)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a number"})
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID} [patch]
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	var req PatchWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	var name, timezone string
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "name cannot be empty"})
 			return
 		}
-		days = parsed
 	}
-
-	celebrationType := strings.ToLower(strings.TrimSpace(c.DefaultQuery("type", "all")))
-	if celebrationType != "all" && celebrationType != "birthdays" && celebrationType != "anniversaries" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of all|birthdays|anniversaries"})
-		return
+	if req.Timezone != nil {
+		timezone = strings.TrimSpace(*req.Timezone)
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid timezone"})
+			return
+		}
 	}
 
-	items, err := h.dashboardSvc.Overview(c.Request.Context(), workspaceID, days, celebrationType)
+	workspace, err := h.workspaceRepo.UpdateWorkspace(c.Request.Context(), c.Param("workspaceID"), name, timezone)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"items": items})
+	c.JSON(http.StatusOK, workspaceResponse(workspace))
 }
 
-// ListPeople godoc
-// @Summary List people in a workspace
-// @Tags people
+// ConnectionStatus godoc
+// @Summary Check the workspace Slack connection
+// @Description Calls Slack auth.test with the stored bot token to report whether it still works. Results are cached for 60 seconds.
+// @Tags workspaces
 // @Produce json
 // @Param workspaceID path string true "Workspace ID"
-// @Success 200 {object} PeopleResponse
+// @Success 200 {object} ConnectionStatusResponse
 // @Failure 404 {object} ErrorResponse
-// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/workspaces/{workspaceID}/people [get]
-func (h *WorkspaceHandler) ListPeople(c *gin.Context) {
-	workspaceID := c.Param("workspaceID")
-	people, err := h.dashboardSvc.ListPeople(c.Request.Context(), workspaceID)
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/connection-status [get]
+func (h *WorkspaceHandler) ConnectionStatus(c *gin.Context) {
+	status, err := h.slackConnection.ConnectionStatus(c.Request.Context(), c.Param("workspaceID"))
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
 			return
 		}
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "not connected") || strings.Contains(msg, "slack api error") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ConnectionStatusResponse{
+		Connected: status.Connected,
+		BotUserID: status.BotUserID,
+		TeamID:    status.TeamID,
+		Error:     status.Error,
+	})
+}
+
+// MemberSyncStatus godoc
+// @Summary Get the Slack member sync status
+// @Description Reports when the workspace's Slack members were last copied into people and how many there were. last_synced_at is null before the first sync.
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} MemberSyncStatusResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/member-sync/status [get]
+func (h *WorkspaceHandler) MemberSyncStatus(c *gin.Context) {
+	status, err := h.memberSync.SyncStatus(c.Request.Context(), c.Param("workspaceID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"people": people})
+	resp := MemberSyncStatusResponse{MemberCount: status.MemberCount}
+	if !status.SyncedAt.IsZero() {
+		syncedAt := status.SyncedAt.UTC()
+		resp.LastSyncedAt = &syncedAt
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+const confirmDeleteHeader = "X-Confirm-Delete"
+
+// DeleteWorkspace godoc
+// @Summary Delete a workspace
+// @Description Permanently deletes the workspace with its channels, people, dispatch and onboarding logs. Requires X-Confirm-Delete: true.
+// @Tags workspaces
+// @Param workspaceID path string true "Workspace ID"
+// @Param X-Confirm-Delete header string true "Must be true"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID} [delete]
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	if !strings.EqualFold(strings.TrimSpace(c.GetHeader(confirmDeleteHeader)), "true") {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: confirmDeleteHeader + ": true header is required"})
+		return
+	}
+
+	if err := h.dashboardSvc.DeleteWorkspace(c.Request.Context(), c.Param("workspaceID")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func workspaceResponse(w domain.Workspace) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:                   w.ID,
+		SlackTeamID:          w.SlackTeamID,
+		Name:                 w.Name,
+		Timezone:             w.Timezone,
+		BirthdaysEnabled:     w.BirthdaysEnabled,
+		AnniversariesEnabled: w.AnniversariesEnabled,
+		CreatedAt:            w.CreatedAt,
+		UpdatedAt:            w.UpdatedAt,
+	}
+}
+
+// BootstrapWorkspace godoc
+// @Summary Bootstrap a workspace
+// @Description Creates or updates a workspace and its default celebration channel. When the workspace already has a bot token, the bot must be able to access (or auto-join) the channel.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param request body BootstrapWorkspaceRequest true "Workspace bootstrap payload"
+// @Success 201 {object} BootstrapWorkspaceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/bootstrap [post]
+func (h *WorkspaceHandler) BootstrapWorkspace(c *gin.Context) {
+	var req BootstrapWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "invalid timezone"})
+		return
+	}
+
+	if _, err := time.Parse("15:04", req.PostingTime); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "posting_time must use HH:MM"})
+		return
+	}
+
+	install, err := h.workspaceRepo.GetSlackInstallationByTeamID(c.Request.Context(), req.SlackTeamID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+	if err == nil && strings.TrimSpace(install.BotToken) != "" {
+		if _, err := h.slackChannels.EnsureBotInChannel(c.Request.Context(), install.WorkspaceID, req.ChannelID); err != nil {
+			if errors.Is(err, service.ErrBotNotInChannel) {
+				c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: service.ErrBotNotInChannel.Error()})
+				return
+			}
+			if errors.Is(err, service.ErrSlackAPIError) {
+				respondSlackError(c, err)
+				return
+			}
+			c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+			return
+		}
+	}
+
+	var workspace domain.Workspace
+	if install.WorkspaceID != "" {
+		workspace, err = h.workspaceRepo.UpdateWorkspace(c.Request.Context(), install.WorkspaceID, req.Name, req.Timezone)
+	} else {
+		workspace, err = h.workspaceRepo.EnsureWorkspace(c.Request.Context(), req.SlackTeamID, req.Name, req.Timezone)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	channel, err := h.workspaceRepo.CreateDefaultChannel(c.Request.Context(), workspace.ID, req.ChannelID, req.ChannelName, req.Timezone, req.PostingTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"workspace": workspace,
+		"channel":   channel,
+	})
+}
+
+// Overview godoc
+// @Summary List upcoming celebrations
+// @Description Returns upcoming birthdays and/or anniversaries for a workspace.
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param days query int false "Number of days to include (default 30)"
+// @Param type query string false "Filter: all|birthdays|anniversaries"
+// @Success 200 {object} OverviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/overview [get]
+func (h *WorkspaceHandler) Overview(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	days := 30
+	if rawDays := strings.TrimSpace(c.Query("days")); rawDays != "" {
+		parsed, err := strconv.Atoi(rawDays)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "days must be a number"})
+			return
+		}
+		days = parsed
+	}
+
+	celebrationType := strings.ToLower(strings.TrimSpace(c.DefaultQuery("type", "all")))
+	if celebrationType != "all" && celebrationType != "birthdays" && celebrationType != "anniversaries" {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "type must be one of all|birthdays|anniversaries"})
+		return
+	}
+
+	items, err := h.dashboardSvc.Overview(c.Request.Context(), workspaceID, days, celebrationType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// Forecast godoc
+// @Summary Forecast celebration counts
+// @Description Returns per-day birthday and anniversary counts for the next N days. Days without celebrations are omitted. Results are cached for up to one hour per workspace.
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param days query int false "Number of days to include (default 90, 1 to 365)"
+// @Success 200 {object} ForecastResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/forecast [get]
+func (h *WorkspaceHandler) Forecast(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	days := service.DefaultForecastDays
+	if rawDays := strings.TrimSpace(c.Query("days")); rawDays != "" {
+		parsed, err := strconv.Atoi(rawDays)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "days must be a number"})
+			return
+		}
+		days = parsed
+	}
+	if days <= 0 {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "days must be a positive number"})
+		return
+	}
+	if days > service.MaxForecastDays {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "days must be at most " + strconv.Itoa(service.MaxForecastDays)})
+		return
+	}
+
+	forecast, err := h.dashboardSvc.Forecast(c.Request.Context(), workspaceID, days)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	items := make([]ForecastDayItem, 0, len(forecast))
+	for _, day := range forecast {
+		items = append(items, ForecastDayItem{
+			Date:             day.Date.Format("2006-01-02"),
+			BirthdayCount:    day.BirthdayCount,
+			AnniversaryCount: day.AnniversaryCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, ForecastResponse{Days: items})
+}
+
+// ListPeople godoc
+// @Summary List people in a workspace
+// @Description With missing set, only saved people lacking that data are listed and the body is a MissingDataPeopleResponse.
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param q query string false "Case-insensitive filter on display name or Slack handle"
+// @Param missing query string false "Only people missing this data" Enums(birthday, hire_date, any)
+// @Param limit query int false "Page size (default 100, max 500)"
+// @Param offset query int false "Number of people to skip"
+// @Success 200 {object} PeopleResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people [get]
+func (h *WorkspaceHandler) ListPeople(c *gin.Context) {
+	limit, offset, err := parsePeoplePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	workspaceID := c.Param("workspaceID")
+	query := strings.TrimSpace(c.Query("q"))
+	if missing := strings.TrimSpace(c.Query("missing")); missing != "" {
+		if query != "" {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "q and missing cannot be combined"})
+			return
+		}
+		h.listPeopleMissingData(c, workspaceID, repository.MissingData(missing), limit, offset)
+		return
+	}
+
+	var (
+		people []domain.Person
+		total  int
+	)
+	if query != "" {
+		people, total, err = h.dashboardSvc.SearchPeople(c.Request.Context(), workspaceID, query, limit, offset)
+	} else {
+		people, total, err = h.dashboardSvc.ListPeople(c.Request.Context(), workspaceID, limit, offset)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PeopleResponse{People: people, TotalCount: total, Limit: limit, Offset: offset})
+}
+
+func (h *WorkspaceHandler) listPeopleMissingData(c *gin.Context, workspaceID string, missing repository.MissingData, limit, offset int) {
+	people, total, err := h.dashboardSvc.ListPeopleMissingData(c.Request.Context(), workspaceID, missing, limit, offset)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, MissingDataPeopleResponse{
+		Missing:    string(missing),
+		People:     people,
+		TotalCount: total,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// ListChannelPeople godoc
+// @Summary List people celebrated in a channel
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel ID"
+// @Param limit query int false "Page size (default 100, max 500)"
+// @Param offset query int false "Number of people to skip"
+// @Success 200 {object} PeopleResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/people [get]
+func (h *WorkspaceHandler) ListChannelPeople(c *gin.Context) {
+	limit, offset, err := parsePeoplePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	workspaceID := c.Param("workspaceID")
+	channelID := c.Param("channelID")
+	people, total, err := h.dashboardSvc.ListChannelPeople(c.Request.Context(), workspaceID, channelID, limit, offset)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PeopleResponse{People: people, TotalCount: total, Limit: limit, Offset: offset})
+}
+
+// BirthdayDuplicates godoc
+// @Summary List people who share a birthday
+// @Description Returns groups of two or more people with the same birthday month and day.
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} BirthdayDuplicatesResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/duplicates [get]
+func (h *WorkspaceHandler) BirthdayDuplicates(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	groups, err := h.dashboardSvc.FindBirthdayDuplicates(c.Request.Context(), workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	items := make([]BirthdayDuplicateGroup, 0, len(groups))
+	for _, g := range groups {
+		items = append(items, BirthdayDuplicateGroup{
+			Month:  g.Month,
+			Day:    g.Day,
+			Count:  len(g.People),
+			People: g.People,
+		})
+	}
+
+	c.JSON(http.StatusOK, BirthdayDuplicatesResponse{Groups: items})
+}
+
+// UpsertPerson godoc
+// @Summary Create or update a person
+// @Tags people
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack User ID"
+// @Param request body UpsertPersonRequest true "Person payload"
+// @Success 200 {object} slackcheers_internal_domain.Person
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID} [put]
+func (h *WorkspaceHandler) UpsertPerson(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	slackUserID := c.Param("slackUserID")
+
+	var req UpsertPersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	var hireDate *time.Time
+	if strings.TrimSpace(req.HireDate) != "" {
+		parsed, err := time.Parse("2006-01-02", req.HireDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "hire_date must use YYYY-MM-DD"})
+			return
+		}
+		hireDate = &parsed
+	}
+
+	mode := strings.TrimSpace(req.RemindersMode)
+	if mode == "" {
+		mode = "same_day"
+	}
+	if !isValidRemindersMode(mode) {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "reminders_mode must be none|same_day|day_before|week_before"})
+		return
+	}
+
+	publicCelebrationOptIn := true
+	if req.PublicCelebrationOptIn != nil {
+		publicCelebrationOptIn = *req.PublicCelebrationOptIn
+	}
+
+	person, err := h.dashboardSvc.UpsertPerson(c.Request.Context(), repository.UpsertPersonInput{
+		WorkspaceID:            workspaceID,
+		SlackUserID:            slackUserID,
+		SlackHandle:            req.SlackHandle,
+		DisplayName:            req.DisplayName,
+		AvatarURL:              req.AvatarURL,
+		BirthdayDay:            req.BirthdayDay,
+		BirthdayMonth:          req.BirthdayMonth,
+		BirthdayYear:           req.BirthdayYear,
+		HireDate:               hireDate,
+		PublicCelebrationOptIn: publicCelebrationOptIn,
+		RemindersMode:          mode,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// ClearBirthday godoc
+// @Summary Remove a person's birthday
+// @Tags people
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID}/birthday [delete]
+func (h *WorkspaceHandler) ClearBirthday(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	slackUserID := c.Param("slackUserID")
+
+	if _, err := h.dashboardSvc.ClearBirthday(c.Request.Context(), workspaceID, slackUserID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeletePerson godoc
+// @Summary Soft-delete a person
+// @Description Hides the person from listings and celebrations. The record, including their opt-out choice, is kept so they can be restored; saving the person again also restores them.
+// @Tags people
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID} [delete]
+func (h *WorkspaceHandler) DeletePerson(c *gin.Context) {
+	if err := h.dashboardSvc.DeletePerson(c.Request.Context(), c.Param("workspaceID"), c.Param("slackUserID")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ErasePersonData godoc
+// @Summary Permanently erase a person's data
+// @Description Hard-deletes the person, their reminder and onboarding DM logs, and celebration post log entries naming them. Pending scheduled celebrations naming them are cancelled first on a best-effort basis. Unlike DELETE /people/{slackUserID} nothing can be restored; only an audit row (workspace, Slack user ID, time) is kept. Requires X-Confirm-Delete: true.
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Param X-Confirm-Delete header string true "Must be true"
+// @Success 200 {object} PersonErasureResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID}/data [delete]
+func (h *WorkspaceHandler) ErasePersonData(c *gin.Context) {
+	if !strings.EqualFold(strings.TrimSpace(c.GetHeader(confirmDeleteHeader)), "true") {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: confirmDeleteHeader + ": true header is required"})
+		return
+	}
+
+	erasure, err := h.dataErasure.ErasePerson(c.Request.Context(), c.Param("workspaceID"), c.Param("slackUserID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PersonErasureResponse{
+		DeletedTables: erasure.DeletedTables,
+		RowsAffected:  erasure.RowsAffected,
+	})
+}
+
+// RestorePerson godoc
+// @Summary Restore a soft-deleted person
+// @Tags people
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID}/restore [post]
+func (h *WorkspaceHandler) RestorePerson(c *gin.Context) {
+	if err := h.dashboardSvc.RestorePerson(c.Request.Context(), c.Param("workspaceID"), c.Param("slackUserID")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListReminders godoc
+// @Summary List a person's scheduled reminders
+// @Description Computes when the person's next birthday and work anniversary reminders fire based on their reminders_mode. Returns an empty list when reminders_mode is none.
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Success 200 {array} service.ScheduledReminder
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID}/reminders [get]
+func (h *WorkspaceHandler) ListReminders(c *gin.Context) {
+	reminders, err := h.reminders.ListScheduledReminders(c.Request.Context(), c.Param("workspaceID"), c.Param("slackUserID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// ImportPeople godoc
+// @Summary Import people from a CSV file
+// @Description Upserts one person per CSV row. The header row is required and must include slack_user_id; display_name, slack_handle, birthday_day, birthday_month, birthday_year and hire_date (YYYY-MM-DD) are optional. Invalid rows are skipped and reported in errors.
+// @Tags people
+// @Accept multipart/form-data
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param file formData file true "People CSV"
+// @Param column_map formData string false "JSON object mapping CSV header names to people fields"
+// @Success 200 {object} BulkImportPeopleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/import [post]
+func (h *WorkspaceHandler) ImportPeople(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "file is required"})
+		return
+	}
+
+	var columnMap service.ColumnMap
+	if raw := strings.TrimSpace(c.PostForm("column_map")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &columnMap); err != nil {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "column_map must be a JSON object of strings"})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	rows, rowErrors, err := service.ParsePeopleCSV(file, workspaceID, columnMap)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	result, err := h.dashboardSvc.BulkImportPeople(c.Request.Context(), workspaceID, rows)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkImportPeopleResponse{
+		Imported: result.Imported,
+		Skipped:  result.Skipped + len(rowErrors),
+		Errors:   append(rowErrors, result.Errors...),
+	})
+}
+
+// ExportWorkspace godoc
+// @Summary Export a workspace as a ZIP archive
+// @Description Downloads people.csv (the people import schema), channels.json (every channel with its settings) and templates.json (channel ID to birthday_template, anniversary_template and branding_emoji). The archive can be loaded into another instance with POST /import.
+// @Tags workspaces
+// @Produce application/zip
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/export [get]
+func (h *WorkspaceHandler) ExportWorkspace(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	people, err := h.dashboardSvc.ExportPeople(c.Request.Context(), workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	channels, err := h.dashboardSvc.ListChannels(c.Request.Context(), workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="workspace-`+workspaceID+`.zip"`)
+	c.Status(http.StatusOK)
+	if err := service.WriteWorkspaceArchive(c.Writer, people, channels); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// ImportWorkspace godoc
+// @Summary Import a workspace ZIP archive
+// @Description Loads an archive from GET /export into this workspace. People are upserted like the CSV import; channels are created or updated by Slack channel ID and get their settings and templates from the archive. Invalid people rows and channels are skipped and reported in errors.
+// @Tags workspaces
+// @Accept multipart/form-data
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param file formData file true "Workspace export ZIP"
+// @Success 200 {object} service.WorkspaceImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/import [post]
+func (h *WorkspaceHandler) ImportWorkspace(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	archive, err := service.ReadWorkspaceArchive(file, fileHeader.Size, workspaceID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	result, err := h.dashboardSvc.ImportWorkspace(c.Request.Context(), workspaceID, archive)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportPeople godoc
+// @Summary Export people
+// @Description Downloads every saved person in the workspace. The CSV uses the same columns as the import endpoint.
+// @Tags people
+// @Produce json
+// @Produce text/csv
+// @Param workspaceID path string true "Workspace ID"
+// @Param format query string false "Response format: csv (default) or json"
+// @Success 200 {array} PersonExportItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/export [get]
+func (h *WorkspaceHandler) ExportPeople(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "csv")))
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "format must be json|csv"})
+		return
+	}
+
+	people, err := h.dashboardSvc.ExportPeople(c.Request.Context(), workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="people-`+workspaceID+`.csv"`)
+		c.Status(http.StatusOK)
+		if err := service.WritePeopleCSV(c.Writer, people); err != nil {
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	items := make([]PersonExportItem, 0, len(people))
+	for _, p := range people {
+		hireDate := ""
+		if p.HireDate != nil {
+			hireDate = p.HireDate.Format("2006-01-02")
+		}
+		items = append(items, PersonExportItem{
+			SlackUserID:            p.SlackUserID,
+			DisplayName:            p.DisplayName,
+			SlackHandle:            p.SlackHandle,
+			BirthdayDay:            p.BirthdayDay,
+			BirthdayMonth:          p.BirthdayMonth,
+			BirthdayYear:           p.BirthdayYear,
+			HireDate:               hireDate,
+			PublicCelebrationOptIn: p.PublicCelebrationOptIn,
+			RemindersMode:          p.RemindersMode,
+		})
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// ExportPersonData godoc
+// @Summary Export everything stored about a person
+// @Description Downloads every stored field for one person plus when onboarding DMs were sent to them, for data access requests.
+// @Tags people
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param slackUserID path string true "Slack user ID"
+// @Success 200 {object} PersonDataExportResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/{slackUserID}/export [get]
+func (h *WorkspaceHandler) ExportPersonData(c *gin.Context) {
+	slackUserID := c.Param("slackUserID")
+
+	export, err := h.dashboardSvc.ExportPersonData(c.Request.Context(), c.Param("workspaceID"), slackUserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "person not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	p := export.Person
+	var hireDate *string
+	if p.HireDate != nil {
+		formatted := p.HireDate.Format("2006-01-02")
+		hireDate = &formatted
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="person-`+slackUserID+`.json"`)
+	c.JSON(http.StatusOK, PersonDataExportResponse{
+		ID:                     p.ID,
+		WorkspaceID:            p.WorkspaceID,
+		SlackUserID:            p.SlackUserID,
+		SlackHandle:            p.SlackHandle,
+		DisplayName:            p.DisplayName,
+		AvatarURL:              p.AvatarURL,
+		BirthdayDay:            p.BirthdayDay,
+		BirthdayMonth:          p.BirthdayMonth,
+		BirthdayYear:           p.BirthdayYear,
+		HireDate:               hireDate,
+		PublicCelebrationOptIn: p.PublicCelebrationOptIn,
+		RemindersMode:          p.RemindersMode,
+		CreatedAt:              p.CreatedAt,
+		UpdatedAt:              p.UpdatedAt,
+		OnboardingDMSentAt:     export.OnboardingDMSentAt,
+	})
+}
+
+// BulkUpdateRemindersMode godoc
+// @Summary Bulk update people reminders mode
+// @Description Sets reminders_mode for the listed Slack users, or for every person in the workspace when user_ids is omitted.
+// @Tags people
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param request body BulkUpdateRemindersModeRequest true "Bulk reminders mode payload"
+// @Success 200 {object} BulkUpdateRemindersModeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/people/bulk-reminders-mode [put]
+func (h *WorkspaceHandler) BulkUpdateRemindersMode(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	var req BulkUpdateRemindersModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	mode := strings.TrimSpace(req.RemindersMode)
+	if !isValidRemindersMode(mode) {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "reminders_mode must be none|same_day|day_before|week_before"})
+		return
+	}
+	if req.UserIDs != nil && len(req.UserIDs) == 0 {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "user_ids must not be empty; omit it to update all people"})
+		return
+	}
+
+	updated, err := h.dashboardSvc.BulkUpdateRemindersMode(c.Request.Context(), workspaceID, mode, req.UserIDs)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkUpdateRemindersModeResponse{Updated: updated})
+}
+
+// GetPrivacySettings godoc
+// @Summary Get workspace privacy settings
+// @Tags workspaces
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} PrivacySettingsResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/privacy [get]
+func (h *WorkspaceHandler) GetPrivacySettings(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	settings, err := h.dashboardSvc.GetPrivacySettings(c.Request.Context(), workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PrivacySettingsResponse{BirthdayYearPrivacy: settings.BirthdayYearPrivacy})
+}
+
+// UpdatePrivacySettings godoc
+// @Summary Update workspace privacy settings
+// @Description birthday_year_privacy=discard stops storing birth years for new and updated people.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param request body UpdatePrivacySettingsRequest true "Privacy settings payload"
+// @Success 200 {object} PrivacySettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/privacy [put]
+func (h *WorkspaceHandler) UpdatePrivacySettings(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	var req UpdatePrivacySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	settings, err := h.dashboardSvc.UpdatePrivacySettings(c.Request.Context(), workspaceID, repository.WorkspacePrivacySettings{
+		BirthdayYearPrivacy: strings.TrimSpace(req.BirthdayYearPrivacy),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PrivacySettingsResponse{BirthdayYearPrivacy: settings.BirthdayYearPrivacy})
+}
+
+// UpdateOnboardingTemplate godoc
+// @Summary Set the onboarding DM template
+// @Description Customizes the onboarding DM sent to workspace members. {name} is replaced with the member's display name. An empty template restores the built-in message.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param request body UpdateOnboardingTemplateRequest true "Onboarding template payload"
+// @Success 200 {object} OnboardingTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/onboarding/template [put]
+func (h *WorkspaceHandler) UpdateOnboardingTemplate(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+
+	var req UpdateOnboardingTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	if err := h.onboardingSvc.UpdateOnboardingTemplate(c.Request.Context(), workspaceID, req.Template); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, OnboardingTemplateResponse{
+		WorkspaceID: workspaceID,
+		Template:    strings.TrimSpace(req.Template),
+	})
 }
 
-// UpsertPerson godoc
-// @Summary Create or update a person
-// @Tags people
+// UpdateAnnouncementChannel godoc
+// @Summary Set the workspace announcement channel
+// @Description Sets the channel where the workspace-wide weekly digest is posted. The bot must be a member of the channel. An empty slack_channel_id clears it.
+// @Tags workspaces
 // @Accept json
 // @Produce json
 // @Param workspaceID path string true "Workspace ID"
-// @Param slackUserID path string true "Slack User ID"
-// @Param request body UpsertPersonRequest true "Person payload"
-// @Success 200 {object} slackcheers_internal_domain.Person
+// @Param request body UpdateAnnouncementChannelRequest true "Announcement channel payload"
+// @Success 200 {object} AnnouncementChannelResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/workspaces/{workspaceID}/people/{slackUserID} [put]
-func (h *WorkspaceHandler) UpsertPerson(c *gin.Context) {
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/announcement-channel [patch]
+func (h *WorkspaceHandler) UpdateAnnouncementChannel(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
-	slackUserID := c.Param("slackUserID")
 
-	var req UpsertPersonRequest
+	var req UpdateAnnouncementChannelRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
-	var hireDate *time.Time
-	if strings.TrimSpace(req.HireDate) != "" {
-		parsed, err := time.Parse("2006-01-02", req.HireDate)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "hire_date must use YYYY-MM-DD"})
+	channelID := strings.TrimSpace(req.SlackChannelID)
+	if err := h.dashboardSvc.UpdateAnnouncementChannel(c.Request.Context(), workspaceID, channelID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
 			return
 		}
-		hireDate = &parsed
-	}
-
-	mode := strings.TrimSpace(req.RemindersMode)
-	if mode == "" {
-		mode = "same_day"
-	}
-	if mode != "none" && mode != "same_day" && mode != "day_before" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "reminders_mode must be none|same_day|day_before"})
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrChannelArchived) || errors.Is(err, service.ErrBotNotChannelMember) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
-	publicCelebrationOptIn := true
-	if req.PublicCelebrationOptIn != nil {
-		publicCelebrationOptIn = *req.PublicCelebrationOptIn
-	}
-
-	person, err := h.dashboardSvc.UpsertPerson(c.Request.Context(), repository.UpsertPersonInput{
-		WorkspaceID:            workspaceID,
-		SlackUserID:            slackUserID,
-		SlackHandle:            req.SlackHandle,
-		DisplayName:            req.DisplayName,
-		AvatarURL:              req.AvatarURL,
-		BirthdayDay:            req.BirthdayDay,
-		BirthdayMonth:          req.BirthdayMonth,
-		BirthdayYear:           req.BirthdayYear,
-		HireDate:               hireDate,
-		PublicCelebrationOptIn: publicCelebrationOptIn,
-		RemindersMode:          mode,
+	c.JSON(http.StatusOK, AnnouncementChannelResponse{
+		WorkspaceID:           workspaceID,
+		AnnouncementChannelID: channelID,
 	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, person)
 }
 
 // ListChannels godoc
@@ -339,12 +1731,13 @@ func (h *WorkspaceHandler) UpsertPerson(c *gin.Context) {
 // @Param workspaceID path string true "Workspace ID"
 // @Success 200 {object} ChannelsResponse
 // @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/channels [get]
 func (h *WorkspaceHandler) ListChannels(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
 	channels, err := h.dashboardSvc.ListChannels(c.Request.Context(), workspaceID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -362,6 +1755,8 @@ func (h *WorkspaceHandler) ListChannels(c *gin.Context) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/onboarding/dm [post]
 func (h *WorkspaceHandler) SendOnboardingDMs(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
@@ -369,15 +1764,18 @@ func (h *WorkspaceHandler) SendOnboardingDMs(c *gin.Context) {
 	result, err := h.onboardingSvc.SendOnboardingDMs(c.Request.Context(), workspaceID, force)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
 			return
 		}
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "not connected") || strings.Contains(msg, "slack api error") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -391,6 +1789,48 @@ func (h *WorkspaceHandler) SendOnboardingDMs(c *gin.Context) {
 	})
 }
 
+// OnboardingProgress godoc
+// @Summary Get onboarding DM progress
+// @Description Returns how many onboarding DMs were sent and how many recipients have shared their birthday, work start date, or both. Members not yet sent a DM are counted against the last Slack member sync.
+// @Tags onboarding
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} OnboardingProgressResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/onboarding/progress [get]
+func (h *WorkspaceHandler) OnboardingProgress(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	progress, err := h.onboardingProgress.GetProgress(c.Request.Context(), workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, OnboardingProgressResponse{
+		TotalSent:         progress.TotalSent,
+		RespondedBirthday: progress.RespondedBirthday,
+		RespondedHireDate: progress.RespondedHireDate,
+		RespondedBoth:     progress.RespondedBoth,
+		Pending:           progress.Pending,
+		NotSent:           progress.NotSent,
+	})
+}
+
 // CleanupOnboardingDMs godoc
 // @Summary Delete bot-authored DM history for a user
 // @Description Deletes past messages authored by SlackCheers bot in the DM with the selected user.
@@ -402,32 +1842,41 @@ func (h *WorkspaceHandler) SendOnboardingDMs(c *gin.Context) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/onboarding/dm/cleanup [post]
 func (h *WorkspaceHandler) CleanupOnboardingDMs(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
 	userID := strings.TrimSpace(c.Query("user_id"))
 	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "user_id is required"})
 		return
 	}
 
 	if h.dmCleanupSvc == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "dm cleanup service is not configured"})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: "dm cleanup service is not configured"})
 		return
 	}
 
 	result, err := h.dmCleanupSvc.CleanupBotDirectMessages(c.Request.Context(), workspaceID, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
+			return
+		}
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
 			return
 		}
-		msg := strings.ToLower(err.Error())
-		if strings.Contains(msg, "not connected") || strings.Contains(msg, "slack api error") || strings.Contains(msg, "required") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -453,20 +1902,25 @@ func (h *WorkspaceHandler) CleanupOnboardingDMs(c *gin.Context) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/slack/channels [get]
 func (h *WorkspaceHandler) ListSlackChannels(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
 	channels, err := h.slackChannels.ListChannels(c.Request.Context(), workspaceID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "workspace not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
 			return
 		}
-		if strings.Contains(strings.ToLower(err.Error()), "not connected") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrNotConnected) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeNotConnected, Message: err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
 		return
 	}
 
@@ -482,6 +1936,30 @@ func (h *WorkspaceHandler) ListSlackChannels(c *gin.Context) {
 	c.JSON(http.StatusOK, SlackChannelsResponse{Channels: items})
 }
 
+// DeleteChannel godoc
+// @Summary Remove a celebration channel
+// @Description Deletes the channel configuration and its dispatch history. The workspace's last channel can be removed too.
+// @Tags channels
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel ID or Slack channel ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID} [delete]
+func (h *WorkspaceHandler) DeleteChannel(c *gin.Context) {
+	if err := h.dashboardSvc.DeleteChannel(c.Request.Context(), c.Param("workspaceID"), c.Param("channelID")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // UpdateChannelSettings godoc
 // @Summary Update channel settings
 // @Tags channels
@@ -490,9 +1968,12 @@ func (h *WorkspaceHandler) ListSlackChannels(c *gin.Context) {
 // @Param workspaceID path string true "Workspace ID"
 // @Param channelID path string true "Channel ID"
 // @Param request body UpdateChannelSettingsRequest true "Channel settings payload"
+// @Param validate query bool false "Send a test ping to post_dispatch_webhook_url and require a 2xx response"
+// @Param skip_channel_validation query bool false "Skip checking that the Slack channel exists, is not archived, and has the bot as a member"
 // @Success 200 {object} slackcheers_internal_domain.WorkspaceChannel
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/channels/{channelID}/settings [put]
 func (h *WorkspaceHandler) UpdateChannelSettings(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
@@ -500,25 +1981,37 @@ func (h *WorkspaceHandler) UpdateChannelSettings(c *gin.Context) {
 
 	var req UpdateChannelSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
-	channel, err := h.dashboardSvc.UpdateChannelSettings(
-		c.Request.Context(),
-		workspaceID,
-		channelID,
-		req.PostingTime,
-		req.Timezone,
-		*req.BirthdaysEnabled,
-		*req.AnniversariesEnabled,
-	)
+	channel, err := h.dashboardSvc.UpdateChannelSettings(c.Request.Context(), repository.UpdateChannelSettingsInput{
+		WorkspaceID:                workspaceID,
+		ChannelID:                  channelID,
+		PostingTime:                req.PostingTime,
+		Timezone:                   req.Timezone,
+		BirthdaysEnabled:           *req.BirthdaysEnabled,
+		AnniversariesEnabled:       *req.AnniversariesEnabled,
+		MinAnniversaryTenureMonths: req.MinAnniversaryTenureMonths,
+		PostDispatchWebhookURL:     req.PostDispatchWebhookURL,
+		MaxRecipientsPerPost:       req.MaxRecipientsPerPost,
+		AnniversaryMilestones:      req.AnniversaryMilestones,
+		SkipWeekends:               req.SkipWeekends,
+		ReactionEmoji:              req.ReactionEmoji,
+	}, service.UpdateChannelSettingsOptions{
+		PingWebhook:           c.Query("validate") == "true",
+		SkipChannelValidation: c.Query("skip_channel_validation") == "true",
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "channel not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSlackAPIError) {
+			respondSlackError(c, err)
 			return
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
@@ -527,6 +2020,7 @@ func (h *WorkspaceHandler) UpdateChannelSettings(c *gin.Context) {
 
 // UpdateChannelTemplates godoc
 // @Summary Update channel templates
+// @Description Templates may hold several alternatives separated by |||. Every alternative must be non-empty and use only known variables.
 // @Tags channels
 // @Accept json
 // @Produce json
@@ -536,6 +2030,7 @@ func (h *WorkspaceHandler) UpdateChannelSettings(c *gin.Context) {
 // @Success 200 {object} slackcheers_internal_domain.WorkspaceChannel
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth
 // @Router /api/workspaces/{workspaceID}/channels/{channelID}/templates [put]
 func (h *WorkspaceHandler) UpdateChannelTemplates(c *gin.Context) {
 	workspaceID := c.Param("workspaceID")
@@ -543,7 +2038,7 @@ func (h *WorkspaceHandler) UpdateChannelTemplates(c *gin.Context) {
 
 	var req UpdateChannelTemplatesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
@@ -557,12 +2052,357 @@ func (h *WorkspaceHandler) UpdateChannelTemplates(c *gin.Context) {
 	)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "channel not found"})
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
 			return
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, channel)
 }
+
+// PreviewChannelTemplates godoc
+// @Summary Preview channel templates
+// @Description Renders birthday and anniversary templates for a sample person without posting to Slack. Omitted fields fall back to the channel's saved templates and emoji; without slack_user_id the first saved person is used.
+// @Tags channels
+// @Accept json
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel ID"
+// @Param request body PreviewTemplateRequest true "Templates to preview"
+// @Success 200 {object} PreviewTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/templates/preview [post]
+func (h *WorkspaceHandler) PreviewChannelTemplates(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	channelID := c.Param("channelID")
+
+	var req PreviewTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	preview, err := h.dashboardSvc.PreviewCelebrationMessage(c.Request.Context(), workspaceID, channelID, service.PreviewTemplateInput{
+		BirthdayTemplate:    req.BirthdayTemplate,
+		AnniversaryTemplate: req.AnniversaryTemplate,
+		BrandingEmoji:       req.BrandingEmoji,
+		SlackUserID:         req.SlackUserID,
+	}, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel or person not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PreviewTemplateResponse{
+		BirthdayPreview:    preview.BirthdayPreview,
+		AnniversaryPreview: preview.AnniversaryPreview,
+		SampleUserID:       preview.SampleUserID,
+	})
+}
+
+// ChannelDispatchLog godoc
+// @Summary List channel dispatch log
+// @Description Returns one page of a channel's dispatch history, newest first, with the total entry count. An empty log is returned as an empty list. Pass format=csv to download the full history as CSV.
+// @Tags channels
+// @Produce json
+// @Produce text/csv
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel ID"
+// @Param format query string false "Response format: json (default) or csv"
+// @Param from query string false "Earliest dispatch date (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Latest dispatch date (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Page size (default 30, max 100)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} DispatchLogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/dispatch-log [get]
+func (h *WorkspaceHandler) ChannelDispatchLog(c *gin.Context) {
+	workspaceID := c.Param("workspaceID")
+	channelID := c.Param("channelID")
+
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "json")))
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "format must be json|csv"})
+		return
+	}
+
+	from, err := parseDateBound(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "from must be RFC3339 or YYYY-MM-DD"})
+		return
+	}
+	to, err := parseDateBound(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "to must be RFC3339 or YYYY-MM-DD"})
+		return
+	}
+	if from != nil && to != nil && from.After(*to) {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "from must not be after to"})
+		return
+	}
+
+	channel, err := h.dashboardSvc.GetChannel(c.Request.Context(), workspaceID, channelID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="dispatch-log-`+channel.SlackChannelID+`.csv"`)
+		c.Status(http.StatusOK)
+		if err := h.dashboardSvc.ExportDispatchLogCSV(c.Request.Context(), channel.ID, from, to, c.Writer); err != nil {
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	limit, offset, err := parseLimitOffset(c, defaultHistoryPerPage, maxHistoryPerPage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+
+	var (
+		entries []domain.DispatchLogEntry
+		total   int
+	)
+	if from == nil && to == nil {
+		var page []repository.ChannelDispatchEntry
+		page, total, err = h.dashboardSvc.ListChannelHistory(c.Request.Context(), workspaceID, channel.ID, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+			return
+		}
+		for _, entry := range page {
+			entries = append(entries, entry.DispatchLogEntry)
+		}
+	} else {
+		entries, err = h.dashboardSvc.ListDispatchLog(c.Request.Context(), channel.ID, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+			return
+		}
+		total = len(entries)
+		entries = entries[min(offset, total):min(offset+limit, total)]
+	}
+
+	items := make([]DispatchLogItem, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, DispatchLogItem{
+			DispatchDate:        entry.DispatchDate.Format("2006-01-02"),
+			ChannelID:           entry.WorkspaceChannelID,
+			SlackChannelID:      entry.SlackChannelID,
+			BirthdayCount:       entry.BirthdayCount,
+			AnniversaryCount:    entry.AnniversaryCount,
+			BirthdayUserIDs:     entry.BirthdayUserIDs,
+			AnniversaryUserIDs:  entry.AnniversaryUserIDs,
+			MessageTS:           entry.MessageTS,
+			MessageTimestamps:   entry.MessageTimestamps,
+			MessageURL:          entry.MessageURL,
+			ScheduledMessageIDs: entry.ScheduledMessageIDs,
+			CreatedAt:           entry.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, DispatchLogResponse{Entries: items, TotalCount: total, Limit: limit, Offset: offset})
+}
+
+// ClearDispatchLogEntry godoc
+// @Summary Clear a channel dispatch log entry
+// @Description Deletes the channel's dispatch log entry for a date so the channel is picked up again by dispatch-now or the scheduler the same day, without waiting for tomorrow. Requires X-Confirm-Delete: true.
+// @Tags channels
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel ID or Slack channel ID"
+// @Param date path string true "Dispatch date (YYYY-MM-DD)"
+// @Param X-Confirm-Delete header string true "Must be true"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/dispatch-log/{date} [delete]
+func (h *WorkspaceHandler) ClearDispatchLogEntry(c *gin.Context) {
+	if !strings.EqualFold(strings.TrimSpace(c.GetHeader(confirmDeleteHeader)), "true") {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: confirmDeleteHeader + ": true header is required"})
+		return
+	}
+
+	date := c.Param("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: "date must use YYYY-MM-DD"})
+		return
+	}
+
+	if err := h.dashboardSvc.ClearDispatchLogEntry(c.Request.Context(), c.Param("workspaceID"), c.Param("channelID"), date); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "dispatch log entry not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func isValidRemindersMode(mode string) bool {
+	switch mode {
+	case "none", "same_day", "day_before", "week_before":
+		return true
+	default:
+		return false
+	}
+}
+
+// ChannelHistory godoc
+// @Summary List channel celebration history
+// @Description Returns who was celebrated in the channel and when, newest first. The total number of entries is also sent in the X-Total-Count header.
+// @Tags channels
+// @Produce json
+// @Param workspaceID path string true "Workspace ID"
+// @Param channelID path string true "Channel UUID or Slack Channel ID"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Entries per page (default 30, max 100)"
+// @Success 200 {object} ChannelHistoryResponse
+// @Header 200 {integer} X-Total-Count "Total number of history entries"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/workspaces/{workspaceID}/channels/{channelID}/history [get]
+func (h *WorkspaceHandler) ChannelHistory(c *gin.Context) {
+	page, err := parsePositiveIntQuery(c, "page", 1)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+	perPage, err := parsePositiveIntQuery(c, "per_page", defaultHistoryPerPage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIError{Code: ErrCodeBadRequest, Message: err.Error()})
+		return
+	}
+	if perPage > maxHistoryPerPage {
+		perPage = maxHistoryPerPage
+	}
+
+	entries, total, err := h.dashboardSvc.ListChannelHistory(c.Request.Context(), c.Param("workspaceID"), c.Param("channelID"), perPage, (page-1)*perPage)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, APIError{Code: ErrCodeNotFound, Message: "channel not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, APIError{Code: ErrCodeInternalError, Message: err.Error()})
+		return
+	}
+
+	items := make([]ChannelHistoryItem, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, ChannelHistoryItem{
+			DispatchDate:  entry.DispatchDate.Format("2006-01-02"),
+			Birthdays:     celebratedPersonItems(entry.Birthdays),
+			Anniversaries: celebratedPersonItems(entry.Anniversaries),
+			MessageTS:     entry.MessageTS,
+			MessageURL:    entry.MessageURL,
+			DispatchedAt:  entry.CreatedAt,
+		})
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.JSON(http.StatusOK, ChannelHistoryResponse{
+		Entries: items,
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	})
+}
+
+const (
+	defaultHistoryPerPage = 30
+	maxHistoryPerPage     = 100
+	maxPeoplePageSize     = 500
+
+	defaultCelebrationHistoryLimit = 50
+	maxCelebrationHistoryLimit     = 200
+
+	defaultWorkspacesPageSize = 20
+	maxWorkspacesPageSize     = 100
+)
+
+func parsePeoplePage(c *gin.Context) (int, int, error) {
+	return parseLimitOffset(c, service.DefaultPeoplePageSize, maxPeoplePageSize)
+}
+
+func parseLimitOffset(c *gin.Context, defaultLimit, maxLimit int) (int, int, error) {
+	limit, err := parsePositiveIntQuery(c, "limit", defaultLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := 0
+	if raw := strings.TrimSpace(c.Query("offset")); raw != "" {
+		offset, err = strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+func celebratedPersonItems(people []repository.CelebratedPerson) []CelebratedPersonItem {
+	items := make([]CelebratedPersonItem, 0, len(people))
+	for _, p := range people {
+		items = append(items, CelebratedPersonItem{SlackUserID: p.SlackUserID, DisplayName: p.DisplayName})
+	}
+	return items
+}
+
+func parsePositiveIntQuery(c *gin.Context, name string, fallback int) (int, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return v, nil
+}
+
+func parseDateBound(raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
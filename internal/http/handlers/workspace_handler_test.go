@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, want string) {
+	t.Helper()
+
+	var body APIError
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error body %q: %v", rec.Body.String(), err)
+	}
+	if body.Code != want {
+		t.Fatalf("code = %q, want %q", body.Code, want)
+	}
+	if body.Message == "" {
+		t.Fatal("expected an error message")
+	}
+}
+
+func TestDeleteWorkspace_RequiresConfirmHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, header := range []string{"", "false", "yes"} {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/workspaces/W1", nil)
+		if header != "" {
+			c.Request.Header.Set(confirmDeleteHeader, header)
+		}
+		c.Params = gin.Params{{Key: "workspaceID", Value: "W1"}}
+
+		// No services: passing the header check would panic.
+		(&WorkspaceHandler{}).DeleteWorkspace(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("header %q: status = %d, want 400", header, rec.Code)
+		}
+		assertErrorCode(t, rec, ErrCodeBadRequest)
+	}
+}
+
+func TestErasePersonData_RequiresConfirmHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/workspaces/W1/people/U1/data", nil)
+	c.Params = gin.Params{{Key: "workspaceID", Value: "W1"}, {Key: "slackUserID", Value: "U1"}}
+
+	// No services: passing validation would panic.
+	(&WorkspaceHandler{}).ErasePersonData(c)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+	assertErrorCode(t, rec, ErrCodeBadRequest)
+}
+
+func TestClearDispatchLogEntry_ValidatesRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		header string
+		date   string
+	}{
+		{"missing confirm header", "", "2025-06-16"},
+		{"invalid date", "true", "16-06-2025"},
+	}
+	for _, tc := range tests {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/workspaces/W1/channels/C1/dispatch-log/"+tc.date, nil)
+		if tc.header != "" {
+			c.Request.Header.Set(confirmDeleteHeader, tc.header)
+		}
+		c.Params = gin.Params{{Key: "workspaceID", Value: "W1"}, {Key: "channelID", Value: "C1"}, {Key: "date", Value: tc.date}}
+
+		// No services: passing validation would panic.
+		(&WorkspaceHandler{}).ClearDispatchLogEntry(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want 400", tc.name, rec.Code)
+		}
+		assertErrorCode(t, rec, ErrCodeBadRequest)
+	}
+}
+
+func TestCelebrationHistory_RejectsInvalidPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"limit=0", "limit=abc", "offset=-1", "offset=x"} {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/workspaces/W1/celebration-history?"+query, nil)
+		c.Params = gin.Params{{Key: "workspaceID", Value: "W1"}}
+
+		// No services: passing validation would panic.
+		(&WorkspaceHandler{}).CelebrationHistory(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want 400", query, rec.Code)
+		}
+		assertErrorCode(t, rec, ErrCodeBadRequest)
+	}
+}
+
+func TestForecast_RejectsInvalidDays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, days := range []string{"0", "-7", "abc", "366"} {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/workspaces/W1/forecast?days="+days, nil)
+		c.Params = gin.Params{{Key: "workspaceID", Value: "W1"}}
+
+		// No services: passing validation would panic.
+		(&WorkspaceHandler{}).Forecast(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("days %s: status = %d, want 400", days, rec.Code)
+		}
+		assertErrorCode(t, rec, ErrCodeBadRequest)
+	}
+}
+
+type failingCompleter struct{ calls int }
+
+func (f *failingCompleter) Complete(context.Context, string, string, any) error {
+	f.calls++
+	return errors.New("connection reset")
+}
+
+func TestCompleteIdempotencyKey_LogsFailure(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	completer := &failingCompleter{}
+
+	completeIdempotencyKey(context.Background(), completer, logger, "W1", "key-1", map[string]string{"ok": "true"})
+
+	if completer.calls != 1 {
+		t.Fatalf("expected one Complete call, got %d", completer.calls)
+	}
+	if !strings.Contains(logs.String(), "store idempotent response failed") || !strings.Contains(logs.String(), "key-1") {
+		t.Fatalf("expected the failure to be logged, got %q", logs.String())
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const AdminAPIKeyHeader = "X-Admin-API-Key"
+
+// AdminAPIKey rejects every request when no key is configured, so admin
+// routes are never exposed by accident.
+func AdminAPIKey(key string) gin.HandlerFunc {
+	key = strings.TrimSpace(key)
+
+	return func(c *gin.Context) {
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin api is disabled", "code": "forbidden"})
+			return
+		}
+
+		provided := strings.TrimSpace(c.GetHeader(AdminAPIKeyHeader))
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin api key", "code": "unauthorized"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuth requires X-API-Key to match one of keys. With no keys configured
+// every request passes, so local setups work without extra config.
+func APIKeyAuth(keys []string) gin.HandlerFunc {
+	allowed := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			allowed = append(allowed, []byte(key))
+		}
+	}
+
+	return func(c *gin.Context) {
+		if len(allowed) == 0 {
+			c.Next()
+			return
+		}
+
+		if !matchesAPIKey(allowed, []byte(strings.TrimSpace(c.GetHeader(APIKeyHeader)))) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid api key", "code": "unauthorized"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// matchesAPIKey compares against every allowed key without stopping at the
+// first match, so timing does not reveal which key matched.
+func matchesAPIKey(allowed [][]byte, provided []byte) bool {
+	match := 0
+	for _, key := range allowed {
+		match |= subtle.ConstantTimeCompare(provided, key)
+	}
+	return match == 1
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		keys     []string
+		provided string
+		want     int
+	}{
+		{name: "no keys configured passes", keys: nil, provided: "", want: http.StatusOK},
+		{name: "blank keys configured passes", keys: []string{" ", ""}, provided: "", want: http.StatusOK},
+		{name: "missing key rejected", keys: []string{"key-one"}, provided: "", want: http.StatusUnauthorized},
+		{name: "wrong key of same length rejected", keys: []string{"key-one"}, provided: "key-two", want: http.StatusUnauthorized},
+		{name: "prefix of a key rejected", keys: []string{"key-one"}, provided: "key", want: http.StatusUnauthorized},
+		{name: "any listed key accepted", keys: []string{"key-one", "key-two"}, provided: "key-two", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/api/workspaces", APIKeyAuth(tt.keys), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/api/workspaces", nil)
+			if tt.provided != "" {
+				req.Header.Set(APIKeyHeader, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
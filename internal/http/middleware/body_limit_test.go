@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{name: "post within limit", method: http.MethodPost, body: "0123456789", wantStatus: http.StatusOK},
+		{name: "post over limit", method: http.MethodPost, body: "0123456789x", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "put over limit with unknown length", method: http.MethodPut, body: strings.Repeat("x", 50), contentLength: -1, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "get is not limited", method: http.MethodGet, body: strings.Repeat("x", 50), wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			r := gin.New()
+			r.Use(RequestBodyLimit(10, nil))
+			r.Handle(tt.method, "/", func(c *gin.Context) {
+				b, _ := io.ReadAll(c.Request.Body)
+				received = string(b)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			if tt.contentLength != 0 {
+				req.ContentLength = tt.contentLength
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusRequestEntityTooLarge {
+				if !strings.Contains(w.Body.String(), "request body too large") {
+					t.Fatalf("unexpected body: %s", w.Body.String())
+				}
+				return
+			}
+			if received != tt.body {
+				t.Fatalf("handler received %q, want %q", received, tt.body)
+			}
+		})
+	}
+}
+
+func TestRequestBodyLimit_RouteOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RequestBodyLimit(10, map[string]int64{"/import/:id": 100}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/import/:id", ok)
+	r.POST("/other", ok)
+
+	body := strings.Repeat("x", 50)
+	for path, want := range map[string]int{
+		"/import/W1": http.StatusOK,
+		"/other":     http.StatusRequestEntityTooLarge,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		if w.Code != want {
+			t.Fatalf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
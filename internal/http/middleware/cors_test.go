@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS_Preflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		allowed    []string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{name: "listed origin", allowed: []string{"https://dash.example.com"}, origin: "https://dash.example.com", wantStatus: http.StatusNoContent, wantOrigin: "https://dash.example.com"},
+		{name: "wildcard", allowed: []string{"*"}, origin: "https://anywhere.example.com", wantStatus: http.StatusNoContent, wantOrigin: "*"},
+		{name: "unlisted origin", allowed: []string{"https://dash.example.com"}, origin: "https://evil.example.com", wantStatus: http.StatusForbidden},
+		{name: "no origins configured", allowed: nil, origin: "https://dash.example.com", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(CORS(tt.allowed))
+			r.GET("/api/workspaces/:workspaceID", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodOptions, "/api/workspaces/W1", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			req.Header.Set("Access-Control-Request-Headers", "X-API-Key")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if tt.wantOrigin == "" {
+				return
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); got != corsAllowMethods {
+				t.Fatalf("Access-Control-Allow-Methods = %q", got)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Headers"); got != corsAllowHeaders {
+				t.Fatalf("Access-Control-Allow-Headers = %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_SimpleRequestGetsOriginHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(CORS([]string{"https://dash.example.com/"}))
+	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "https://dash.example.com" {
+		t.Fatalf("got status %d and origin %q", rec.Code, rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
@@ -7,11 +7,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+func RequestLogger(logger *slog.Logger, slowThreshold time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
 
+		duration := time.Since(start)
+		requestID := RequestIDFromContext(c.Request.Context())
+		if slowThreshold > 0 && duration > slowThreshold {
+			logger.Warn("slow http request",
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.String()),
+				slog.Int("status", c.Writer.Status()),
+				slog.Duration("duration", duration),
+				slog.String("remote_ip", c.ClientIP()),
+				slog.Int64("slow_request_threshold_ms", slowThreshold.Milliseconds()),
+				slog.String("request_id", requestID),
+			)
+			return
+		}
+
 		path := c.FullPath()
 		if path == "" {
 			path = c.Request.URL.Path
@@ -21,7 +36,8 @@ func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
 			slog.String("method", c.Request.Method),
 			slog.String("path", path),
 			slog.Int("status", c.Writer.Status()),
-			slog.Duration("duration", time.Since(start)),
+			slog.Duration("duration", duration),
+			slog.String("request_id", requestID),
 		)
 	}
 }
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestLogger_LevelByDuration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		sleep     time.Duration
+		wantLevel string
+		wantMsg   string
+	}{
+		{name: "slow request", sleep: 30 * time.Millisecond, wantLevel: "WARN", wantMsg: "slow http request"},
+		{name: "fast request", sleep: 0, wantLevel: "INFO", wantMsg: "http request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			r := gin.New()
+			r.Use(RequestID())
+			r.Use(RequestLogger(logger, 10*time.Millisecond))
+			r.GET("/slow", func(c *gin.Context) {
+				time.Sleep(tt.sleep)
+				c.Status(http.StatusOK)
+			})
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow?x=1", nil))
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("decode log entry: %v (%s)", err, buf.String())
+			}
+			if entry["level"] != tt.wantLevel || entry["msg"] != tt.wantMsg {
+				t.Fatalf("unexpected log entry: %v", entry)
+			}
+			if id, _ := entry["request_id"].(string); id == "" {
+				t.Fatalf("expected a request_id in the log entry, got %v", entry)
+			}
+			if tt.wantLevel == "WARN" && entry["path"] != "/slow?x=1" {
+				t.Fatalf("expected full path in slow log, got %v", entry["path"])
+			}
+		})
+	}
+}
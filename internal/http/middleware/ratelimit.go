@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	workspaceRequestsPerMinute = 5
+	limiterIdleTTL             = 10 * time.Minute
+	limiterEvictInterval       = time.Minute
+)
+
+// WorkspaceRateLimiter keeps one token bucket per workspace ID so a single
+// workspace cannot burn through the Slack API rate limit with manual runs.
+type WorkspaceRateLimiter struct {
+	limiters sync.Map // workspace ID or "ip:<addr>" -> *workspaceLimiter
+	limit    rate.Limit
+	burst    int
+}
+
+type workspaceLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen atomic.Int64 // unix nanoseconds
+}
+
+// NewWorkspaceRateLimiter allows 5 requests per minute per workspace. Limiters
+// idle for 10 minutes are evicted in the background until ctx is done.
+func NewWorkspaceRateLimiter(ctx context.Context) *WorkspaceRateLimiter {
+	l := &WorkspaceRateLimiter{
+		limit: rate.Every(time.Minute / workspaceRequestsPerMinute),
+		burst: workspaceRequestsPerMinute,
+	}
+	go l.evictIdle(ctx)
+	return l
+}
+
+// Middleware limits requests by the :workspaceID route param. Routes without
+// it pass through.
+func (l *WorkspaceRateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		workspaceID := c.Param("workspaceID")
+		if workspaceID == "" {
+			c.Next()
+			return
+		}
+		l.allow(c, workspaceID)
+	}
+}
+
+// ClientIPMiddleware limits requests by client IP, for unauthenticated routes
+// that have no workspace to key on.
+func (l *WorkspaceRateLimiter) ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l.allow(c, "ip:"+c.ClientIP())
+	}
+}
+
+func (l *WorkspaceRateLimiter) allow(c *gin.Context, key string) {
+	now := time.Now()
+	reservation := l.limiterFor(key, now).ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		retryAfter := int(math.Ceil(delay.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":               "rate limit exceeded",
+			"code":                "rate_limited",
+			"retry_after_seconds": retryAfter,
+		})
+		return
+	}
+
+	c.Next()
+}
+
+func (l *WorkspaceRateLimiter) limiterFor(workspaceID string, now time.Time) *rate.Limiter {
+	entry, ok := l.limiters.Load(workspaceID)
+	if !ok {
+		entry, _ = l.limiters.LoadOrStore(workspaceID, &workspaceLimiter{limiter: rate.NewLimiter(l.limit, l.burst)})
+	}
+	wl := entry.(*workspaceLimiter)
+	wl.lastSeen.Store(now.UnixNano())
+	return wl.limiter
+}
+
+func (l *WorkspaceRateLimiter) evictIdle(ctx context.Context) {
+	ticker := time.NewTicker(limiterEvictInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.evictBefore(now.Add(-limiterIdleTTL))
+		}
+	}
+}
+
+func (l *WorkspaceRateLimiter) evictBefore(cutoff time.Time) {
+	l.limiters.Range(func(key, value any) bool {
+		if value.(*workspaceLimiter).lastSeen.Load() < cutoff.UnixNano() {
+			l.limiters.Delete(key)
+		}
+		return true
+	})
+}
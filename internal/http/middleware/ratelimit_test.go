@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWorkspaceRateLimiter_ConcurrentRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := gin.New()
+	r.POST("/api/workspaces/:workspaceID/dispatch-now", NewWorkspaceRateLimiter(ctx).Middleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	const requests = 12
+	recorders := make([]*httptest.ResponseRecorder, requests)
+	var wg sync.WaitGroup
+	for i := range recorders {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recorders[i] = httptest.NewRecorder()
+			r.ServeHTTP(recorders[i], httptest.NewRequest(http.MethodPost, "/api/workspaces/W1/dispatch-now", nil))
+		}(i)
+	}
+	wg.Wait()
+
+	var ok, limited int
+	for _, rec := range recorders {
+		switch rec.Code {
+		case http.StatusOK:
+			ok++
+		case http.StatusTooManyRequests:
+			limited++
+			retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+			if err != nil || retryAfter < 1 {
+				t.Fatalf("Retry-After = %q, want a positive number of seconds", rec.Header().Get("Retry-After"))
+			}
+			var body struct {
+				Error             string `json:"error"`
+				RetryAfterSeconds int    `json:"retry_after_seconds"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode 429 body: %v", err)
+			}
+			if body.Error != "rate limit exceeded" || body.RetryAfterSeconds != retryAfter {
+				t.Fatalf("unexpected 429 body: %s", rec.Body.String())
+			}
+		default:
+			t.Fatalf("unexpected status %d", rec.Code)
+		}
+	}
+	if ok != workspaceRequestsPerMinute || limited != requests-workspaceRequestsPerMinute {
+		t.Fatalf("got %d allowed and %d limited, want %d and %d", ok, limited, workspaceRequestsPerMinute, requests-workspaceRequestsPerMinute)
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/workspaces/W2/dispatch-now", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected another workspace to be unaffected, got %d", rec.Code)
+	}
+}
+
+func TestWorkspaceRateLimiter_EvictsIdleLimiters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := NewWorkspaceRateLimiter(ctx)
+	now := time.Now()
+	l.limiterFor("idle", now.Add(-limiterIdleTTL-time.Second))
+	l.limiterFor("active", now)
+
+	l.evictBefore(now.Add(-limiterIdleTTL))
+
+	if _, ok := l.limiters.Load("idle"); ok {
+		t.Fatal("expected the idle limiter to be evicted")
+	}
+	if _, ok := l.limiters.Load("active"); !ok {
+		t.Fatal("expected the active limiter to be kept")
+	}
+}
+
+func TestWorkspaceRateLimiter_ClientIPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := gin.New()
+	r.GET("/auth/slack/install", NewWorkspaceRateLimiter(ctx).ClientIPMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/auth/slack/install", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < workspaceRequestsPerMinute; i++ {
+		if code := request("192.0.2.1:1234"); code != http.StatusOK {
+			t.Fatalf("request %d: got %d, want 200", i, code)
+		}
+	}
+	if code := request("192.0.2.1:1234"); code != http.StatusTooManyRequests {
+		t.Fatalf("got %d, want 429 once the client is over the limit", code)
+	}
+	if code := request("192.0.2.2:1234"); code != http.StatusOK {
+		t.Fatalf("expected another client to be unaffected, got %d", code)
+	}
+}
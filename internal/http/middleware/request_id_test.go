@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "reuses incoming id", incoming: "req-123", wantSame: true},
+		{name: "generates when missing", incoming: ""},
+		{name: "replaces unprintable id", incoming: "bad id\twith spaces"},
+		{name: "replaces overlong id", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext, fromGin string
+			r := gin.New()
+			r.Use(RequestID())
+			r.GET("/", func(c *gin.Context) {
+				fromContext = RequestIDFromContext(c.Request.Context())
+				fromGin = c.GetString(string(RequestIDKey))
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if tt.wantSame && got != tt.incoming {
+				t.Fatalf("response id = %q, want %q", got, tt.incoming)
+			}
+			if !tt.wantSame && !uuidV4Pattern.MatchString(got) {
+				t.Fatalf("response id = %q, want a UUID v4", got)
+			}
+			if fromContext != got || fromGin != got {
+				t.Fatalf("context ids = %q / %q, want %q", fromContext, fromGin, got)
+			}
+		})
+	}
+}
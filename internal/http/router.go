@@ -2,46 +2,117 @@ package http
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"slackcheers/internal/http/handlers"
 	"slackcheers/internal/http/middleware"
+	"slackcheers/internal/metrics"
 )
 
 type RouterDependencies struct {
-	Logger           *slog.Logger
-	HealthHandler    *handlers.HealthHandler
-	AuthHandler      *handlers.AuthHandler
-	WorkspaceHandler *handlers.WorkspaceHandler
+	Logger               *slog.Logger
+	SlowRequestThreshold time.Duration
+	MaxRequestBodyBytes  int64
+	MaxImportBodyBytes   int64
+	AdminAPIKey          string
+	APIKeys              []string
+	CORSAllowedOrigins   []string
+	WorkspaceRateLimiter *middleware.WorkspaceRateLimiter
+	MetricsEnabled       bool
+	HealthHandler        *handlers.HealthHandler
+	AuthHandler          *handlers.AuthHandler
+	InteractiveHandler   *handlers.SlackInteractiveHandler
+	CommandHandler       *handlers.SlackCommandHandler
+	WorkspaceHandler     *handlers.WorkspaceHandler
+	AdminHandler         *handlers.AdminHandler
+	SchedulerHandler     *handlers.SchedulerHandler
 }
 
 func NewRouter(deps RouterDependencies) *gin.Engine {
 	r := gin.New()
+	r.Use(middleware.CORS(deps.CORSAllowedOrigins))
 	r.Use(gin.Recovery())
-	r.Use(middleware.RequestLogger(deps.Logger))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.RequestLogger(deps.Logger, deps.SlowRequestThreshold))
+	r.Use(middleware.RequestBodyLimit(deps.MaxRequestBodyBytes, map[string]int64{
+		"/api/workspaces/:workspaceID/import": deps.MaxImportBodyBytes,
+	}))
 
-	r.GET("/healthz", deps.HealthHandler.Healthz)
-	r.GET("/auth/slack/install", deps.AuthHandler.SlackInstall)
+	r.GET("/healthz", deps.HealthHandler.Live)
+	r.GET("/healthz/live", deps.HealthHandler.Live)
+	r.GET("/healthz/ready", deps.HealthHandler.Ready)
+	if deps.MetricsEnabled {
+		r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+	r.GET("/auth/slack/install", deps.WorkspaceRateLimiter.ClientIPMiddleware(), deps.AuthHandler.SlackInstall)
 	r.GET("/auth/slack/callback", deps.AuthHandler.SlackOAuthCallback)
 	r.POST("/slack/events", deps.AuthHandler.SlackEvents)
+	r.POST("/slack/actions", deps.InteractiveHandler.SlackActions)
+	r.POST("/slack/commands", deps.CommandHandler.SlackCommands)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	api := r.Group("/api")
+	api := r.Group("/api", middleware.APIKeyAuth(deps.APIKeys))
 	{
+		rateLimited := deps.WorkspaceRateLimiter.Middleware()
+		api.GET("/workspaces", deps.WorkspaceHandler.ListWorkspaces)
+		api.GET("/scheduler/status", deps.SchedulerHandler.Status)
+		api.POST("/scheduler/pause", deps.SchedulerHandler.Pause)
+		api.POST("/scheduler/resume", deps.SchedulerHandler.Resume)
 		api.POST("/workspaces/bootstrap", deps.WorkspaceHandler.BootstrapWorkspace)
-		api.POST("/workspaces/:workspaceID/dispatch-now", deps.WorkspaceHandler.DispatchCelebrationsNow)
+		api.GET("/workspaces/:workspaceID", deps.WorkspaceHandler.GetWorkspace)
+		api.PATCH("/workspaces/:workspaceID", deps.WorkspaceHandler.UpdateWorkspace)
+		api.DELETE("/workspaces/:workspaceID", deps.WorkspaceHandler.DeleteWorkspace)
+		api.GET("/workspaces/:workspaceID/export", deps.WorkspaceHandler.ExportWorkspace)
+		api.POST("/workspaces/:workspaceID/import", deps.WorkspaceHandler.ImportWorkspace)
+		api.GET("/workspaces/:workspaceID/connection-status", deps.WorkspaceHandler.ConnectionStatus)
+		api.GET("/workspaces/:workspaceID/member-sync/status", deps.WorkspaceHandler.MemberSyncStatus)
+		api.POST("/workspaces/:workspaceID/dispatch-now", rateLimited, deps.WorkspaceHandler.DispatchCelebrationsNow)
+		api.POST("/workspaces/:workspaceID/backfill", rateLimited, deps.WorkspaceHandler.BackfillCelebrations)
 		api.GET("/workspaces/:workspaceID/overview", deps.WorkspaceHandler.Overview)
+		api.GET("/workspaces/:workspaceID/forecast", deps.WorkspaceHandler.Forecast)
+		api.GET("/workspaces/:workspaceID/celebration-history", deps.WorkspaceHandler.CelebrationHistory)
 		api.GET("/workspaces/:workspaceID/people", deps.WorkspaceHandler.ListPeople)
+		api.GET("/workspaces/:workspaceID/people/duplicates", deps.WorkspaceHandler.BirthdayDuplicates)
+		api.GET("/workspaces/:workspaceID/people/export", deps.WorkspaceHandler.ExportPeople)
+		api.POST("/workspaces/:workspaceID/people/import", deps.WorkspaceHandler.ImportPeople)
+		api.PUT("/workspaces/:workspaceID/people/bulk-reminders-mode", deps.WorkspaceHandler.BulkUpdateRemindersMode)
 		api.PUT("/workspaces/:workspaceID/people/:slackUserID", deps.WorkspaceHandler.UpsertPerson)
+		api.GET("/workspaces/:workspaceID/people/:slackUserID/reminders", deps.WorkspaceHandler.ListReminders)
+		api.GET("/workspaces/:workspaceID/people/:slackUserID/export", deps.WorkspaceHandler.ExportPersonData)
+		api.DELETE("/workspaces/:workspaceID/people/:slackUserID", deps.WorkspaceHandler.DeletePerson)
+		api.DELETE("/workspaces/:workspaceID/people/:slackUserID/data", deps.WorkspaceHandler.ErasePersonData)
+		api.POST("/workspaces/:workspaceID/people/:slackUserID/restore", deps.WorkspaceHandler.RestorePerson)
+		api.DELETE("/workspaces/:workspaceID/people/:slackUserID/birthday", deps.WorkspaceHandler.ClearBirthday)
+		api.PATCH("/workspaces/:workspaceID/announcement-channel", deps.WorkspaceHandler.UpdateAnnouncementChannel)
+		api.GET("/workspaces/:workspaceID/privacy", deps.WorkspaceHandler.GetPrivacySettings)
+		api.PUT("/workspaces/:workspaceID/privacy", deps.WorkspaceHandler.UpdatePrivacySettings)
 		api.GET("/workspaces/:workspaceID/channels", deps.WorkspaceHandler.ListChannels)
-		api.POST("/workspaces/:workspaceID/channels/:channelID/cleanup-birthday-messages", deps.WorkspaceHandler.CleanupBirthdayMessages)
+		api.DELETE("/workspaces/:workspaceID/channels/:channelID", deps.WorkspaceHandler.DeleteChannel)
+		api.POST("/workspaces/:workspaceID/channels/:channelID/cleanup-birthday-messages", rateLimited, deps.WorkspaceHandler.CleanupBirthdayMessages)
+		api.POST("/workspaces/:workspaceID/channels/:channelID/pause", deps.WorkspaceHandler.PauseChannel)
+		api.DELETE("/workspaces/:workspaceID/channels/:channelID/pause", deps.WorkspaceHandler.UnpauseChannel)
+		api.POST("/workspaces/:workspaceID/channels/:channelID/preview-ephemeral", deps.WorkspaceHandler.PreviewChannelEphemeral)
+		api.GET("/workspaces/:workspaceID/channels/:channelID/people", deps.WorkspaceHandler.ListChannelPeople)
+		api.GET("/workspaces/:workspaceID/channels/:channelID/history", deps.WorkspaceHandler.ChannelHistory)
+		api.GET("/workspaces/:workspaceID/channels/:channelID/dispatch-log", deps.WorkspaceHandler.ChannelDispatchLog)
+		api.DELETE("/workspaces/:workspaceID/channels/:channelID/dispatch-log/:date", deps.WorkspaceHandler.ClearDispatchLogEntry)
 		api.GET("/workspaces/:workspaceID/slack/channels", deps.WorkspaceHandler.ListSlackChannels)
-		api.POST("/workspaces/:workspaceID/onboarding/dm", deps.WorkspaceHandler.SendOnboardingDMs)
-		api.POST("/workspaces/:workspaceID/onboarding/dm/cleanup", deps.WorkspaceHandler.CleanupOnboardingDMs)
+		api.POST("/workspaces/:workspaceID/onboarding/dm", rateLimited, deps.WorkspaceHandler.SendOnboardingDMs)
+		api.PUT("/workspaces/:workspaceID/onboarding/template", deps.WorkspaceHandler.UpdateOnboardingTemplate)
+		api.GET("/workspaces/:workspaceID/onboarding/progress", deps.WorkspaceHandler.OnboardingProgress)
+		api.POST("/workspaces/:workspaceID/onboarding/dm/cleanup", rateLimited, deps.WorkspaceHandler.CleanupOnboardingDMs)
 		api.PUT("/workspaces/:workspaceID/channels/:channelID/settings", deps.WorkspaceHandler.UpdateChannelSettings)
 		api.PUT("/workspaces/:workspaceID/channels/:channelID/templates", deps.WorkspaceHandler.UpdateChannelTemplates)
+		api.POST("/workspaces/:workspaceID/channels/:channelID/templates/preview", deps.WorkspaceHandler.PreviewChannelTemplates)
+	}
+
+	admin := r.Group("/api/admin", middleware.AdminAPIKey(deps.AdminAPIKey))
+	{
+		admin.PUT("/log-level", deps.AdminHandler.UpdateLogLevel)
+		admin.GET("/birthdays", deps.AdminHandler.Birthdays)
 	}
 
 	return r
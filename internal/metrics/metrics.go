@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	CelebrationsDispatched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slackcheers_celebrations_dispatched_total",
+		Help: "People celebrated in a posted or scheduled message, by celebration type.",
+	}, []string{"type"})
+
+	CelebrationsFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slackcheers_celebrations_failed_total",
+		Help: "Celebration messages that could not be posted or scheduled.",
+	})
+
+	OnboardingDMsSent = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slackcheers_onboarding_dms_sent_total",
+		Help: "Onboarding direct messages sent.",
+	})
+
+	SlackAPICalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slackcheers_slack_api_calls_total",
+		Help: "Slack Web API calls by method and outcome.",
+	}, []string{"endpoint", "status"})
+
+	SlackAPIDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "slackcheers_slack_api_duration_seconds",
+		Help:    "Slack Web API call latency by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	SchedulerTickDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "slackcheers_scheduler_tick_duration_seconds",
+		Help:    "Time spent running one scheduler tick.",
+		Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+	})
+)
+
+// ObserveSlackAPICall records one Slack Web API call. status is ok, error or
+// rate_limited.
+func ObserveSlackAPICall(endpoint, status string, elapsed time.Duration) {
+	SlackAPICalls.WithLabelValues(endpoint, status).Inc()
+	SlackAPIDuration.WithLabelValues(endpoint).Observe(elapsed.Seconds())
+}
+
+// Handler serves the registered metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+type CelebrationPostLogRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewCelebrationPostLogRepository(db *sql.DB, queryTimeout time.Duration) *CelebrationPostLogRepository {
+	return &CelebrationPostLogRepository{db: db, queryTimeout: queryTimeout}
+}
+
+type InsertCelebrationPostInput struct {
+	ChannelID       string
+	CelebrationType string
+	SlackUserIDs    []string
+	MessageText     string
+	SlackMessageTS  string
+}
+
+func (r *CelebrationPostLogRepository) Insert(ctx context.Context, in InsertCelebrationPostInput) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+INSERT INTO celebration_post_log (
+    workspace_channel_id, celebration_type, slack_user_ids, message_text, slack_message_ts
+)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+	userIDs := in.SlackUserIDs
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+
+	if _, err := r.db.ExecContext(ctx, q, in.ChannelID, in.CelebrationType, userIDs, in.MessageText, in.SlackMessageTS); err != nil {
+		return fmt.Errorf("insert celebration post log: %w", err)
+	}
+
+	return nil
+}
+
+// ListByWorkspace returns one page of posts across every channel in the
+// workspace, newest first, along with the total number of posts.
+func (r *CelebrationPostLogRepository) ListByWorkspace(ctx context.Context, workspaceID string, limit, offset int) ([]domain.CelebrationPostLogEntry, int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const countQ = `
+SELECT COUNT(*)
+FROM celebration_post_log l
+JOIN workspace_channels wc ON wc.id = l.workspace_channel_id
+WHERE wc.workspace_id = $1
+`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, workspaceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count celebration post log: %w", err)
+	}
+
+	const q = `
+SELECT l.id, l.workspace_channel_id, wc.slack_channel_id, l.celebration_type,
+       array_to_string(l.slack_user_ids, ','), l.message_text, l.dispatched_at, l.slack_message_ts
+FROM celebration_post_log l
+JOIN workspace_channels wc ON wc.id = l.workspace_channel_id
+WHERE wc.workspace_id = $1
+ORDER BY l.dispatched_at DESC, l.id DESC
+LIMIT $2 OFFSET $3
+`
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list celebration post log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.CelebrationPostLogEntry, 0)
+	for rows.Next() {
+		var (
+			entry   domain.CelebrationPostLogEntry
+			userIDs string
+		)
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.WorkspaceChannelID,
+			&entry.SlackChannelID,
+			&entry.CelebrationType,
+			&userIDs,
+			&entry.MessageText,
+			&entry.DispatchedAt,
+			&entry.SlackMessageTS,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan celebration post log entry: %w", err)
+		}
+		entry.SlackUserIDs = splitUserIDs(userIDs)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate celebration post log: %w", err)
+	}
+
+	return entries, total, nil
+}
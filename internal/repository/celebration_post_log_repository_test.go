@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCelebrationPostLog_InsertAndListByWorkspace(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-post-log-%d", time.Now().UnixNano()), "Post log test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	channel, err := workspaces.CreateDefaultChannel(ctx, workspace.ID, "C-post-log", "celebrations", "UTC", "09:00")
+	if err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+
+	repo := NewCelebrationPostLogRepository(db, testQueryTimeout)
+	for _, in := range []InsertCelebrationPostInput{
+		{ChannelID: channel.ID, CelebrationType: "birthday", SlackUserIDs: []string{"U1", "U2"}, MessageText: "Happy birthday!", SlackMessageTS: "1700000000.000001"},
+		{ChannelID: channel.ID, CelebrationType: "anniversary", MessageText: "Happy anniversary!", SlackMessageTS: "1700000000.000002"},
+	} {
+		if err := repo.Insert(ctx, in); err != nil {
+			t.Fatalf("insert %s post: %v", in.CelebrationType, err)
+		}
+	}
+
+	entries, total, err := repo.ListByWorkspace(ctx, workspace.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("list post log: %v", err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, fmt.Sprintf("%s|%s|%s|%s|%s", e.SlackChannelID, e.CelebrationType, strings.Join(e.SlackUserIDs, ","), e.MessageText, e.SlackMessageTS))
+	}
+	want := []string{
+		"C-post-log|anniversary||Happy anniversary!|1700000000.000002",
+		"C-post-log|birthday|U1,U2|Happy birthday!|1700000000.000001",
+	}
+	if total != 2 || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("post log = %q (total %d), want %q newest first", got, total, want)
+	}
+
+	page, total, err := repo.ListByWorkspace(ctx, workspace.ID, 1, 1)
+	if err != nil {
+		t.Fatalf("list second page: %v", err)
+	}
+	if total != 2 || len(page) != 1 || page[0].CelebrationType != "birthday" {
+		t.Fatalf("unexpected second page: %+v (total %d)", page, total)
+	}
+
+	if err := repo.Insert(ctx, InsertCelebrationPostInput{ChannelID: channel.ID, CelebrationType: "farewell", MessageText: "Bye", SlackMessageTS: "1700000000.000003"}); err == nil {
+		t.Fatal("expected an unknown celebration type to be rejected")
+	}
+}
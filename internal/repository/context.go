@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// withDBTimeout bounds a single query by the repository's timeout. The caller's
+// own deadline and cancellation still apply, whichever comes first. A
+// non-positive timeout leaves the caller's context untouched.
+func withDBTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDBTimeout_KeepsEarlierCallerDeadline(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Second)
+	defer cancelParent()
+	parentDeadline, _ := parent.Deadline()
+
+	ctx, cancel := withDBTimeout(parent, time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || !deadline.Equal(parentDeadline) {
+		t.Fatalf("expected the caller's earlier deadline %v, got %v (set=%v)", parentDeadline, deadline, ok)
+	}
+}
+
+func TestWithDBTimeout_AppliesQueryTimeout(t *testing.T) {
+	ctx, cancel := withDBTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute || time.Until(deadline) < 50*time.Second {
+		t.Fatalf("expected a one minute deadline, got %v (set=%v)", deadline, ok)
+	}
+}
+
+func TestWithDBTimeout_PropagatesCallerCancellation(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := withDBTimeout(parent, time.Minute)
+	defer cancel()
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected caller cancellation to cancel the query context")
+	}
+}
+
+func TestWithDBTimeout_DisabledKeepsCallerContext(t *testing.T) {
+	parent := context.Background()
+	ctx, cancel := withDBTimeout(parent, 0)
+	defer cancel()
+
+	if ctx != parent {
+		t.Fatal("expected caller context to be returned unchanged")
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DigestLogRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewDigestLogRepository(db *sql.DB, queryTimeout time.Duration) *DigestLogRepository {
+	return &DigestLogRepository{db: db, queryTimeout: queryTimeout}
+}
+
+// Claim records that the workspace's digest for the week starting weekStart
+// is being sent. It returns false when the digest was already claimed.
+func (r *DigestLogRepository) Claim(ctx context.Context, workspaceID string, weekStart time.Time) (bool, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+INSERT INTO digest_log (workspace_id, week_start)
+VALUES ($1, $2)
+ON CONFLICT (workspace_id, week_start) DO NOTHING
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, weekStart.Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("claim digest: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim digest rows affected: %w", err)
+	}
+
+	return inserted == 1, nil
+}
+
+// Release drops a claim so a digest that failed to send is retried.
+func (r *DigestLogRepository) Release(ctx context.Context, workspaceID string, weekStart time.Time) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+DELETE FROM digest_log
+WHERE workspace_id = $1 AND week_start = $2
+`
+
+	if _, err := r.db.ExecContext(ctx, q, workspaceID, weekStart.Format("2006-01-02")); err != nil {
+		return fmt.Errorf("release digest: %w", err)
+	}
+
+	return nil
+}
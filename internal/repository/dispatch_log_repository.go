@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+var dispatchLogCSVHeader = []string{
+	"dispatch_date",
+	"channel_id",
+	"slack_channel_id",
+	"birthday_count",
+	"anniversary_count",
+	"birthday_user_ids",
+	"anniversary_user_ids",
+	"message_timestamps",
+	"message_url",
+}
+
+type DispatchLogRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewDispatchLogRepository(db *sql.DB, queryTimeout time.Duration) *DispatchLogRepository {
+	return &DispatchLogRepository{db: db, queryTimeout: queryTimeout}
+}
+
+func (r *DispatchLogRepository) List(ctx context.Context, channelID string, from, to *time.Time) ([]domain.DispatchLogEntry, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	rows, err := r.queryByChannel(ctx, channelID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.DispatchLogEntry, 0)
+	for rows.Next() {
+		entry, err := scanDispatchLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dispatch log: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *DispatchLogRepository) ExportCSV(ctx context.Context, channelID string, from, to *time.Time, w io.Writer) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	rows, err := r.queryByChannel(ctx, channelID, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(dispatchLogCSVHeader); err != nil {
+		return fmt.Errorf("write dispatch log csv header: %w", err)
+	}
+
+	for rows.Next() {
+		entry, err := scanDispatchLogEntry(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := cw.Write([]string{
+			entry.DispatchDate.Format("2006-01-02"),
+			entry.WorkspaceChannelID,
+			entry.SlackChannelID,
+			strconv.Itoa(entry.BirthdayCount),
+			strconv.Itoa(entry.AnniversaryCount),
+			strings.Join(entry.BirthdayUserIDs, " "),
+			strings.Join(entry.AnniversaryUserIDs, " "),
+			strings.Join(entry.MessageTimestamps, " "),
+			entry.MessageURL,
+		}); err != nil {
+			return fmt.Errorf("write dispatch log csv row: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate dispatch log: %w", err)
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush dispatch log csv: %w", err)
+	}
+
+	return nil
+}
+
+type CelebratedPerson struct {
+	SlackUserID string
+	DisplayName string
+}
+
+type ChannelDispatchEntry struct {
+	domain.DispatchLogEntry
+	Birthdays     []CelebratedPerson
+	Anniversaries []CelebratedPerson
+}
+
+// ListByChannel returns one page of a channel's dispatch history, newest
+// first, with celebrated people resolved to their display names. The second
+// return value is the total number of entries for the channel. It backs both
+// the channel history and the dispatch log endpoints.
+func (r *DispatchLogRepository) ListByChannel(ctx context.Context, channelID string, limit, offset int) ([]ChannelDispatchEntry, int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const countQ = `SELECT COUNT(*) FROM celebration_dispatch_log WHERE workspace_channel_id = $1`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, channelID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count channel history: %w", err)
+	}
+
+	const q = `
+SELECT l.id, l.workspace_channel_id, wc.slack_channel_id, l.dispatch_date,
+       l.birthday_count, l.anniversary_count,
+       array_to_string(l.birthday_user_ids, ','), array_to_string(l.anniversary_user_ids, ','),
+       COALESCE(l.message_ts, ''), COALESCE(l.message_url, ''),
+       array_to_string(l.scheduled_message_ids, ','),
+       array_to_string(l.message_timestamps, ','), l.created_at,
+       array_to_json(ARRAY(
+           SELECT COALESCE(p.display_name, '')
+           FROM unnest(l.birthday_user_ids) WITH ORDINALITY AS u(slack_user_id, ord)
+           LEFT JOIN people p ON p.workspace_id = wc.workspace_id AND p.slack_user_id = u.slack_user_id
+           ORDER BY u.ord
+       )),
+       array_to_json(ARRAY(
+           SELECT COALESCE(p.display_name, '')
+           FROM unnest(l.anniversary_user_ids) WITH ORDINALITY AS u(slack_user_id, ord)
+           LEFT JOIN people p ON p.workspace_id = wc.workspace_id AND p.slack_user_id = u.slack_user_id
+           ORDER BY u.ord
+       ))
+FROM celebration_dispatch_log l
+JOIN workspace_channels wc ON wc.id = l.workspace_channel_id
+WHERE l.workspace_channel_id = $1
+ORDER BY l.dispatch_date DESC, l.id DESC
+LIMIT $2 OFFSET $3
+`
+
+	rows, err := r.db.QueryContext(ctx, q, channelID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list channel history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]ChannelDispatchEntry, 0)
+	for rows.Next() {
+		var (
+			entry              ChannelDispatchEntry
+			birthdayUserIDs    string
+			anniversaryUserIDs string
+			scheduledIDs       string
+			messageTimestamps  string
+			birthdayNames      []byte
+			anniversaryNames   []byte
+		)
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.WorkspaceChannelID,
+			&entry.SlackChannelID,
+			&entry.DispatchDate,
+			&entry.BirthdayCount,
+			&entry.AnniversaryCount,
+			&birthdayUserIDs,
+			&anniversaryUserIDs,
+			&entry.MessageTS,
+			&entry.MessageURL,
+			&scheduledIDs,
+			&messageTimestamps,
+			&entry.CreatedAt,
+			&birthdayNames,
+			&anniversaryNames,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan channel history entry: %w", err)
+		}
+
+		entry.BirthdayUserIDs = splitUserIDs(birthdayUserIDs)
+		entry.AnniversaryUserIDs = splitUserIDs(anniversaryUserIDs)
+		entry.ScheduledMessageIDs = splitUserIDs(scheduledIDs)
+		entry.MessageTimestamps = splitUserIDs(messageTimestamps)
+		if entry.Birthdays, err = celebratedPeople(entry.BirthdayUserIDs, birthdayNames); err != nil {
+			return nil, 0, err
+		}
+		if entry.Anniversaries, err = celebratedPeople(entry.AnniversaryUserIDs, anniversaryNames); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate channel history: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func celebratedPeople(userIDs []string, namesJSON []byte) ([]CelebratedPerson, error) {
+	var names []string
+	if len(namesJSON) > 0 {
+		if err := json.Unmarshal(namesJSON, &names); err != nil {
+			return nil, fmt.Errorf("decode celebrated names: %w", err)
+		}
+	}
+
+	people := make([]CelebratedPerson, 0, len(userIDs))
+	for i, id := range userIDs {
+		p := CelebratedPerson{SlackUserID: id}
+		if i < len(names) {
+			p.DisplayName = names[i]
+		}
+		people = append(people, p)
+	}
+	return people, nil
+}
+
+// ListScheduledForUser returns dispatch log entries on or after since whose
+// posts name slackUserID and were handed to Slack as scheduled messages.
+func (r *DispatchLogRepository) ListScheduledForUser(ctx context.Context, workspaceID, slackUserID string, since time.Time) ([]domain.DispatchLogEntry, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT l.id, l.workspace_channel_id, wc.slack_channel_id, l.dispatch_date,
+       l.birthday_count, l.anniversary_count,
+       array_to_string(l.birthday_user_ids, ','), array_to_string(l.anniversary_user_ids, ','),
+       COALESCE(l.message_ts, ''), COALESCE(l.message_url, ''),
+       array_to_string(l.scheduled_message_ids, ','),
+       array_to_string(l.message_timestamps, ','), l.created_at
+FROM celebration_dispatch_log l
+JOIN workspace_channels wc ON wc.id = l.workspace_channel_id
+WHERE wc.workspace_id = $1
+  AND ($2 = ANY(l.birthday_user_ids) OR $2 = ANY(l.anniversary_user_ids))
+  AND cardinality(l.scheduled_message_ids) > 0
+  AND l.dispatch_date >= $3::date
+ORDER BY l.dispatch_date
+`
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, slackUserID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled dispatches for user: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.DispatchLogEntry, 0)
+	for rows.Next() {
+		entry, err := scanDispatchLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scheduled dispatches for user: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *DispatchLogRepository) queryByChannel(ctx context.Context, channelID string, from, to *time.Time) (*sql.Rows, error) {
+	const q = `
+SELECT l.id, l.workspace_channel_id, wc.slack_channel_id, l.dispatch_date,
+       l.birthday_count, l.anniversary_count,
+       array_to_string(l.birthday_user_ids, ','), array_to_string(l.anniversary_user_ids, ','),
+       COALESCE(l.message_ts, ''), COALESCE(l.message_url, ''),
+       array_to_string(l.scheduled_message_ids, ','),
+       array_to_string(l.message_timestamps, ','), l.created_at
+FROM celebration_dispatch_log l
+JOIN workspace_channels wc ON wc.id = l.workspace_channel_id
+WHERE l.workspace_channel_id = $1
+  AND ($2::date IS NULL OR l.dispatch_date >= $2::date)
+  AND ($3::date IS NULL OR l.dispatch_date <= $3::date)
+ORDER BY l.dispatch_date DESC
+`
+
+	rows, err := r.db.QueryContext(ctx, q, channelID, toNullDate(from), toNullDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("list dispatch log: %w", err)
+	}
+
+	return rows, nil
+}
+
+type dispatchLogScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDispatchLogEntry(scanner dispatchLogScanner) (domain.DispatchLogEntry, error) {
+	var (
+		entry              domain.DispatchLogEntry
+		birthdayUserIDs    string
+		anniversaryUserIDs string
+		scheduledIDs       string
+		messageTimestamps  string
+	)
+	if err := scanner.Scan(
+		&entry.ID,
+		&entry.WorkspaceChannelID,
+		&entry.SlackChannelID,
+		&entry.DispatchDate,
+		&entry.BirthdayCount,
+		&entry.AnniversaryCount,
+		&birthdayUserIDs,
+		&anniversaryUserIDs,
+		&entry.MessageTS,
+		&entry.MessageURL,
+		&scheduledIDs,
+		&messageTimestamps,
+		&entry.CreatedAt,
+	); err != nil {
+		return domain.DispatchLogEntry{}, fmt.Errorf("scan dispatch log entry: %w", err)
+	}
+
+	entry.BirthdayUserIDs = splitUserIDs(birthdayUserIDs)
+	entry.AnniversaryUserIDs = splitUserIDs(anniversaryUserIDs)
+	entry.ScheduledMessageIDs = splitUserIDs(scheduledIDs)
+	entry.MessageTimestamps = splitUserIDs(messageTimestamps)
+	return entry, nil
+}
+
+func splitUserIDs(joined string) []string {
+	if joined == "" {
+		return []string{}
+	}
+	return strings.Split(joined, ",")
+}
+
+func toNullDate(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.Format("2006-01-02"), Valid: true}
+}
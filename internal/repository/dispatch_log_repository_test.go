@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCelebratedPeople_PairsIDsWithNames(t *testing.T) {
+	got, err := celebratedPeople([]string{"U1", "U2", "U3"}, []byte(`["Ada", "", "Grace"]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []CelebratedPerson{
+		{SlackUserID: "U1", DisplayName: "Ada"},
+		{SlackUserID: "U2", DisplayName: ""},
+		{SlackUserID: "U3", DisplayName: "Grace"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCelebratedPeople_EmptyNames(t *testing.T) {
+	got, err := celebratedPeople([]string{"U1"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].SlackUserID != "U1" || got[0].DisplayName != "" {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewIdempotencyRepository(db *sql.DB, queryTimeout time.Duration) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db, queryTimeout: queryTimeout}
+}
+
+// Claim reserves key for workspaceID. When the key was already claimed after
+// notBefore, claimed is false and responseBody holds the stored response, or
+// nil when the original request has not finished yet.
+func (r *IdempotencyRepository) Claim(ctx context.Context, workspaceID, key string, notBefore time.Time) (bool, []byte, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	claimed, err := r.insert(ctx, workspaceID, key)
+	if err != nil || claimed {
+		return claimed, nil, err
+	}
+
+	const q = `
+SELECT response_body, created_at
+FROM idempotency_keys
+WHERE key = $1 AND workspace_id = $2
+`
+
+	var (
+		body      []byte
+		createdAt time.Time
+	)
+	if err := r.db.QueryRowContext(ctx, q, key, workspaceID).Scan(&body, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			claimed, err := r.insert(ctx, workspaceID, key)
+			return claimed, nil, err
+		}
+		return false, nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	if createdAt.Before(notBefore) {
+		const expire = `
+DELETE FROM idempotency_keys
+WHERE key = $1 AND workspace_id = $2 AND created_at < $3
+`
+		if _, err := r.db.ExecContext(ctx, expire, key, workspaceID, notBefore); err != nil {
+			return false, nil, fmt.Errorf("expire idempotency key: %w", err)
+		}
+		claimed, err := r.insert(ctx, workspaceID, key)
+		return claimed, nil, err
+	}
+
+	return false, body, nil
+}
+
+func (r *IdempotencyRepository) insert(ctx context.Context, workspaceID, key string) (bool, error) {
+	const q = `
+INSERT INTO idempotency_keys (key, workspace_id)
+VALUES ($1, $2)
+ON CONFLICT (key, workspace_id) DO NOTHING
+`
+
+	res, err := r.db.ExecContext(ctx, q, key, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim idempotency key rows affected: %w", err)
+	}
+
+	return inserted == 1, nil
+}
+
+func (r *IdempotencyRepository) SaveResponse(ctx context.Context, workspaceID, key string, responseBody []byte) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE idempotency_keys
+SET response_body = $3
+WHERE key = $1 AND workspace_id = $2
+`
+
+	if _, err := r.db.ExecContext(ctx, q, key, workspaceID, responseBody); err != nil {
+		return fmt.Errorf("save idempotency response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, workspaceID, key string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+DELETE FROM idempotency_keys
+WHERE key = $1 AND workspace_id = $2 AND response_body IS NULL
+`
+
+	if _, err := r.db.ExecContext(ctx, q, key, workspaceID); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+DELETE FROM idempotency_keys
+WHERE created_at < $1
+`
+
+	res, err := r.db.ExecContext(ctx, q, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
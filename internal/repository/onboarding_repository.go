@@ -3,18 +3,26 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
+
+	"slackcheers/internal/domain"
 )
 
 type OnboardingRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
-func NewOnboardingRepository(db *sql.DB) *OnboardingRepository {
-	return &OnboardingRepository{db: db}
+func NewOnboardingRepository(db *sql.DB, queryTimeout time.Duration) *OnboardingRepository {
+	return &OnboardingRepository{db: db, queryTimeout: queryTimeout}
 }
 
 func (r *OnboardingRepository) ListSentUserIDs(ctx context.Context, workspaceID string) (map[string]struct{}, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 SELECT slack_user_id
 FROM onboarding_dm_log
@@ -44,6 +52,9 @@ WHERE workspace_id = $1
 }
 
 func (r *OnboardingRepository) MarkSent(ctx context.Context, workspaceID, slackUserID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 INSERT INTO onboarding_dm_log (workspace_id, slack_user_id)
 VALUES ($1, $2)
@@ -55,3 +66,140 @@ ON CONFLICT (workspace_id, slack_user_id) DO NOTHING
 	}
 	return nil
 }
+
+// GetSentTimestamps returns when onboarding DMs were sent to one member,
+// oldest first.
+func (r *OnboardingRepository) GetSentTimestamps(ctx context.Context, workspaceID, slackUserID string) ([]time.Time, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT sent_at
+FROM onboarding_dm_log
+WHERE workspace_id = $1 AND slack_user_id = $2
+ORDER BY sent_at
+`
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, slackUserID)
+	if err != nil {
+		return nil, fmt.Errorf("list onboarding dm sent timestamps: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]time.Time, 0)
+	for rows.Next() {
+		var sentAt time.Time
+		if err := rows.Scan(&sentAt); err != nil {
+			return nil, fmt.Errorf("scan onboarding dm sent timestamp: %w", err)
+		}
+		result = append(result, sentAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate onboarding dm sent timestamps: %w", err)
+	}
+
+	return result, nil
+}
+
+type OnboardingProgressCounts struct {
+	TotalSent         int
+	RespondedBirthday int
+	RespondedHireDate int
+	RespondedBoth     int
+}
+
+func (r *OnboardingRepository) GetProgressCounts(ctx context.Context, workspaceID string) (OnboardingProgressCounts, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT
+    COUNT(*),
+    COUNT(*) FILTER (WHERE p.birthday_month IS NOT NULL AND p.hire_date IS NULL),
+    COUNT(*) FILTER (WHERE p.birthday_month IS NULL AND p.hire_date IS NOT NULL),
+    COUNT(*) FILTER (WHERE p.birthday_month IS NOT NULL AND p.hire_date IS NOT NULL)
+FROM onboarding_dm_log l
+LEFT JOIN people p ON p.workspace_id = l.workspace_id AND p.slack_user_id = l.slack_user_id
+WHERE l.workspace_id = $1
+`
+
+	var out OnboardingProgressCounts
+	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(
+		&out.TotalSent,
+		&out.RespondedBirthday,
+		&out.RespondedHireDate,
+		&out.RespondedBoth,
+	); err != nil {
+		return OnboardingProgressCounts{}, fmt.Errorf("get onboarding progress: %w", err)
+	}
+
+	return out, nil
+}
+
+// StartOnboarding puts a member at the first step of the guided onboarding
+// conversation, creating their people row if needed. Soft-deleted people stay
+// deleted.
+func (r *OnboardingRepository) StartOnboarding(ctx context.Context, workspaceID string, member SlackMemberProfile) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+INSERT INTO people (workspace_id, slack_user_id, slack_handle, display_name, avatar_url, onboarding_state)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (workspace_id, slack_user_id)
+DO UPDATE SET onboarding_state = EXCLUDED.onboarding_state, updated_at = NOW()
+`
+
+	if _, err := r.db.ExecContext(ctx, q, workspaceID, member.SlackUserID, member.SlackHandle, member.DisplayName, member.AvatarURL, domain.OnboardingStateAwaitingBirthday); err != nil {
+		return fmt.Errorf("start onboarding: %w", err)
+	}
+	return nil
+}
+
+// GetOnboardingState returns "" for people outside the guided conversation
+// and ErrNotFound when there is no saved person.
+func (r *OnboardingRepository) GetOnboardingState(ctx context.Context, workspaceID, slackUserID string) (string, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT COALESCE(onboarding_state, '')
+FROM people
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
+`
+
+	var state string
+	if err := r.db.QueryRowContext(ctx, q, workspaceID, slackUserID).Scan(&state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("get onboarding state: %w", err)
+	}
+	return state, nil
+}
+
+func (r *OnboardingRepository) SetOnboardingState(ctx context.Context, workspaceID, slackUserID, state string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE people
+SET onboarding_state = $3,
+    updated_at = NOW()
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, slackUserID, state)
+	if err != nil {
+		return fmt.Errorf("set onboarding state: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set onboarding state rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+func TestGetSentTimestamps(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-onboarding-sent-%d", time.Now().UnixNano()), "Onboarding sent test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	repo := NewOnboardingRepository(db, testQueryTimeout)
+	sent, err := repo.GetSentTimestamps(ctx, workspace.ID, "U-sent")
+	if err != nil {
+		t.Fatalf("get sent timestamps: %v", err)
+	}
+	if len(sent) != 0 {
+		t.Fatalf("expected no timestamps before a DM is sent, got %v", sent)
+	}
+
+	before := time.Now().Add(-time.Minute)
+	if err := repo.MarkSent(ctx, workspace.ID, "U-sent"); err != nil {
+		t.Fatalf("mark sent: %v", err)
+	}
+
+	sent, err = repo.GetSentTimestamps(ctx, workspace.ID, "U-sent")
+	if err != nil {
+		t.Fatalf("get sent timestamps: %v", err)
+	}
+	if len(sent) != 1 || sent[0].Before(before) {
+		t.Fatalf("expected one recent timestamp, got %v", sent)
+	}
+}
+
+func TestOnboardingState_StartGetAndSet(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-onboarding-state-%d", time.Now().UnixNano()), "Onboarding state test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	repo := NewOnboardingRepository(db, testQueryTimeout)
+	if _, err := repo.GetOnboardingState(ctx, workspace.ID, "U-guided"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before onboarding, got %v", err)
+	}
+
+	if err := repo.StartOnboarding(ctx, workspace.ID, SlackMemberProfile{SlackUserID: "U-guided", SlackHandle: "guided", DisplayName: "Guided"}); err != nil {
+		t.Fatalf("start onboarding: %v", err)
+	}
+	state, err := repo.GetOnboardingState(ctx, workspace.ID, "U-guided")
+	if err != nil {
+		t.Fatalf("get onboarding state: %v", err)
+	}
+	if state != domain.OnboardingStateAwaitingBirthday {
+		t.Fatalf("state = %q, want %q", state, domain.OnboardingStateAwaitingBirthday)
+	}
+
+	if err := repo.SetOnboardingState(ctx, workspace.ID, "U-guided", domain.OnboardingStateComplete); err != nil {
+		t.Fatalf("set onboarding state: %v", err)
+	}
+	if state, _ := repo.GetOnboardingState(ctx, workspace.ID, "U-guided"); state != domain.OnboardingStateComplete {
+		t.Fatalf("state = %q, want %q", state, domain.OnboardingStateComplete)
+	}
+
+	if err := repo.SetOnboardingState(ctx, workspace.ID, "U-missing", domain.OnboardingStateComplete); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown person, got %v", err)
+	}
+}
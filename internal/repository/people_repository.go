@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"slackcheers/internal/domain"
@@ -24,27 +25,148 @@ type UpsertPersonInput struct {
 	RemindersMode          string
 }
 
+// MissingData narrows a people listing to saved rows lacking a birthday, a
+// hire date, or either one. MissingNone applies no filter.
+type MissingData string
+
+const (
+	MissingNone     MissingData = ""
+	MissingBirthday MissingData = "birthday"
+	MissingHireDate MissingData = "hire_date"
+	MissingAny      MissingData = "any"
+)
+
+func (m MissingData) Valid() bool {
+	switch m {
+	case MissingNone, MissingBirthday, MissingHireDate, MissingAny:
+		return true
+	}
+	return false
+}
+
+// missingDataCondition returns the WHERE fragment for m, to be appended after
+// the workspace condition.
+func missingDataCondition(m MissingData) (string, error) {
+	switch m {
+	case MissingNone:
+		return "", nil
+	case MissingBirthday:
+		return "\n  AND birthday_day IS NULL", nil
+	case MissingHireDate:
+		return "\n  AND hire_date IS NULL", nil
+	case MissingAny:
+		return "\n  AND (birthday_day IS NULL OR hire_date IS NULL)", nil
+	}
+	return "", fmt.Errorf("unknown missing data filter %q", m)
+}
+
 type PeopleRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
-func NewPeopleRepository(db *sql.DB) *PeopleRepository {
-	return &PeopleRepository{db: db}
+func NewPeopleRepository(db *sql.DB, queryTimeout time.Duration) *PeopleRepository {
+	return &PeopleRepository{db: db, queryTimeout: queryTimeout}
 }
 
-func (r *PeopleRepository) ListByWorkspace(ctx context.Context, workspaceID string) ([]domain.Person, error) {
+// ListByWorkspace returns one page of a workspace's people along with the
+// total number of people matching missing. A limit of zero or less returns
+// every row.
+func (r *PeopleRepository) ListByWorkspace(ctx context.Context, workspaceID string, missing MissingData, limit, offset int) ([]domain.Person, int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	condition, err := missingDataCondition(missing)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQ := `SELECT COUNT(*) FROM people WHERE workspace_id = $1 AND deleted_at IS NULL` + condition
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, workspaceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count people: %w", err)
+	}
+
+	q := `
+SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+       birthday_day, birthday_month, birthday_year,
+       hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+FROM people
+WHERE workspace_id = $1
+  AND deleted_at IS NULL` + condition + `
+ORDER BY display_name, slack_user_id
+LIMIT $2 OFFSET $3
+`
+
+	var pageLimit sql.NullInt64
+	if limit > 0 {
+		pageLimit = sql.NullInt64{Int64: int64(limit), Valid: true}
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, pageLimit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list people: %w", err)
+	}
+	defer rows.Close()
+
+	people := make([]domain.Person, 0)
+	for rows.Next() {
+		p, err := scanPerson(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		people = append(people, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate people: %w", err)
+	}
+
+	return people, total, nil
+}
+
+// SearchByWorkspace pages through people whose display name or Slack handle
+// contains query, ignoring case. The returned total is the filtered count.
+func (r *PeopleRepository) SearchByWorkspace(ctx context.Context, workspaceID, query string, limit, offset int) ([]domain.Person, int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	pattern := peopleSearchPattern(query)
+
+	const countQ = `SELECT COUNT(*) FROM people WHERE workspace_id = $1 AND deleted_at IS NULL AND (display_name || ' ' || slack_handle) ILIKE $2`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, workspaceID, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count people search: %w", err)
+	}
+
 	const q = `
 SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
        birthday_day, birthday_month, birthday_year,
        hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
 FROM people
 WHERE workspace_id = $1
-ORDER BY display_name
+  AND deleted_at IS NULL
+  AND (display_name || ' ' || slack_handle) ILIKE $2
+ORDER BY display_name, slack_user_id
+LIMIT $3 OFFSET $4
 `
 
-	rows, err := r.db.QueryContext(ctx, q, workspaceID)
+	var pageLimit sql.NullInt64
+	if limit > 0 {
+		pageLimit = sql.NullInt64{Int64: int64(limit), Valid: true}
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, pattern, pageLimit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("list people: %w", err)
+		return nil, 0, fmt.Errorf("search people: %w", err)
 	}
 	defer rows.Close()
 
@@ -52,25 +174,127 @@ ORDER BY display_name
 	for rows.Next() {
 		p, err := scanPerson(rows)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		people = append(people, p)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate people: %w", err)
+		return nil, 0, fmt.Errorf("iterate people search: %w", err)
 	}
 
-	return people, nil
+	return people, total, nil
+}
+
+// peopleSearchPattern turns free text into an ILIKE substring pattern, escaping
+// the LIKE wildcards so they match literally.
+func peopleSearchPattern(query string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSpace(query))
+	return "%" + escaped + "%"
+}
+
+// SlackMemberProfile is the part of a person that a Slack member sync owns.
+type SlackMemberProfile struct {
+	SlackUserID string
+	SlackHandle string
+	DisplayName string
+	AvatarURL   string
+}
+
+type MemberSyncStatus struct {
+	WorkspaceID string
+	SyncedAt    time.Time
+	MemberCount int
+}
+
+// SyncSlackMembers inserts members without a row and refreshes the handle,
+// display name and avatar of the rest, then records the sync in
+// workspace_member_sync_log. Soft-deleted people stay deleted. members must
+// not repeat a Slack user ID.
+func (r *PeopleRepository) SyncSlackMembers(ctx context.Context, workspaceID string, members []SlackMemberProfile, syncedAt time.Time) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	ids := make([]string, 0, len(members))
+	handles := make([]string, 0, len(members))
+	names := make([]string, 0, len(members))
+	avatars := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.SlackUserID)
+		handles = append(handles, m.SlackHandle)
+		names = append(names, m.DisplayName)
+		avatars = append(avatars, m.AvatarURL)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sync slack members: %w", err)
+	}
+
+	const upsert = `
+INSERT INTO people (workspace_id, slack_user_id, slack_handle, display_name, avatar_url)
+SELECT $1, m.slack_user_id, m.slack_handle, m.display_name, m.avatar_url
+FROM unnest($2::text[], $3::text[], $4::text[], $5::text[]) AS m(slack_user_id, slack_handle, display_name, avatar_url)
+ON CONFLICT (workspace_id, slack_user_id)
+DO UPDATE SET
+    slack_handle = EXCLUDED.slack_handle,
+    display_name = EXCLUDED.display_name,
+    avatar_url = EXCLUDED.avatar_url,
+    updated_at = NOW()
+WHERE (people.slack_handle, people.display_name, people.avatar_url)
+      IS DISTINCT FROM (EXCLUDED.slack_handle, EXCLUDED.display_name, EXCLUDED.avatar_url)
+`
+	if _, err := tx.ExecContext(ctx, upsert, workspaceID, ids, handles, names, avatars); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("upsert slack members: %w", err)
+	}
+
+	const record = `
+INSERT INTO workspace_member_sync_log (workspace_id, synced_at, member_count)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id)
+DO UPDATE SET synced_at = EXCLUDED.synced_at, member_count = EXCLUDED.member_count
+`
+	if _, err := tx.ExecContext(ctx, record, workspaceID, syncedAt, len(members)); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("record member sync: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit sync slack members: %w", err)
+	}
+
+	return nil
+}
+
+// GetMemberSyncStatus returns ErrNotFound until the workspace's first sync.
+func (r *PeopleRepository) GetMemberSyncStatus(ctx context.Context, workspaceID string) (MemberSyncStatus, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `SELECT workspace_id, synced_at, member_count FROM workspace_member_sync_log WHERE workspace_id = $1`
+
+	var out MemberSyncStatus
+	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(&out.WorkspaceID, &out.SyncedAt, &out.MemberCount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return MemberSyncStatus{}, ErrNotFound
+		}
+		return MemberSyncStatus{}, fmt.Errorf("get member sync status: %w", err)
+	}
+
+	return out, nil
 }
 
 func (r *PeopleRepository) GetByWorkspaceAndSlackUserID(ctx context.Context, workspaceID, slackUserID string) (domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
        birthday_day, birthday_month, birthday_year,
        hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
 FROM people
-WHERE workspace_id = $1 AND slack_user_id = $2
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
 `
 
 	row := r.db.QueryRowContext(ctx, q, workspaceID, slackUserID)
@@ -86,6 +310,9 @@ WHERE workspace_id = $1 AND slack_user_id = $2
 }
 
 func (r *PeopleRepository) Upsert(ctx context.Context, in UpsertPersonInput) (domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 INSERT INTO people (
     workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
@@ -104,6 +331,7 @@ DO UPDATE SET
     hire_date = EXCLUDED.hire_date,
     public_celebration_opt_in = EXCLUDED.public_celebration_opt_in,
     reminders_mode = EXCLUDED.reminders_mode,
+    deleted_at = NULL,
     updated_at = NOW()
 RETURNING id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
           birthday_day, birthday_month, birthday_year,
@@ -140,13 +368,220 @@ RETURNING id, workspace_id, slack_user_id, slack_handle, display_name, avatar_ur
 	return p, nil
 }
 
+func (r *PeopleRepository) ClearBirthday(ctx context.Context, workspaceID, slackUserID string) (domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE people
+SET birthday_day = NULL,
+    birthday_month = NULL,
+    birthday_year = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
+RETURNING id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+          birthday_day, birthday_month, birthday_year,
+          hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+`
+
+	p, err := scanPerson(r.db.QueryRowContext(ctx, q, workspaceID, slackUserID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Person{}, ErrNotFound
+		}
+		return domain.Person{}, fmt.Errorf("clear birthday: %w", err)
+	}
+
+	return p, nil
+}
+
+func (r *PeopleRepository) ClearHireDate(ctx context.Context, workspaceID, slackUserID string) (domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE people
+SET hire_date = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
+RETURNING id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+          birthday_day, birthday_month, birthday_year,
+          hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+`
+
+	p, err := scanPerson(r.db.QueryRowContext(ctx, q, workspaceID, slackUserID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Person{}, ErrNotFound
+		}
+		return domain.Person{}, fmt.Errorf("clear hire date: %w", err)
+	}
+
+	return p, nil
+}
+
+// SoftDeletePerson hides a person from listings and celebrations while keeping
+// the row, and with it their opt-out choice, for a later restore.
+func (r *PeopleRepository) SoftDeletePerson(ctx context.Context, workspaceID, slackUserID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE people
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE workspace_id = $1 AND slack_user_id = $2 AND deleted_at IS NULL
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, slackUserID)
+	if err != nil {
+		return fmt.Errorf("soft delete person: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("soft delete person rows affected: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RestorePerson undoes SoftDeletePerson. Restoring a person who is not deleted
+// is a no-op.
+func (r *PeopleRepository) RestorePerson(ctx context.Context, workspaceID, slackUserID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE people
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1 AND slack_user_id = $2
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, slackUserID)
+	if err != nil {
+		return fmt.Errorf("restore person: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("restore person rows affected: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// personEraseStatements remove every row holding a person's data, children
+// first. Each takes the workspace ID as $1 and the Slack user ID as $2.
+var personEraseStatements = []struct {
+	table string
+	query string
+}{
+	{"reminder_log", `DELETE FROM reminder_log WHERE person_id IN (SELECT id FROM people WHERE workspace_id = $1 AND slack_user_id = $2)`},
+	{"people", `DELETE FROM people WHERE workspace_id = $1 AND slack_user_id = $2`},
+	{"onboarding_dm_log", `DELETE FROM onboarding_dm_log WHERE workspace_id = $1 AND slack_user_id = $2`},
+	{"celebration_post_log", `DELETE FROM celebration_post_log WHERE workspace_channel_id IN (SELECT id FROM workspace_channels WHERE workspace_id = $1) AND $2 = ANY(slack_user_ids)`},
+}
+
+type PersonErasure struct {
+	DeletedTables []string
+	RowsAffected  int64
+}
+
+// ErasePerson hard-deletes everything stored about a person, soft-deleted or
+// not, and records the erasure in data_deletion_audit_log in the same
+// transaction. It returns ErrNotFound when there was nothing to delete.
+func (r *PeopleRepository) ErasePerson(ctx context.Context, workspaceID, slackUserID string) (PersonErasure, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PersonErasure{}, fmt.Errorf("begin erase person: %w", err)
+	}
+
+	out := PersonErasure{DeletedTables: make([]string, 0, len(personEraseStatements))}
+	for _, stmt := range personEraseStatements {
+		res, err := tx.ExecContext(ctx, stmt.query, workspaceID, slackUserID)
+		if err != nil {
+			_ = tx.Rollback()
+			return PersonErasure{}, fmt.Errorf("erase person from %s: %w", stmt.table, err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			_ = tx.Rollback()
+			return PersonErasure{}, fmt.Errorf("erase person from %s rows affected: %w", stmt.table, err)
+		}
+		out.DeletedTables = append(out.DeletedTables, stmt.table)
+		out.RowsAffected += affected
+	}
+
+	if out.RowsAffected == 0 {
+		_ = tx.Rollback()
+		return PersonErasure{}, ErrNotFound
+	}
+
+	const audit = `INSERT INTO data_deletion_audit_log (workspace_id, slack_user_id) VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, audit, workspaceID, slackUserID); err != nil {
+		_ = tx.Rollback()
+		return PersonErasure{}, fmt.Errorf("record data deletion audit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PersonErasure{}, fmt.Errorf("commit erase person: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PeopleRepository) BulkUpdateRemindersMode(ctx context.Context, workspaceID, mode string, userIDs []string) (int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	q := `
+UPDATE people
+SET reminders_mode = $2,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+`
+	args := []any{workspaceID, mode}
+	if len(userIDs) > 0 {
+		q += "  AND slack_user_id = ANY($3)\n"
+		args = append(args, userIDs)
+	}
+
+	res, err := r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return 0, fmt.Errorf("bulk update reminders mode: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("bulk update reminders mode rows affected: %w", err)
+	}
+
+	return int(updated), nil
+}
+
 func (r *PeopleRepository) FindBirthdaysByWorkspaceAndDate(ctx context.Context, workspaceID string, month, day int) ([]domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
        birthday_day, birthday_month, birthday_year,
        hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
 FROM people
 WHERE workspace_id = $1
+  AND deleted_at IS NULL
   AND public_celebration_opt_in = TRUE
   AND birthday_month = $2
   AND birthday_day = $3
@@ -175,7 +610,144 @@ ORDER BY display_name
 	return birthdays, nil
 }
 
-func (r *PeopleRepository) FindAnniversariesByWorkspaceAndDate(ctx context.Context, workspaceID string, month, day, year int) ([]domain.AnniversaryPerson, error) {
+// FindReminderCandidates returns people using remindersMode whose birthday or
+// hire date falls on month/day. Public celebration opt-in does not apply to
+// personal reminders.
+func (r *PeopleRepository) FindReminderCandidates(ctx context.Context, workspaceID, remindersMode string, month, day int) ([]domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+       birthday_day, birthday_month, birthday_year,
+       hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+FROM people
+WHERE workspace_id = $1
+  AND deleted_at IS NULL
+  AND reminders_mode = $2
+  AND (
+      (birthday_month = $3 AND birthday_day = $4)
+      OR (hire_date IS NOT NULL AND EXTRACT(MONTH FROM hire_date) = $3 AND EXTRACT(DAY FROM hire_date) = $4)
+  )
+ORDER BY display_name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, remindersMode, month, day)
+	if err != nil {
+		return nil, fmt.Errorf("find reminder candidates: %w", err)
+	}
+	defer rows.Close()
+
+	people := make([]domain.Person, 0)
+	for rows.Next() {
+		p, err := scanPerson(rows)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reminder candidates: %w", err)
+	}
+
+	return people, nil
+}
+
+func (r *PeopleRepository) FindAllBirthdaysByDate(ctx context.Context, month, day int) ([]domain.Person, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+       birthday_day, birthday_month, birthday_year,
+       hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+FROM people
+WHERE deleted_at IS NULL
+  AND birthday_month = $1
+  AND birthday_day = $2
+ORDER BY workspace_id, display_name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, month, day)
+	if err != nil {
+		return nil, fmt.Errorf("find all birthdays: %w", err)
+	}
+	defer rows.Close()
+
+	birthdays := make([]domain.Person, 0)
+	for rows.Next() {
+		p, err := scanPerson(rows)
+		if err != nil {
+			return nil, err
+		}
+		birthdays = append(birthdays, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate all birthdays: %w", err)
+	}
+
+	return birthdays, nil
+}
+
+type BirthdayGroup struct {
+	Month  int
+	Day    int
+	People []domain.Person
+}
+
+func (r *PeopleRepository) FindBirthdayDuplicates(ctx context.Context, workspaceID string) ([]BirthdayGroup, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
+       birthday_day, birthday_month, birthday_year,
+       hire_date, public_celebration_opt_in, reminders_mode, created_at, updated_at
+FROM (
+    SELECT *, COUNT(*) OVER (PARTITION BY birthday_month, birthday_day) AS group_size
+    FROM people
+    WHERE workspace_id = $1
+      AND deleted_at IS NULL
+      AND birthday_month IS NOT NULL
+      AND birthday_day IS NOT NULL
+) p
+WHERE group_size >= 2
+ORDER BY birthday_month, birthday_day, display_name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("find birthday duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make([]BirthdayGroup, 0)
+	for rows.Next() {
+		p, err := scanPerson(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		month, day := *p.BirthdayMonth, *p.BirthdayDay
+		if n := len(groups); n == 0 || groups[n-1].Month != month || groups[n-1].Day != day {
+			groups = append(groups, BirthdayGroup{Month: month, Day: day})
+		}
+		groups[len(groups)-1].People = append(groups[len(groups)-1].People, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate birthday duplicates: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (r *PeopleRepository) FindAnniversariesByWorkspaceAndDate(ctx context.Context, workspaceID string, month, day, year, minTenureMonths int) ([]domain.AnniversaryPerson, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
        birthday_day, birthday_month, birthday_year,
@@ -184,14 +756,16 @@ SELECT id, workspace_id, slack_user_id, slack_handle, display_name, avatar_url,
        ($4 - EXTRACT(YEAR FROM hire_date)::int) AS years
 FROM people
 WHERE workspace_id = $1
+  AND deleted_at IS NULL
   AND public_celebration_opt_in = TRUE
   AND hire_date IS NOT NULL
   AND EXTRACT(MONTH FROM hire_date) = $2
   AND EXTRACT(DAY FROM hire_date) = $3
+  AND ($4 - EXTRACT(YEAR FROM hire_date)::int) * 12 >= $5
 ORDER BY display_name
 `
 
-	rows, err := r.db.QueryContext(ctx, q, workspaceID, month, day, year)
+	rows, err := r.db.QueryContext(ctx, q, workspaceID, month, day, year, minTenureMonths)
 	if err != nil {
 		return nil, fmt.Errorf("find anniversaries: %w", err)
 	}
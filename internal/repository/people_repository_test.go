@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPeopleSearchPattern(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "alice", want: "%alice%"},
+		{query: "  Alice ", want: "%Alice%"},
+		{query: "50%_off", want: `%50\%\_off%`},
+		{query: `back\slash`, want: `%back\\slash%`},
+	}
+
+	for _, tc := range tests {
+		if got := peopleSearchPattern(tc.query); got != tc.want {
+			t.Fatalf("peopleSearchPattern(%q) = %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestSearchByWorkspace_IgnoresCase(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-people-search-%d", time.Now().UnixNano()), "People search test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	people := NewPeopleRepository(db, testQueryTimeout)
+	for _, in := range []UpsertPersonInput{
+		{WorkspaceID: workspace.ID, SlackUserID: "U-alice", SlackHandle: "alice.w", DisplayName: "Alice Walker", RemindersMode: "none"},
+		{WorkspaceID: workspace.ID, SlackUserID: "U-bob", SlackHandle: "BOBBY", DisplayName: "Bob Stone", RemindersMode: "none"},
+	} {
+		if _, err := people.Upsert(ctx, in); err != nil {
+			t.Fatalf("upsert person: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "ALICE", want: "U-alice"},
+		{query: "walker", want: "U-alice"},
+		{query: "bobby", want: "U-bob"},
+		{query: "sToNe", want: "U-bob"},
+	}
+
+	for _, tc := range tests {
+		got, total, err := people.SearchByWorkspace(ctx, workspace.ID, tc.query, 10, 0)
+		if err != nil {
+			t.Fatalf("search %q: %v", tc.query, err)
+		}
+		if total != 1 || len(got) != 1 || got[0].SlackUserID != tc.want {
+			t.Fatalf("search %q = %d results (total %d), want only %s", tc.query, len(got), total, tc.want)
+		}
+	}
+}
+
+func TestMissingDataCondition(t *testing.T) {
+	tests := []struct {
+		missing MissingData
+		want    string
+	}{
+		{MissingNone, ""},
+		{MissingBirthday, "\n  AND birthday_day IS NULL"},
+		{MissingHireDate, "\n  AND hire_date IS NULL"},
+		{MissingAny, "\n  AND (birthday_day IS NULL OR hire_date IS NULL)"},
+	}
+
+	for _, tc := range tests {
+		got, err := missingDataCondition(tc.missing)
+		if err != nil || got != tc.want {
+			t.Fatalf("missingDataCondition(%q) = %q, %v; want %q", tc.missing, got, err, tc.want)
+		}
+		if !tc.missing.Valid() {
+			t.Fatalf("expected %q to be valid", tc.missing)
+		}
+	}
+
+	if _, err := missingDataCondition("avatar"); err == nil {
+		t.Fatal("expected an error for an unknown filter")
+	}
+	if MissingData("avatar").Valid() {
+		t.Fatal("expected an unknown filter to be invalid")
+	}
+}
+
+func TestErasePerson_RemovesRowsAndRecordsAudit(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-erase-person-%d", time.Now().UnixNano()), "Erase person test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = workspaces.DeleteWorkspace(context.Background(), workspace.ID)
+		_, _ = db.ExecContext(context.Background(), `DELETE FROM data_deletion_audit_log WHERE workspace_id = $1`, workspace.ID)
+	})
+
+	people := NewPeopleRepository(db, testQueryTimeout)
+	if _, err := people.Upsert(ctx, UpsertPersonInput{
+		WorkspaceID:   workspace.ID,
+		SlackUserID:   "U-erase",
+		SlackHandle:   "erase",
+		DisplayName:   "Erase Me",
+		RemindersMode: "none",
+	}); err != nil {
+		t.Fatalf("upsert person: %v", err)
+	}
+	if err := NewOnboardingRepository(db, testQueryTimeout).MarkSent(ctx, workspace.ID, "U-erase"); err != nil {
+		t.Fatalf("mark onboarding sent: %v", err)
+	}
+
+	erasure, err := people.ErasePerson(ctx, workspace.ID, "U-erase")
+	if err != nil {
+		t.Fatalf("erase person: %v", err)
+	}
+	if erasure.RowsAffected != 2 {
+		t.Fatalf("rows affected = %d, want 2", erasure.RowsAffected)
+	}
+
+	if _, err := people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-erase"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the person to be gone, got %v", err)
+	}
+
+	var audits int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM data_deletion_audit_log WHERE workspace_id = $1 AND slack_user_id = $2`, workspace.ID, "U-erase").Scan(&audits); err != nil {
+		t.Fatalf("count audit rows: %v", err)
+	}
+	if audits != 1 {
+		t.Fatalf("audit rows = %d, want 1", audits)
+	}
+
+	if _, err := people.ErasePerson(ctx, workspace.ID, "U-erase"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on second erase, got %v", err)
+	}
+}
+
+func TestSyncSlackMembers_RefreshesProfilesOnly(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-member-sync-%d", time.Now().UnixNano()), "Member sync test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	people := NewPeopleRepository(db, testQueryTimeout)
+	day, month := 14, 6
+	for _, in := range []UpsertPersonInput{
+		{WorkspaceID: workspace.ID, SlackUserID: "U-saved", SlackHandle: "old", DisplayName: "Old Name", BirthdayDay: &day, BirthdayMonth: &month, RemindersMode: "day_before"},
+		{WorkspaceID: workspace.ID, SlackUserID: "U-deleted", SlackHandle: "gone", DisplayName: "Gone", RemindersMode: "same_day"},
+	} {
+		if _, err := people.Upsert(ctx, in); err != nil {
+			t.Fatalf("upsert person: %v", err)
+		}
+	}
+	if err := people.SoftDeletePerson(ctx, workspace.ID, "U-deleted"); err != nil {
+		t.Fatalf("soft delete person: %v", err)
+	}
+
+	if _, err := people.GetMemberSyncStatus(ctx, workspace.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before the first sync, got %v", err)
+	}
+
+	syncedAt := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	if err := people.SyncSlackMembers(ctx, workspace.ID, []SlackMemberProfile{
+		{SlackUserID: "U-saved", SlackHandle: "new", DisplayName: "New Name", AvatarURL: "https://example.com/a.png"},
+		{SlackUserID: "U-deleted", SlackHandle: "gone", DisplayName: "Gone"},
+		{SlackUserID: "U-new", SlackHandle: "fresh", DisplayName: "Fresh"},
+	}, syncedAt); err != nil {
+		t.Fatalf("sync slack members: %v", err)
+	}
+
+	saved, err := people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-saved")
+	if err != nil {
+		t.Fatalf("get saved person: %v", err)
+	}
+	if saved.SlackHandle != "new" || saved.DisplayName != "New Name" || saved.AvatarURL != "https://example.com/a.png" {
+		t.Fatalf("expected the profile to be refreshed, got %#v", saved)
+	}
+	if saved.BirthdayDay == nil || *saved.BirthdayDay != day || saved.RemindersMode != "day_before" {
+		t.Fatalf("expected saved fields to be kept, got %#v", saved)
+	}
+
+	if _, err := people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-deleted"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the soft-deleted person to stay deleted, got %v", err)
+	}
+	if _, err := people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-new"); err != nil {
+		t.Fatalf("expected the new member to be inserted: %v", err)
+	}
+
+	status, err := people.GetMemberSyncStatus(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("get member sync status: %v", err)
+	}
+	if !status.SyncedAt.Equal(syncedAt) || status.MemberCount != 3 {
+		t.Fatalf("unexpected sync status: %#v", status)
+	}
+}
+
+func TestClearBirthdayAndHireDate_LeaveTheOtherDate(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	workspaces := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := workspaces.EnsureWorkspace(ctx, fmt.Sprintf("T-clear-dates-%d", time.Now().UnixNano()), "Clear dates test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = workspaces.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	people := NewPeopleRepository(db, testQueryTimeout)
+	day, month := 14, 6
+	hire := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := people.Upsert(ctx, UpsertPersonInput{
+		WorkspaceID:            workspace.ID,
+		SlackUserID:            "U-clear",
+		SlackHandle:            "clear",
+		DisplayName:            "Clear Me",
+		BirthdayDay:            &day,
+		BirthdayMonth:          &month,
+		HireDate:               &hire,
+		PublicCelebrationOptIn: true,
+		RemindersMode:          "same_day",
+	}); err != nil {
+		t.Fatalf("upsert person: %v", err)
+	}
+
+	if _, err := people.ClearBirthday(ctx, workspace.ID, "U-clear"); err != nil {
+		t.Fatalf("clear birthday: %v", err)
+	}
+	p, err := people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-clear")
+	if err != nil {
+		t.Fatalf("get person: %v", err)
+	}
+	if p.BirthdayDay != nil || p.BirthdayMonth != nil {
+		t.Fatalf("expected the birthday to be cleared, got %#v", p)
+	}
+	if p.HireDate == nil || !p.HireDate.Equal(hire) {
+		t.Fatalf("expected the hire date to be unchanged, got %v", p.HireDate)
+	}
+
+	if _, err := people.Upsert(ctx, UpsertPersonInput{
+		WorkspaceID:            workspace.ID,
+		SlackUserID:            "U-clear",
+		SlackHandle:            "clear",
+		DisplayName:            "Clear Me",
+		BirthdayDay:            &day,
+		BirthdayMonth:          &month,
+		HireDate:               &hire,
+		PublicCelebrationOptIn: true,
+		RemindersMode:          "same_day",
+	}); err != nil {
+		t.Fatalf("upsert person: %v", err)
+	}
+	if _, err := people.ClearHireDate(ctx, workspace.ID, "U-clear"); err != nil {
+		t.Fatalf("clear hire date: %v", err)
+	}
+	p, err = people.GetByWorkspaceAndSlackUserID(ctx, workspace.ID, "U-clear")
+	if err != nil {
+		t.Fatalf("get person: %v", err)
+	}
+	if p.HireDate != nil {
+		t.Fatalf("expected the hire date to be cleared, got %v", p.HireDate)
+	}
+	if p.BirthdayDay == nil || *p.BirthdayDay != day || p.BirthdayMonth == nil || *p.BirthdayMonth != month {
+		t.Fatalf("expected the birthday to be unchanged, got %#v", p)
+	}
+
+	if _, err := people.ClearHireDate(ctx, workspace.ID, "U-missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown person, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ReminderLogRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewReminderLogRepository(db *sql.DB, queryTimeout time.Duration) *ReminderLogRepository {
+	return &ReminderLogRepository{db: db, queryTimeout: queryTimeout}
+}
+
+// Claim records that the person's reminder for eventType in eventYear is being
+// sent. It returns false when the reminder was already claimed.
+func (r *ReminderLogRepository) Claim(ctx context.Context, personID, eventType string, eventYear int) (bool, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+INSERT INTO reminder_log (person_id, event_type, event_year)
+VALUES ($1, $2, $3)
+ON CONFLICT (person_id, event_type, event_year) DO NOTHING
+`
+
+	res, err := r.db.ExecContext(ctx, q, personID, eventType, eventYear)
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim reminder rows affected: %w", err)
+	}
+
+	return inserted == 1, nil
+}
+
+// Release drops a claim so a reminder that failed to send is retried.
+func (r *ReminderLogRepository) Release(ctx context.Context, personID, eventType string, eventYear int) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+DELETE FROM reminder_log
+WHERE person_id = $1 AND event_type = $2 AND event_year = $3
+`
+
+	if _, err := r.db.ExecContext(ctx, q, personID, eventType, eventYear); err != nil {
+		return fmt.Errorf("release reminder: %w", err)
+	}
+
+	return nil
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"slackcheers/internal/domain"
 )
 
 type WorkspaceRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
 type WorkspaceSlackInstallation struct {
@@ -18,6 +21,9 @@ type WorkspaceSlackInstallation struct {
 	SlackTeamID string
 	BotToken    string
 	BotUserID   string
+	// InstalledByUserID is the Slack user who installed the app, the
+	// workspace's admin contact.
+	InstalledByUserID string
 }
 
 type SaveSlackInstallationInput struct {
@@ -29,59 +35,240 @@ type SaveSlackInstallationInput struct {
 	Scope           string
 }
 
-func NewWorkspaceRepository(db *sql.DB) *WorkspaceRepository {
-	return &WorkspaceRepository{db: db}
+func NewWorkspaceRepository(db *sql.DB, queryTimeout time.Duration) *WorkspaceRepository {
+	return &WorkspaceRepository{db: db, queryTimeout: queryTimeout}
 }
 
 func (r *WorkspaceRepository) EnsureWorkspace(ctx context.Context, slackTeamID, name, timezone string) (domain.Workspace, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 INSERT INTO workspaces (slack_team_id, name, timezone)
 VALUES ($1, $2, $3)
 ON CONFLICT (slack_team_id)
 DO UPDATE SET name = EXCLUDED.name, timezone = EXCLUDED.timezone, updated_at = NOW()
-RETURNING id, slack_team_id, name, timezone, created_at, updated_at
-`
+RETURNING ` + workspaceColumns
 
 	var w domain.Workspace
-	if err := r.db.QueryRowContext(ctx, q, slackTeamID, name, timezone).Scan(
-		&w.ID,
-		&w.SlackTeamID,
-		&w.Name,
-		&w.Timezone,
-		&w.CreatedAt,
-		&w.UpdatedAt,
-	); err != nil {
+	if err := scanWorkspace(r.db.QueryRowContext(ctx, q, slackTeamID, name, timezone), &w); err != nil {
 		return domain.Workspace{}, fmt.Errorf("ensure workspace: %w", err)
 	}
 
 	return w, nil
 }
 
+func (r *WorkspaceRepository) GetByID(ctx context.Context, workspaceID string) (domain.Workspace, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	q := `SELECT ` + workspaceColumns + `FROM workspaces WHERE id = $1`
+
+	var w domain.Workspace
+	if err := scanWorkspace(r.db.QueryRowContext(ctx, q, workspaceID), &w); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Workspace{}, ErrNotFound
+		}
+		return domain.Workspace{}, fmt.Errorf("get workspace: %w", err)
+	}
+
+	return w, nil
+}
+
+// UpdateWorkspace sets the workspace name and timezone. An empty value keeps
+// the stored one. Channel timezones are independent and left alone.
+func (r *WorkspaceRepository) UpdateWorkspace(ctx context.Context, workspaceID, name, timezone string) (domain.Workspace, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspaces
+SET name = COALESCE(NULLIF($2, ''), name),
+    timezone = COALESCE(NULLIF($3, ''), timezone),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING ` + workspaceColumns
+
+	var w domain.Workspace
+	if err := scanWorkspace(r.db.QueryRowContext(ctx, q, workspaceID, name, timezone), &w); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Workspace{}, ErrNotFound
+		}
+		return domain.Workspace{}, fmt.Errorf("update workspace: %w", err)
+	}
+
+	return w, nil
+}
+
+// workspaceDeleteStatements remove a workspace's rows children first. Each
+// takes the workspace ID as $1.
+var workspaceDeleteStatements = []string{
+	`DELETE FROM celebration_dispatch_log WHERE workspace_channel_id IN (SELECT id FROM workspace_channels WHERE workspace_id = $1)`,
+	`DELETE FROM celebration_post_log WHERE workspace_channel_id IN (SELECT id FROM workspace_channels WHERE workspace_id = $1)`,
+	`DELETE FROM workspace_channels WHERE workspace_id = $1`,
+	`DELETE FROM reminder_log WHERE person_id IN (SELECT id FROM people WHERE workspace_id = $1)`,
+	`DELETE FROM people WHERE workspace_id = $1`,
+	`DELETE FROM onboarding_dm_log WHERE workspace_id = $1`,
+	`DELETE FROM idempotency_keys WHERE workspace_id = $1`,
+	`DELETE FROM workspace_member_sync_log WHERE workspace_id = $1`,
+	`DELETE FROM digest_log WHERE workspace_id = $1`,
+	`DELETE FROM workspaces WHERE id = $1`,
+}
+
+// DeleteWorkspace hard-deletes a workspace and all of its rows in a single
+// transaction.
+func (r *WorkspaceRepository) DeleteWorkspace(ctx context.Context, workspaceID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete workspace: %w", err)
+	}
+
+	var id string
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM workspaces WHERE id = $1 FOR UPDATE`, workspaceID).Scan(&id); err != nil {
+		_ = tx.Rollback()
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("lock workspace: %w", err)
+	}
+
+	for _, stmt := range workspaceDeleteStatements {
+		if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("delete workspace: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete workspace: %w", err)
+	}
+
+	return nil
+}
+
 func (r *WorkspaceRepository) EnsureWorkspaceFromInstall(ctx context.Context, slackTeamID, name string) (domain.Workspace, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 INSERT INTO workspaces (slack_team_id, name, timezone)
 VALUES ($1, $2, 'UTC')
 ON CONFLICT (slack_team_id)
 DO UPDATE SET name = EXCLUDED.name, updated_at = NOW()
-RETURNING id, slack_team_id, name, timezone, created_at, updated_at
-`
+RETURNING ` + workspaceColumns
 
 	var w domain.Workspace
-	if err := r.db.QueryRowContext(ctx, q, slackTeamID, name).Scan(
-		&w.ID,
-		&w.SlackTeamID,
-		&w.Name,
-		&w.Timezone,
-		&w.CreatedAt,
-		&w.UpdatedAt,
-	); err != nil {
+	if err := scanWorkspace(r.db.QueryRowContext(ctx, q, slackTeamID, name), &w); err != nil {
 		return domain.Workspace{}, fmt.Errorf("ensure workspace from install: %w", err)
 	}
 
 	return w, nil
 }
 
+type WorkspaceSummary struct {
+	domain.Workspace
+	PeopleCount   int
+	ChannelsCount int
+}
+
+// ListAll pages through workspaces, newest first, with their people and
+// channel counts and the total number of workspaces. It selects only listing
+// columns; bot tokens live in the installation columns and are never read
+// here.
+func (r *WorkspaceRepository) ListAll(ctx context.Context, limit, offset int) ([]WorkspaceSummary, int, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workspaces`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count workspaces: %w", err)
+	}
+
+	const q = `
+SELECT w.id, w.slack_team_id, w.name, w.timezone, w.created_at, w.updated_at,
+       COUNT(DISTINCT p.id) AS people_count,
+       COUNT(DISTINCT wc.id) AS channels_count
+FROM workspaces w
+LEFT JOIN people p ON p.workspace_id = w.id AND p.deleted_at IS NULL
+LEFT JOIN workspace_channels wc ON wc.workspace_id = w.id
+GROUP BY w.id
+ORDER BY w.created_at DESC, w.id
+LIMIT $1 OFFSET $2
+`
+
+	var pageLimit sql.NullInt64
+	if limit > 0 {
+		pageLimit = sql.NullInt64{Int64: int64(limit), Valid: true}
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, pageLimit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list all workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]WorkspaceSummary, 0)
+	for rows.Next() {
+		var s WorkspaceSummary
+		if err := rows.Scan(
+			&s.ID,
+			&s.SlackTeamID,
+			&s.Name,
+			&s.Timezone,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+			&s.PeopleCount,
+			&s.ChannelsCount,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan workspace summary: %w", err)
+		}
+		summaries = append(summaries, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate all workspaces: %w", err)
+	}
+
+	return summaries, total, nil
+}
+
+// ListConnectedWorkspaceIDs returns the workspaces that still have a bot token.
+func (r *WorkspaceRepository) ListConnectedWorkspaceIDs(ctx context.Context) ([]string, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM workspaces WHERE COALESCE(slack_bot_token, '') <> '' ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list connected workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan connected workspace: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate connected workspaces: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *WorkspaceRepository) SaveSlackInstallation(ctx context.Context, in SaveSlackInstallationInput) (domain.Workspace, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	workspace, err := r.EnsureWorkspaceFromInstall(ctx, in.TeamID, in.TeamName)
 	if err != nil {
 		return domain.Workspace{}, err
@@ -111,15 +298,48 @@ WHERE id = $1
 	return workspace, nil
 }
 
+// RevokeSlackInstallation clears the stored bot credentials for a team after
+// Slack reports the app was uninstalled or its tokens revoked. It returns the
+// workspace ID so callers can drop anything cached for it.
+func (r *WorkspaceRepository) RevokeSlackInstallation(ctx context.Context, slackTeamID string) (string, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspaces
+SET slack_bot_token = NULL,
+    slack_bot_user_id = NULL,
+    installed_by_user_id = NULL,
+    installed_scopes = NULL,
+    updated_at = NOW()
+WHERE slack_team_id = $1
+RETURNING id
+`
+
+	var workspaceID string
+	if err := r.db.QueryRowContext(ctx, q, slackTeamID).Scan(&workspaceID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("revoke slack installation: %w", err)
+	}
+
+	return workspaceID, nil
+}
+
 func (r *WorkspaceRepository) GetSlackInstallationByWorkspaceID(ctx context.Context, workspaceID string) (WorkspaceSlackInstallation, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
-SELECT id, slack_team_id, COALESCE(slack_bot_token, ''), COALESCE(slack_bot_user_id, '')
+SELECT id, slack_team_id, COALESCE(slack_bot_token, ''), COALESCE(slack_bot_user_id, ''),
+       COALESCE(installed_by_user_id, '')
 FROM workspaces
 WHERE id = $1
 `
 
 	var out WorkspaceSlackInstallation
-	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(&out.WorkspaceID, &out.SlackTeamID, &out.BotToken, &out.BotUserID); err != nil {
+	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(&out.WorkspaceID, &out.SlackTeamID, &out.BotToken, &out.BotUserID, &out.InstalledByUserID); err != nil {
 		if err == sql.ErrNoRows {
 			return WorkspaceSlackInstallation{}, ErrNotFound
 		}
@@ -130,14 +350,18 @@ WHERE id = $1
 }
 
 func (r *WorkspaceRepository) GetSlackInstallationByTeamID(ctx context.Context, slackTeamID string) (WorkspaceSlackInstallation, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
-SELECT id, slack_team_id, COALESCE(slack_bot_token, ''), COALESCE(slack_bot_user_id, '')
+SELECT id, slack_team_id, COALESCE(slack_bot_token, ''), COALESCE(slack_bot_user_id, ''),
+       COALESCE(installed_by_user_id, '')
 FROM workspaces
 WHERE slack_team_id = $1
 `
 
 	var out WorkspaceSlackInstallation
-	if err := r.db.QueryRowContext(ctx, q, slackTeamID).Scan(&out.WorkspaceID, &out.SlackTeamID, &out.BotToken, &out.BotUserID); err != nil {
+	if err := r.db.QueryRowContext(ctx, q, slackTeamID).Scan(&out.WorkspaceID, &out.SlackTeamID, &out.BotToken, &out.BotUserID, &out.InstalledByUserID); err != nil {
 		if err == sql.ErrNoRows {
 			return WorkspaceSlackInstallation{}, ErrNotFound
 		}
@@ -147,7 +371,133 @@ WHERE slack_team_id = $1
 	return out, nil
 }
 
+type WorkspacePrivacySettings struct {
+	BirthdayYearPrivacy string
+}
+
+func (r *WorkspaceRepository) GetPrivacySettings(ctx context.Context, workspaceID string) (WorkspacePrivacySettings, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT birthday_year_privacy
+FROM workspaces
+WHERE id = $1
+`
+
+	var out WorkspacePrivacySettings
+	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(&out.BirthdayYearPrivacy); err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspacePrivacySettings{}, ErrNotFound
+		}
+		return WorkspacePrivacySettings{}, fmt.Errorf("get workspace privacy settings: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *WorkspaceRepository) UpdatePrivacySettings(ctx context.Context, workspaceID string, in WorkspacePrivacySettings) (WorkspacePrivacySettings, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspaces
+SET birthday_year_privacy = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING birthday_year_privacy
+`
+
+	var out WorkspacePrivacySettings
+	if err := r.db.QueryRowContext(ctx, q, workspaceID, in.BirthdayYearPrivacy).Scan(&out.BirthdayYearPrivacy); err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspacePrivacySettings{}, ErrNotFound
+		}
+		return WorkspacePrivacySettings{}, fmt.Errorf("update workspace privacy settings: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *WorkspaceRepository) UpdateAnnouncementChannel(ctx context.Context, workspaceID, slackChannelID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspaces
+SET announcement_channel_id = NULLIF($2, ''),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, slackChannelID)
+	if err != nil {
+		return fmt.Errorf("update announcement channel: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update announcement channel rows affected: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRepository) GetOnboardingTemplate(ctx context.Context, workspaceID string) (string, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT COALESCE(onboarding_message_template, '')
+FROM workspaces
+WHERE id = $1
+`
+
+	var template string
+	if err := r.db.QueryRowContext(ctx, q, workspaceID).Scan(&template); err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("get onboarding template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *WorkspaceRepository) UpdateOnboardingTemplate(ctx context.Context, workspaceID, template string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspaces
+SET onboarding_message_template = NULLIF($2, ''),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceID, template)
+	if err != nil {
+		return fmt.Errorf("update onboarding template: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update onboarding template rows affected: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *WorkspaceRepository) CreateDefaultChannel(ctx context.Context, workspaceID, channelID, channelName, timezone, postingTime string) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 INSERT INTO workspace_channels (
     workspace_id, slack_channel_id, slack_channel_name, posting_time, timezone
@@ -159,42 +509,96 @@ DO UPDATE SET
     posting_time = EXCLUDED.posting_time,
     timezone = EXCLUDED.timezone,
     updated_at = NOW()
-RETURNING id, workspace_id, slack_channel_id, slack_channel_name,
-          to_char(posting_time, 'HH24:MI'), timezone,
-          birthdays_enabled, anniversaries_enabled,
-          birthday_template, anniversary_template, COALESCE(branding_emoji, ''),
-          created_at, updated_at
-`
+RETURNING ` + channelColumns
 
 	var c domain.WorkspaceChannel
-	if err := r.db.QueryRowContext(ctx, q, workspaceID, channelID, channelName, postingTime, timezone).Scan(
-		&c.ID,
-		&c.WorkspaceID,
-		&c.SlackChannelID,
-		&c.SlackChannelName,
-		&c.PostingTime,
-		&c.Timezone,
-		&c.BirthdaysEnabled,
-		&c.AnniversariesEnabled,
-		&c.BirthdayTemplate,
-		&c.AnniversaryTemplate,
-		&c.BrandingEmoji,
-		&c.CreatedAt,
-		&c.UpdatedAt,
-	); err != nil {
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, channelID, channelName, postingTime, timezone), &c); err != nil {
 		return domain.WorkspaceChannel{}, fmt.Errorf("create or update channel: %w", err)
 	}
 
 	return c, nil
 }
 
+// DeleteChannel removes a channel, matched by ID or Slack channel ID, together
+// with its dispatch and post logs.
+func (r *WorkspaceRepository) DeleteChannel(ctx context.Context, workspaceID, channelID string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete channel: %w", err)
+	}
+
+	const lockQ = `
+SELECT id
+FROM workspace_channels
+WHERE workspace_id = $1
+  AND (id::text = $2 OR slack_channel_id = $2)
+FOR UPDATE
+`
+
+	var id string
+	if err := tx.QueryRowContext(ctx, lockQ, workspaceID, channelID).Scan(&id); err != nil {
+		_ = tx.Rollback()
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("lock channel: %w", err)
+	}
+
+	for _, stmt := range []string{
+		`DELETE FROM celebration_dispatch_log WHERE workspace_channel_id = $1`,
+		`DELETE FROM celebration_post_log WHERE workspace_channel_id = $1`,
+		`DELETE FROM workspace_channels WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("delete channel: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete channel: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDispatchLogEntry removes a channel's dispatch log entry for one date
+// (YYYY-MM-DD) so the scheduler treats the channel as not yet dispatched.
+func (r *WorkspaceRepository) DeleteDispatchLogEntry(ctx context.Context, workspaceChannelID, dispatchDate string) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+DELETE FROM celebration_dispatch_log
+WHERE workspace_channel_id = $1
+  AND dispatch_date = $2::date
+`
+
+	res, err := r.db.ExecContext(ctx, q, workspaceChannelID, dispatchDate)
+	if err != nil {
+		return fmt.Errorf("delete dispatch log entry: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete dispatch log entry rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *WorkspaceRepository) ListChannelsByWorkspace(ctx context.Context, workspaceID string) ([]domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
-SELECT id, workspace_id, slack_channel_id, slack_channel_name,
-       to_char(posting_time, 'HH24:MI'), timezone,
-       birthdays_enabled, anniversaries_enabled,
-       birthday_template, anniversary_template, COALESCE(branding_emoji, ''),
-       created_at, updated_at
+SELECT ` + channelColumns + `
 FROM workspace_channels
 WHERE workspace_id = $1
 ORDER BY slack_channel_name
@@ -209,21 +613,7 @@ ORDER BY slack_channel_name
 	channels := make([]domain.WorkspaceChannel, 0)
 	for rows.Next() {
 		var c domain.WorkspaceChannel
-		if err := rows.Scan(
-			&c.ID,
-			&c.WorkspaceID,
-			&c.SlackChannelID,
-			&c.SlackChannelName,
-			&c.PostingTime,
-			&c.Timezone,
-			&c.BirthdaysEnabled,
-			&c.AnniversariesEnabled,
-			&c.BirthdayTemplate,
-			&c.AnniversaryTemplate,
-			&c.BrandingEmoji,
-			&c.CreatedAt,
-			&c.UpdatedAt,
-		); err != nil {
+		if err := scanChannel(rows, &c); err != nil {
 			return nil, fmt.Errorf("scan channel: %w", err)
 		}
 		channels = append(channels, c)
@@ -236,39 +626,89 @@ ORDER BY slack_channel_name
 	return channels, nil
 }
 
-func (r *WorkspaceRepository) UpdateChannelSettings(ctx context.Context, workspaceID, channelID, postingTime, timezone string, birthdaysEnabled, anniversariesEnabled bool) (domain.WorkspaceChannel, error) {
+type UpdateChannelSettingsInput struct {
+	WorkspaceID                string
+	ChannelID                  string
+	PostingTime                string
+	Timezone                   string
+	BirthdaysEnabled           bool
+	AnniversariesEnabled       bool
+	MinAnniversaryTenureMonths *int
+	PostDispatchWebhookURL     *string
+	MaxRecipientsPerPost       *int
+	// AnniversaryMilestones leaves the stored list unchanged when nil and
+	// clears it when empty.
+	AnniversaryMilestones []int
+	// SkipWeekends leaves the stored value unchanged when nil.
+	SkipWeekends *bool
+	// ReactionEmoji leaves the stored emoji unchanged when nil and clears it
+	// when empty.
+	ReactionEmoji *string
+}
+
+func (r *WorkspaceRepository) UpdateChannelSettings(ctx context.Context, in UpdateChannelSettingsInput) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 UPDATE workspace_channels
 SET posting_time = $3,
     timezone = $4,
     birthdays_enabled = $5,
     anniversaries_enabled = $6,
+    min_anniversary_tenure_months = COALESCE($7, min_anniversary_tenure_months),
+    post_dispatch_webhook_url = CASE WHEN $8::text IS NULL THEN post_dispatch_webhook_url ELSE NULLIF($8::text, '') END,
+    max_recipients_per_post = COALESCE($9, max_recipients_per_post),
+    anniversary_milestones = CASE WHEN $10::text IS NULL THEN anniversary_milestones ELSE NULLIF($10::text, '') END,
+    skip_weekends = COALESCE($11, skip_weekends),
+    reaction_emoji = CASE WHEN $12::text IS NULL THEN reaction_emoji ELSE NULLIF($12::text, '') END,
     updated_at = NOW()
 WHERE workspace_id = $1
   AND (id::text = $2 OR slack_channel_id = $2)
-RETURNING id, workspace_id, slack_channel_id, slack_channel_name,
-          to_char(posting_time, 'HH24:MI'), timezone,
-          birthdays_enabled, anniversaries_enabled,
-          birthday_template, anniversary_template, COALESCE(branding_emoji, ''),
-          created_at, updated_at
-`
+RETURNING ` + channelColumns
+
+	var minTenure sql.NullInt32
+	if in.MinAnniversaryTenureMonths != nil {
+		minTenure = sql.NullInt32{Int32: int32(*in.MinAnniversaryTenureMonths), Valid: true}
+	}
+
+	var maxRecipients sql.NullInt32
+	if in.MaxRecipientsPerPost != nil {
+		maxRecipients = sql.NullInt32{Int32: int32(*in.MaxRecipientsPerPost), Valid: true}
+	}
+
+	var webhookURL sql.NullString
+	if in.PostDispatchWebhookURL != nil {
+		webhookURL = sql.NullString{String: *in.PostDispatchWebhookURL, Valid: true}
+	}
+
+	var milestones sql.NullString
+	if in.AnniversaryMilestones != nil {
+		milestones = sql.NullString{String: formatMilestones(in.AnniversaryMilestones), Valid: true}
+	}
+
+	var reactionEmoji sql.NullString
+	if in.ReactionEmoji != nil {
+		reactionEmoji = sql.NullString{String: *in.ReactionEmoji, Valid: true}
+	}
 
 	var c domain.WorkspaceChannel
-	if err := r.db.QueryRowContext(ctx, q, workspaceID, channelID, postingTime, timezone, birthdaysEnabled, anniversariesEnabled).Scan(
-		&c.ID,
-		&c.WorkspaceID,
-		&c.SlackChannelID,
-		&c.SlackChannelName,
-		&c.PostingTime,
-		&c.Timezone,
-		&c.BirthdaysEnabled,
-		&c.AnniversariesEnabled,
-		&c.BirthdayTemplate,
-		&c.AnniversaryTemplate,
-		&c.BrandingEmoji,
-		&c.CreatedAt,
-		&c.UpdatedAt,
-	); err != nil {
+	if err := scanChannel(r.db.QueryRowContext(
+		ctx,
+		q,
+		in.WorkspaceID,
+		in.ChannelID,
+		in.PostingTime,
+		in.Timezone,
+		in.BirthdaysEnabled,
+		in.AnniversariesEnabled,
+		minTenure,
+		webhookURL,
+		maxRecipients,
+		milestones,
+		in.SkipWeekends,
+		reactionEmoji,
+	), &c); err != nil {
 		if err == sql.ErrNoRows {
 			return domain.WorkspaceChannel{}, ErrNotFound
 		}
@@ -279,6 +719,9 @@ RETURNING id, workspace_id, slack_channel_id, slack_channel_name,
 }
 
 func (r *WorkspaceRepository) UpdateChannelTemplates(ctx context.Context, workspaceID, channelID, birthdayTemplate, anniversaryTemplate, brandingEmoji string) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
 UPDATE workspace_channels
 SET birthday_template = $3,
@@ -287,29 +730,10 @@ SET birthday_template = $3,
     updated_at = NOW()
 WHERE workspace_id = $1
   AND (id::text = $2 OR slack_channel_id = $2)
-RETURNING id, workspace_id, slack_channel_id, slack_channel_name,
-          to_char(posting_time, 'HH24:MI'), timezone,
-          birthdays_enabled, anniversaries_enabled,
-          birthday_template, anniversary_template, COALESCE(branding_emoji, ''),
-          created_at, updated_at
-`
+RETURNING ` + channelColumns
 
 	var c domain.WorkspaceChannel
-	if err := r.db.QueryRowContext(ctx, q, workspaceID, channelID, birthdayTemplate, anniversaryTemplate, brandingEmoji).Scan(
-		&c.ID,
-		&c.WorkspaceID,
-		&c.SlackChannelID,
-		&c.SlackChannelName,
-		&c.PostingTime,
-		&c.Timezone,
-		&c.BirthdaysEnabled,
-		&c.AnniversariesEnabled,
-		&c.BirthdayTemplate,
-		&c.AnniversaryTemplate,
-		&c.BrandingEmoji,
-		&c.CreatedAt,
-		&c.UpdatedAt,
-	); err != nil {
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, channelID, birthdayTemplate, anniversaryTemplate, brandingEmoji), &c); err != nil {
 		if err == sql.ErrNoRows {
 			return domain.WorkspaceChannel{}, ErrNotFound
 		}
@@ -319,16 +743,87 @@ RETURNING id, workspace_id, slack_channel_id, slack_channel_name,
 	return c, nil
 }
 
+func (r *WorkspaceRepository) PauseChannel(ctx context.Context, workspaceID, channelID string, until time.Time) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspace_channels
+SET paused_until = $3,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND (id::text = $2 OR slack_channel_id = $2)
+RETURNING ` + channelColumns
+
+	var c domain.WorkspaceChannel
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, channelID, until.UTC()), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.WorkspaceChannel{}, ErrNotFound
+		}
+		return domain.WorkspaceChannel{}, fmt.Errorf("pause channel: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r *WorkspaceRepository) UnpauseChannel(ctx context.Context, workspaceID, channelID string) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+UPDATE workspace_channels
+SET paused_until = NULL,
+    updated_at = NOW()
+WHERE workspace_id = $1
+  AND (id::text = $2 OR slack_channel_id = $2)
+RETURNING ` + channelColumns
+
+	var c domain.WorkspaceChannel
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, channelID), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.WorkspaceChannel{}, ErrNotFound
+		}
+		return domain.WorkspaceChannel{}, fmt.Errorf("unpause channel: %w", err)
+	}
+
+	return c, nil
+}
+
 func (r *WorkspaceRepository) ListDueChannels(ctx context.Context, now time.Time) ([]domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
-SELECT wc.id, wc.workspace_id, wc.slack_channel_id, wc.slack_channel_name,
-       to_char(wc.posting_time, 'HH24:MI'), wc.timezone,
-       wc.birthdays_enabled, wc.anniversaries_enabled,
-       wc.birthday_template, wc.anniversary_template, COALESCE(wc.branding_emoji, ''),
-       wc.created_at, wc.updated_at
+SELECT ` + channelColumns + `
 FROM workspace_channels wc
 WHERE EXTRACT(HOUR FROM timezone(wc.timezone, $1)) = EXTRACT(HOUR FROM wc.posting_time)
   AND EXTRACT(MINUTE FROM timezone(wc.timezone, $1)) = EXTRACT(MINUTE FROM wc.posting_time)
+  AND (wc.paused_until IS NULL OR wc.paused_until < $1)
+  AND NOT (wc.skip_weekends AND EXTRACT(ISODOW FROM timezone(wc.timezone, $1)) IN (6, 7))
+  AND NOT EXISTS (
+      SELECT 1
+      FROM celebration_dispatch_log l
+      WHERE l.workspace_channel_id = wc.id
+        AND l.dispatch_date = (timezone(wc.timezone, $1))::date
+  )
+`
+
+	return r.queryDueChannels(ctx, q, now)
+}
+
+// ListSchedulableChannels returns channels that have not been dispatched for
+// their local today and whose posting time is still ahead, so their posts can
+// be handed to Slack to schedule.
+func (r *WorkspaceRepository) ListSchedulableChannels(ctx context.Context, now time.Time) ([]domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT ` + channelColumns + `
+FROM workspace_channels wc
+WHERE (timezone(wc.timezone, $1))::time < wc.posting_time
+  AND (wc.paused_until IS NULL OR wc.paused_until < $1)
+  AND NOT (wc.skip_weekends AND EXTRACT(ISODOW FROM timezone(wc.timezone, $1)) IN (6, 7))
   AND NOT EXISTS (
       SELECT 1
       FROM celebration_dispatch_log l
@@ -337,6 +832,10 @@ WHERE EXTRACT(HOUR FROM timezone(wc.timezone, $1)) = EXTRACT(HOUR FROM wc.postin
   )
 `
 
+	return r.queryDueChannels(ctx, q, now)
+}
+
+func (r *WorkspaceRepository) queryDueChannels(ctx context.Context, q string, now time.Time) ([]domain.WorkspaceChannel, error) {
 	rows, err := r.db.QueryContext(ctx, q, now.UTC())
 	if err != nil {
 		return nil, fmt.Errorf("list due channels: %w", err)
@@ -346,21 +845,7 @@ WHERE EXTRACT(HOUR FROM timezone(wc.timezone, $1)) = EXTRACT(HOUR FROM wc.postin
 	channels := make([]domain.WorkspaceChannel, 0)
 	for rows.Next() {
 		var c domain.WorkspaceChannel
-		if err := rows.Scan(
-			&c.ID,
-			&c.WorkspaceID,
-			&c.SlackChannelID,
-			&c.SlackChannelName,
-			&c.PostingTime,
-			&c.Timezone,
-			&c.BirthdaysEnabled,
-			&c.AnniversariesEnabled,
-			&c.BirthdayTemplate,
-			&c.AnniversaryTemplate,
-			&c.BrandingEmoji,
-			&c.CreatedAt,
-			&c.UpdatedAt,
-		); err != nil {
+		if err := scanChannel(rows, &c); err != nil {
 			return nil, fmt.Errorf("scan due channel: %w", err)
 		}
 		channels = append(channels, c)
@@ -373,16 +858,242 @@ WHERE EXTRACT(HOUR FROM timezone(wc.timezone, $1)) = EXTRACT(HOUR FROM wc.postin
 	return channels, nil
 }
 
-func (r *WorkspaceRepository) MarkChannelDispatched(ctx context.Context, channelID string, dispatchDate time.Time) error {
+type MarkChannelDispatchedInput struct {
+	ChannelID          string
+	DispatchDate       time.Time
+	BirthdayCount      int
+	AnniversaryCount   int
+	BirthdayUserIDs    []string
+	AnniversaryUserIDs []string
+	// MessageTS is the first post's ts, used for the permalink;
+	// MessageTimestamps holds the ts of every batch posted.
+	MessageTS         string
+	MessageTimestamps []string
+	MessageURL        string
+	// ScheduledMessageIDs are the chat.scheduleMessage IDs when the posts were
+	// scheduled rather than sent right away.
+	ScheduledMessageIDs []string
+}
+
+func (r *WorkspaceRepository) MarkChannelDispatched(ctx context.Context, in MarkChannelDispatchedInput) error {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
 	const q = `
-INSERT INTO celebration_dispatch_log (workspace_channel_id, dispatch_date)
-VALUES ($1, $2)
+INSERT INTO celebration_dispatch_log (
+    workspace_channel_id, dispatch_date,
+    birthday_count, anniversary_count,
+    birthday_user_ids, anniversary_user_ids, message_ts, message_url,
+    scheduled_message_ids, message_timestamps
+)
+VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10)
 ON CONFLICT (workspace_channel_id, dispatch_date) DO NOTHING
 `
 
-	if _, err := r.db.ExecContext(ctx, q, channelID, dispatchDate.Format("2006-01-02")); err != nil {
+	birthdayUserIDs := in.BirthdayUserIDs
+	if birthdayUserIDs == nil {
+		birthdayUserIDs = []string{}
+	}
+	anniversaryUserIDs := in.AnniversaryUserIDs
+	if anniversaryUserIDs == nil {
+		anniversaryUserIDs = []string{}
+	}
+	scheduledMessageIDs := in.ScheduledMessageIDs
+	if scheduledMessageIDs == nil {
+		scheduledMessageIDs = []string{}
+	}
+	messageTimestamps := in.MessageTimestamps
+	if messageTimestamps == nil {
+		messageTimestamps = []string{}
+	}
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		q,
+		in.ChannelID,
+		in.DispatchDate.Format("2006-01-02"),
+		in.BirthdayCount,
+		in.AnniversaryCount,
+		birthdayUserIDs,
+		anniversaryUserIDs,
+		in.MessageTS,
+		in.MessageURL,
+		scheduledMessageIDs,
+		messageTimestamps,
+	); err != nil {
 		return fmt.Errorf("mark channel dispatched: %w", err)
 	}
 
 	return nil
 }
+
+// HasChannelDispatched reports whether the channel already has a dispatch log
+// entry for the given local date.
+func (r *WorkspaceRepository) HasChannelDispatched(ctx context.Context, channelID string, date time.Time) (bool, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT EXISTS (
+    SELECT 1 FROM celebration_dispatch_log
+    WHERE workspace_channel_id = $1 AND dispatch_date = $2
+)
+`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, q, channelID, date.Format("2006-01-02")).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check channel dispatch: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *WorkspaceRepository) GetChannel(ctx context.Context, workspaceID, channelID string) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT ` + channelColumns + `
+FROM workspace_channels
+WHERE workspace_id = $1
+  AND (id::text = $2 OR slack_channel_id = $2)
+`
+
+	var c domain.WorkspaceChannel
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, channelID), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.WorkspaceChannel{}, ErrNotFound
+		}
+		return domain.WorkspaceChannel{}, fmt.Errorf("get channel: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r *WorkspaceRepository) FindChannelBySlackID(ctx context.Context, workspaceID, slackChannelID string) (domain.WorkspaceChannel, error) {
+	ctx, cancel := withDBTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	const q = `
+SELECT ` + channelColumns + `
+FROM workspace_channels
+WHERE workspace_id = $1
+  AND slack_channel_id = $2
+`
+
+	var c domain.WorkspaceChannel
+	if err := scanChannel(r.db.QueryRowContext(ctx, q, workspaceID, slackChannelID), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.WorkspaceChannel{}, ErrNotFound
+		}
+		return domain.WorkspaceChannel{}, fmt.Errorf("find channel by slack id: %w", err)
+	}
+
+	return c, nil
+}
+
+const workspaceColumns = `id, slack_team_id, name, timezone, birthdays_enabled, anniversaries_enabled, birthday_year_privacy,
+       COALESCE(announcement_channel_id, ''), COALESCE(onboarding_message_template, ''),
+       created_at, updated_at
+`
+
+type workspaceScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWorkspace(scanner workspaceScanner, w *domain.Workspace) error {
+	return scanner.Scan(
+		&w.ID,
+		&w.SlackTeamID,
+		&w.Name,
+		&w.Timezone,
+		&w.BirthdaysEnabled,
+		&w.AnniversariesEnabled,
+		&w.BirthdayYearPrivacy,
+		&w.AnnouncementChannelID,
+		&w.OnboardingMessageTemplate,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+}
+
+const channelColumns = `id, workspace_id, slack_channel_id, slack_channel_name,
+       to_char(posting_time, 'HH24:MI'), timezone,
+       birthdays_enabled, anniversaries_enabled,
+       birthday_template, anniversary_template, COALESCE(branding_emoji, ''),
+       min_anniversary_tenure_months, COALESCE(post_dispatch_webhook_url, ''),
+       max_recipients_per_post, COALESCE(anniversary_milestones, ''), skip_weekends,
+       COALESCE(reaction_emoji, ''), paused_until,
+       created_at, updated_at
+`
+
+type channelScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanChannel(scanner channelScanner, c *domain.WorkspaceChannel) error {
+	var (
+		milestones  string
+		pausedUntil sql.NullTime
+	)
+	if err := scanner.Scan(
+		&c.ID,
+		&c.WorkspaceID,
+		&c.SlackChannelID,
+		&c.SlackChannelName,
+		&c.PostingTime,
+		&c.Timezone,
+		&c.BirthdaysEnabled,
+		&c.AnniversariesEnabled,
+		&c.BirthdayTemplate,
+		&c.AnniversaryTemplate,
+		&c.BrandingEmoji,
+		&c.MinAnniversaryTenureMonths,
+		&c.PostDispatchWebhookURL,
+		&c.MaxRecipientsPerPost,
+		&milestones,
+		&c.SkipWeekends,
+		&c.ReactionEmoji,
+		&pausedUntil,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	parsed, err := parseMilestones(milestones)
+	if err != nil {
+		return err
+	}
+	c.AnniversaryMilestones = parsed
+
+	c.PausedUntil = nil
+	if pausedUntil.Valid {
+		t := pausedUntil.Time
+		c.PausedUntil = &t
+	}
+	return nil
+}
+
+func formatMilestones(years []int) string {
+	parts := make([]string, 0, len(years))
+	for _, y := range years {
+		parts = append(parts, strconv.Itoa(y))
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseMilestones(raw string) ([]int, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	years := make([]int, 0, len(parts))
+	for _, part := range parts {
+		y, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("parse anniversary milestones %q: %w", raw, err)
+		}
+		years = append(years, y)
+	}
+	return years, nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"slackcheers/internal/config"
+	"slackcheers/internal/database"
+)
+
+var (
+	createTablePattern = regexp.MustCompile(`CREATE TABLE IF NOT EXISTS (\w+) \(`)
+	referencesPattern  = regexp.MustCompile(`REFERENCES (\w+)\(`)
+	deleteFromPattern  = regexp.MustCompile(`^DELETE FROM (\w+) `)
+)
+
+// TestWorkspaceDeleteStatements_CoverEveryChildTable reads the migrations so a
+// new table hanging off a workspace cannot be forgotten by DeleteWorkspace.
+func TestWorkspaceDeleteStatements_CoverEveryChildTable(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "db", "migrations", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("find migrations: %v (%d files)", err, len(files))
+	}
+
+	parents := make(map[string][]string)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		for _, block := range strings.Split(string(content), ";") {
+			table := createTablePattern.FindStringSubmatch(block)
+			if table == nil {
+				continue
+			}
+			for _, ref := range referencesPattern.FindAllStringSubmatch(block, -1) {
+				parents[table[1]] = append(parents[table[1]], ref[1])
+			}
+		}
+	}
+
+	order := make(map[string]int)
+	for i, stmt := range workspaceDeleteStatements {
+		match := deleteFromPattern.FindStringSubmatch(stmt)
+		if match == nil {
+			t.Fatalf("statement %d is not a DELETE: %s", i, stmt)
+		}
+		order[match[1]] = i
+	}
+
+	if order["workspaces"] != len(workspaceDeleteStatements)-1 {
+		t.Fatal("expected workspaces to be deleted last")
+	}
+	for child, refs := range parents {
+		childIndex, ok := order[child]
+		if !ok {
+			t.Fatalf("table %s references %v but is not deleted with the workspace", child, refs)
+		}
+		for _, parent := range refs {
+			if parentIndex, ok := order[parent]; ok && parentIndex < childIndex {
+				t.Fatalf("table %s is deleted after its parent %s", child, parent)
+			}
+		}
+	}
+}
+
+const testQueryTimeout = 10 * time.Second
+
+// openTestDB connects to TEST_DATABASE_URL and applies the migrations, or
+// skips the test when no database is configured.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	db, err := database.OpenPostgres(ctx, config.DBConfig{
+		URL:             url,
+		MaxOpenConns:    2,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := database.UpMigrations(ctx, db, filepath.Join("..", "..", "db", "migrations")); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return db
+}
+
+func TestDeleteChannel_RemovesDispatchLog(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	repo := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := repo.EnsureWorkspace(ctx, fmt.Sprintf("T-delete-channel-%d", time.Now().UnixNano()), "Delete channel test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	channel, err := repo.CreateDefaultChannel(ctx, workspace.ID, "C-delete", "celebrations", "UTC", "09:00")
+	if err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+	if err := repo.MarkChannelDispatched(ctx, MarkChannelDispatchedInput{ChannelID: channel.ID, DispatchDate: time.Now()}); err != nil {
+		t.Fatalf("mark dispatched: %v", err)
+	}
+
+	if err := repo.DeleteChannel(ctx, workspace.ID, "C-delete"); err != nil {
+		t.Fatalf("delete channel: %v", err)
+	}
+
+	var logRows int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM celebration_dispatch_log WHERE workspace_channel_id = $1`, channel.ID).Scan(&logRows); err != nil {
+		t.Fatalf("count dispatch log: %v", err)
+	}
+	if logRows != 0 {
+		t.Fatalf("expected the dispatch log to be purged, found %d rows", logRows)
+	}
+
+	channels, err := repo.ListChannelsByWorkspace(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("list channels: %v", err)
+	}
+	if len(channels) != 0 {
+		t.Fatalf("expected no channels after delete, got %d", len(channels))
+	}
+
+	if err := repo.DeleteChannel(ctx, workspace.ID, channel.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestDeleteDispatchLogEntry_MakesChannelDueAgain(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	repo := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := repo.EnsureWorkspace(ctx, fmt.Sprintf("T-redispatch-%d", time.Now().UnixNano()), "Re-dispatch test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	channel, err := repo.CreateDefaultChannel(ctx, workspace.ID, "C-redispatch", "celebrations", "UTC", "09:00")
+	if err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+
+	now := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	isDue := func() bool {
+		t.Helper()
+		due, err := repo.ListDueChannels(ctx, now)
+		if err != nil {
+			t.Fatalf("list due channels: %v", err)
+		}
+		for _, c := range due {
+			if c.ID == channel.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := repo.MarkChannelDispatched(ctx, MarkChannelDispatchedInput{ChannelID: channel.ID, DispatchDate: now}); err != nil {
+		t.Fatalf("mark dispatched: %v", err)
+	}
+	if isDue() {
+		t.Fatal("expected a dispatched channel not to be due")
+	}
+
+	if err := repo.DeleteDispatchLogEntry(ctx, channel.ID, "2025-06-16"); err != nil {
+		t.Fatalf("delete dispatch log entry: %v", err)
+	}
+	if !isDue() {
+		t.Fatal("expected the channel to be due again after clearing its dispatch log entry")
+	}
+
+	if err := repo.DeleteDispatchLogEntry(ctx, channel.ID, "2025-06-16"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestListAll_CountsPeopleAndChannels(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	repo := NewWorkspaceRepository(db, testQueryTimeout)
+	workspace, err := repo.EnsureWorkspace(ctx, fmt.Sprintf("T-list-all-%d", time.Now().UnixNano()), "List all test", "UTC")
+	if err != nil {
+		t.Fatalf("ensure workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.DeleteWorkspace(context.Background(), workspace.ID) })
+
+	for _, slackChannelID := range []string{"C-list-1", "C-list-2"} {
+		if _, err := repo.CreateDefaultChannel(ctx, workspace.ID, slackChannelID, "celebrations", "UTC", "09:00"); err != nil {
+			t.Fatalf("create channel: %v", err)
+		}
+	}
+	if _, err := NewPeopleRepository(db, testQueryTimeout).Upsert(ctx, UpsertPersonInput{
+		WorkspaceID:   workspace.ID,
+		SlackUserID:   "U-list",
+		RemindersMode: "none",
+	}); err != nil {
+		t.Fatalf("upsert person: %v", err)
+	}
+
+	summaries, total, err := repo.ListAll(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if total < 1 || len(summaries) != total {
+		t.Fatalf("got %d summaries with total %d", len(summaries), total)
+	}
+	for _, s := range summaries {
+		if s.ID != workspace.ID {
+			continue
+		}
+		if s.PeopleCount != 1 || s.ChannelsCount != 2 {
+			t.Fatalf("counts = %d people, %d channels; want 1 and 2", s.PeopleCount, s.ChannelsCount)
+		}
+		return
+	}
+	t.Fatal("workspace missing from the list")
+}
@@ -2,27 +2,142 @@ package scheduler
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
 	"log/slog"
+	"math/big"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/robfig/cron/v3"
+	"slackcheers/internal/metrics"
 	"slackcheers/internal/service"
 )
 
+const idempotencyPurgeInterval = time.Hour
+
 type Scheduler struct {
-	service      *service.CelebrationService
-	pollInterval time.Duration
-	logger       *slog.Logger
+	service        *service.CelebrationService
+	reminderSvc    *service.ReminderService
+	digestSvc      *service.DigestService
+	idempotencySvc *service.IdempotencyService
+	pollInterval   time.Duration
+	jitterMax      time.Duration
+	jitterRange    time.Duration
+	logger         *slog.Logger
+	lastPurge      time.Time
+
+	memberSyncInterval time.Duration
+	// syncAllMembers is the member sync service's SyncAllWorkspaces; nil
+	// disables member sync.
+	syncAllMembers func(ctx context.Context) error
+
+	// cronExpression and schedule are set in cron mode, which replaces the
+	// poll interval ticker.
+	cronExpression string
+	schedule       cron.Schedule
+	now            func() time.Time
+	after          func(time.Duration) <-chan time.Time
+
+	running atomic.Bool
+	paused  atomic.Bool
+	// runTick does one tick's work; it is s.dispatch outside tests.
+	runTick func(ctx context.Context, now time.Time)
 }
 
-func New(service *service.CelebrationService, pollInterval time.Duration, logger *slog.Logger) *Scheduler {
-	return &Scheduler{
-		service:      service,
-		pollInterval: pollInterval,
-		logger:       logger,
+func New(
+	service *service.CelebrationService,
+	reminderSvc *service.ReminderService,
+	digestSvc *service.DigestService,
+	idempotencySvc *service.IdempotencyService,
+	memberSyncSvc *service.SlackMemberSyncService,
+	pollInterval time.Duration,
+	jitterMax time.Duration,
+	jitterRange time.Duration,
+	memberSyncInterval time.Duration,
+	cronExpression string,
+	logger *slog.Logger,
+) (*Scheduler, error) {
+	s := &Scheduler{
+		service:        service,
+		reminderSvc:    reminderSvc,
+		digestSvc:      digestSvc,
+		idempotencySvc: idempotencySvc,
+		pollInterval:   pollInterval,
+		jitterMax:      jitterMax,
+		jitterRange:    jitterRange,
+		logger:         logger,
+		now:            time.Now,
+		after:          time.After,
+
+		memberSyncInterval: memberSyncInterval,
+	}
+	s.runTick = s.dispatch
+	if memberSyncSvc != nil {
+		s.syncAllMembers = memberSyncSvc.SyncAllWorkspaces
 	}
+
+	if expr := strings.TrimSpace(cronExpression); expr != "" {
+		schedule, err := cron.ParseStandard(expr)
+		if err != nil {
+			return nil, fmt.Errorf("parse scheduler cron expression: %w", err)
+		}
+		s.cronExpression = expr
+		s.schedule = schedule
+	}
+	return s, nil
+}
+
+// Pause makes the scheduler skip ticks until Resume. A tick already running
+// finishes.
+func (s *Scheduler) Pause() {
+	s.paused.Store(true)
 }
 
+func (s *Scheduler) Resume() {
+	s.paused.Store(false)
+}
+
+func (s *Scheduler) Paused() bool {
+	return s.paused.Load()
+}
+
+// Running reports whether Run is looping.
+func (s *Scheduler) Running() bool {
+	return s.running.Load()
+}
+
+func (s *Scheduler) PollInterval() time.Duration {
+	return s.pollInterval
+}
+
+// CronExpression is empty when the scheduler polls on an interval.
+func (s *Scheduler) CronExpression() string {
+	return s.cronExpression
+}
+
+// Run polls every pollInterval, or fires at the cron schedule's times when
+// one is configured, until ctx ends.
 func (s *Scheduler) Run(ctx context.Context) {
+	s.running.Store(true)
+	defer s.running.Store(false)
+
+	go s.runMemberSync(ctx)
+
+	if s.schedule != nil {
+		s.runCron(ctx)
+	} else {
+		s.runTicker(ctx)
+	}
+	s.logger.Info("scheduler stopped")
+}
+
+func (s *Scheduler) runTicker(ctx context.Context) {
+	if !s.waitStartupJitter(ctx) {
+		return
+	}
+
 	ticker := time.NewTicker(s.pollInterval)
 	defer ticker.Stop()
 
@@ -30,12 +145,164 @@ func (s *Scheduler) Run(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			s.logger.Info("scheduler stopped")
 			return
 		case now := <-ticker.C:
-			if err := s.service.RunDueCelebrations(ctx, now.UTC()); err != nil {
-				s.logger.Error("scheduler tick failed", slog.String("error", err.Error()))
+			if !s.tick(ctx, now.UTC()) {
+				return
+			}
+		}
+	}
+}
+
+// runCron fires at each time the schedule yields. Times are computed in UTC
+// unless the expression starts with CRON_TZ=.
+func (s *Scheduler) runCron(ctx context.Context) {
+	s.logger.Info("scheduler started", slog.String("cron", s.cronExpression))
+	for {
+		next := s.schedule.Next(s.now().UTC())
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.after(next.Sub(s.now())):
+			if !s.tick(ctx, next.UTC()) {
+				return
 			}
 		}
 	}
 }
+
+// tick runs one round of work unless paused. It reports false when ctx ended
+// while waiting for the tick jitter.
+func (s *Scheduler) tick(ctx context.Context, now time.Time) bool {
+	if s.paused.Load() {
+		s.logger.Debug("scheduler paused, skipping tick")
+		return true
+	}
+	if !s.waitTickJitter(ctx) {
+		return false
+	}
+	s.runTick(ctx, now)
+	return true
+}
+
+func (s *Scheduler) dispatch(ctx context.Context, now time.Time) {
+	start := time.Now()
+	if err := s.service.RunDueCelebrations(ctx, now); err != nil {
+		s.logger.Error("scheduler tick failed", slog.String("error", err.Error()))
+	}
+	if s.reminderSvc != nil {
+		if err := s.reminderSvc.RunDueReminders(ctx, now); err != nil {
+			s.logger.Error("reminder tick failed", slog.String("error", err.Error()))
+		}
+	}
+	if s.digestSvc != nil {
+		if err := s.digestSvc.RunDueDigests(ctx, now); err != nil {
+			s.logger.Error("digest tick failed", slog.String("error", err.Error()))
+		}
+	}
+	s.purgeIdempotencyKeys(ctx, now)
+	metrics.SchedulerTickDuration.Observe(time.Since(start).Seconds())
+}
+
+// waitStartupJitter delays the first tick by a random amount so instances
+// booted together do not all poll at the same second.
+func (s *Scheduler) waitStartupJitter(ctx context.Context) bool {
+	jitter, err := startupJitter(s.jitterMax)
+	if err != nil {
+		s.logger.Warn("scheduler jitter unavailable", slog.String("error", err.Error()))
+		return true
+	}
+	s.logger.Debug("scheduler startup jitter", slog.Duration("jitter", jitter))
+	return sleepContext(ctx, jitter)
+}
+
+// waitTickJitter delays a tick's work by a random amount so instances, and
+// with them channels sharing a posting time, do not all hit Slack at once.
+// The tick time is still what decides which channels are due.
+func (s *Scheduler) waitTickJitter(ctx context.Context) bool {
+	jitter, err := tickJitter(s.jitterRange)
+	if err != nil {
+		s.logger.Warn("scheduler jitter unavailable", slog.String("error", err.Error()))
+		return true
+	}
+	return sleepContext(ctx, jitter)
+}
+
+// sleepContext waits for d and reports false when ctx ends first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func startupJitter(max time.Duration) (time.Duration, error) {
+	if max <= 0 {
+		return 0, nil
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)+1))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n.Int64()), nil
+}
+
+// tickJitter returns a random duration in [0, r).
+func tickJitter(r time.Duration) (time.Duration, error) {
+	if r <= 0 {
+		return 0, nil
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(r)))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n.Int64()), nil
+}
+
+func (s *Scheduler) purgeIdempotencyKeys(ctx context.Context, now time.Time) {
+	if s.idempotencySvc == nil || now.Sub(s.lastPurge) < idempotencyPurgeInterval {
+		return
+	}
+	s.lastPurge = now
+
+	deleted, err := s.idempotencySvc.PurgeExpired(ctx, now)
+	if err != nil {
+		s.logger.Error("idempotency key purge failed", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		s.logger.Info("expired idempotency keys purged", slog.Int64("deleted", deleted))
+	}
+}
+
+// runMemberSync refreshes every connected workspace's people from Slack right
+// away and then once per memberSyncInterval until ctx ends. It runs beside the
+// dispatch loop so a long users.list crawl never delays celebrations.
+func (s *Scheduler) runMemberSync(ctx context.Context) {
+	if s.syncAllMembers == nil || s.memberSyncInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.memberSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.syncAllMembers(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("member sync failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartupJitter_StaysWithinRange(t *testing.T) {
+	max := 30 * time.Second
+	for i := 0; i < 1000; i++ {
+		jitter, err := startupJitter(max)
+		if err != nil {
+			t.Fatalf("startupJitter returned error: %v", err)
+		}
+		if jitter < 0 || jitter > max {
+			t.Fatalf("startupJitter(%s) = %s, want within [0, %s]", max, jitter, max)
+		}
+	}
+}
+
+func TestStartupJitter_DisabledWhenMaxNotPositive(t *testing.T) {
+	for _, max := range []time.Duration{0, -time.Second} {
+		jitter, err := startupJitter(max)
+		if err != nil {
+			t.Fatalf("startupJitter returned error: %v", err)
+		}
+		if jitter != 0 {
+			t.Fatalf("startupJitter(%s) = %s, want 0", max, jitter)
+		}
+	}
+}
+
+func TestTickJitter_StaysBelowRange(t *testing.T) {
+	r := 10 * time.Second
+	for i := 0; i < 1000; i++ {
+		jitter, err := tickJitter(r)
+		if err != nil {
+			t.Fatalf("tickJitter returned error: %v", err)
+		}
+		if jitter < 0 || jitter >= r {
+			t.Fatalf("tickJitter(%s) = %s, want within [0, %s)", r, jitter, r)
+		}
+	}
+}
+
+func TestTickJitter_DisabledWhenRangeNotPositive(t *testing.T) {
+	for _, r := range []time.Duration{0, -time.Second} {
+		jitter, err := tickJitter(r)
+		if err != nil {
+			t.Fatalf("tickJitter returned error: %v", err)
+		}
+		if jitter != 0 {
+			t.Fatalf("tickJitter(%s) = %s, want 0", r, jitter)
+		}
+	}
+}
+
+func TestRun_SkipsTicksWhilePaused(t *testing.T) {
+	var ticks atomic.Int32
+	s, err := New(nil, nil, nil, nil, nil, 5*time.Millisecond, 0, 0, 0, "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	s.runTick = func(context.Context, time.Time) { ticks.Add(1) }
+	s.Pause()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if !s.Running() {
+		t.Fatal("expected the scheduler to report running while paused")
+	}
+	if n := ticks.Load(); n != 0 {
+		t.Fatalf("ran %d ticks while paused, want 0", n)
+	}
+
+	s.Resume()
+	deadline := time.Now().Add(time.Second)
+	for ticks.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a tick to run after resume")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunCron_FiresAtScheduledTimes(t *testing.T) {
+	s, err := New(nil, nil, nil, nil, nil, time.Minute, 0, 0, 0, "0 * * * *", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	// The test clock jumps straight to whatever time the scheduler waits for.
+	var mu sync.Mutex
+	clock := time.Date(2025, time.June, 16, 10, 15, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return clock
+	}
+	s.after = func(d time.Duration) <-chan time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		clock = clock.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- clock
+		return ch
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fired := make(chan time.Time)
+	s.runTick = func(ctx context.Context, now time.Time) {
+		select {
+		case fired <- now:
+		case <-ctx.Done():
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for _, want := range []time.Time{
+		time.Date(2025, time.June, 16, 11, 0, 0, 0, time.UTC),
+		time.Date(2025, time.June, 16, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, time.June, 16, 13, 0, 0, 0, time.UTC),
+	} {
+		select {
+		case got := <-fired:
+			if !got.Equal(want) {
+				t.Fatalf("fired at %s, want %s", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no tick fired for %s", want)
+		}
+	}
+}
+
+func TestNew_RejectsInvalidCron(t *testing.T) {
+	if _, err := New(nil, nil, nil, nil, nil, time.Minute, 0, 0, 0, "every hour", slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("expected an invalid cron expression to be rejected")
+	}
+}
+
+func TestRun_MemberSyncDoesNotBlockTicks(t *testing.T) {
+	var ticks, syncs atomic.Int32
+	s, err := New(nil, nil, nil, nil, nil, 5*time.Millisecond, 0, 0, 10*time.Millisecond, "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	s.runTick = func(context.Context, time.Time) { ticks.Add(1) }
+
+	release := make(chan struct{})
+	s.syncAllMembers = func(ctx context.Context) error {
+		if syncs.Add(1) == 1 {
+			// The first sync hangs like a slow users.list crawl.
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for ticks.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected ticks to keep running during a slow member sync, got %d", ticks.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := syncs.Load(); n != 1 {
+		t.Fatalf("expected the sync to start once at startup, got %d", n)
+	}
+
+	close(release)
+	for syncs.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the member sync to repeat after its interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
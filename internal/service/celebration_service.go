@@ -1,22 +1,54 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"slackcheers/internal/domain"
+	"slackcheers/internal/metrics"
 	"slackcheers/internal/repository"
 	"slackcheers/internal/slack"
 )
 
+// celebrationChannelStore, celebrantStore and celebrationPostLog are the
+// repository methods CelebrationService needs, kept narrow so dispatch runs
+// can be tested without a database.
+type celebrationChannelStore interface {
+	ListDueChannels(ctx context.Context, now time.Time) ([]domain.WorkspaceChannel, error)
+	ListSchedulableChannels(ctx context.Context, now time.Time) ([]domain.WorkspaceChannel, error)
+	ListChannelsByWorkspace(ctx context.Context, workspaceID string) ([]domain.WorkspaceChannel, error)
+	GetChannel(ctx context.Context, workspaceID, channelID string) (domain.WorkspaceChannel, error)
+	HasChannelDispatched(ctx context.Context, channelID string, date time.Time) (bool, error)
+	MarkChannelDispatched(ctx context.Context, in repository.MarkChannelDispatchedInput) error
+}
+
+type celebrantStore interface {
+	FindBirthdaysByWorkspaceAndDate(ctx context.Context, workspaceID string, month, day int) ([]domain.Person, error)
+	FindAnniversariesByWorkspaceAndDate(ctx context.Context, workspaceID string, month, day, year, minTenureMonths int) ([]domain.AnniversaryPerson, error)
+}
+
+type celebrationPostLog interface {
+	Insert(ctx context.Context, in repository.InsertCelebrationPostInput) error
+}
+
 type CelebrationService struct {
-	workspaceRepo *repository.WorkspaceRepository
-	peopleRepo    *repository.PeopleRepository
+	workspaceRepo celebrationChannelStore
+	peopleRepo    celebrantStore
+	postLogRepo   celebrationPostLog
 	slackClient   slack.Client
 	logger        *slog.Logger
+	webhookClient *http.Client
+
+	useScheduledMessages bool
 }
 
 type ManualDispatchResult struct {
@@ -35,20 +67,28 @@ type ManualChannelResult struct {
 	AnniversaryCount  int    `json:"anniversary_count"`
 	BirthdayPosted    bool   `json:"birthday_posted"`
 	AnniversaryPosted bool   `json:"anniversary_posted"`
+	MessageURL        string `json:"message_url,omitempty"`
+	DryRun            bool   `json:"dry_run,omitempty"`
+	PreviewMessage    string `json:"preview_message,omitempty"`
 	Error             string `json:"error,omitempty"`
 }
 
 func NewCelebrationService(
 	workspaceRepo *repository.WorkspaceRepository,
 	peopleRepo *repository.PeopleRepository,
+	postLogRepo *repository.CelebrationPostLogRepository,
 	slackClient slack.Client,
 	logger *slog.Logger,
+	useScheduledMessages bool,
 ) *CelebrationService {
 	return &CelebrationService{
-		workspaceRepo: workspaceRepo,
-		peopleRepo:    peopleRepo,
-		slackClient:   slackClient,
-		logger:        logger,
+		workspaceRepo:        workspaceRepo,
+		peopleRepo:           peopleRepo,
+		postLogRepo:          postLogRepo,
+		slackClient:          slackClient,
+		logger:               logger,
+		webhookClient:        newWebhookClient(dispatchWebhookTimeout),
+		useScheduledMessages: useScheduledMessages,
 	}
 }
 
@@ -58,6 +98,16 @@ func (s *CelebrationService) RunDueCelebrations(ctx context.Context, now time.Ti
 		return err
 	}
 
+	if s.useScheduledMessages {
+		// Channels whose posting time is still ahead get their posts handed to
+		// Slack now; the due list above still covers anything not scheduled.
+		schedulable, err := s.workspaceRepo.ListSchedulableChannels(ctx, now)
+		if err != nil {
+			return err
+		}
+		channels = append(channels, schedulable...)
+	}
+
 	for _, channel := range channels {
 		if err := s.runChannelCelebration(ctx, channel, now); err != nil {
 			s.logger.ErrorContext(ctx, "failed channel celebration run",
@@ -73,11 +123,24 @@ func (s *CelebrationService) RunDueCelebrations(ctx context.Context, now time.Ti
 }
 
 func (s *CelebrationService) runChannelCelebration(ctx context.Context, channel domain.WorkspaceChannel, now time.Time) error {
-	_, err := s.runChannelCelebrationWithResult(ctx, channel, now)
+	_, err := s.runChannelCelebrationWithResult(ctx, channel, now, channelRunOptions{AllowSchedule: true})
 	return err
 }
 
-func (s *CelebrationService) RunWorkspaceNow(ctx context.Context, workspaceID string, now time.Time) (ManualDispatchResult, error) {
+// channelRunOptions controls how runChannelCelebrationWithResult delivers.
+type channelRunOptions struct {
+	// DryRun renders the posts without sending or recording them.
+	DryRun bool
+	// AllowSchedule hands posts whose posting time is still ahead to
+	// chat.scheduleMessage. Only the scheduler sets it; manual runs and
+	// backfills always post right away.
+	AllowSchedule bool
+}
+
+// RunWorkspaceNow dispatches today's celebrations for every channel in the
+// workspace. With dryRun set, messages are rendered but neither posted nor
+// recorded, so the channels stay due for their scheduled run.
+func (s *CelebrationService) RunWorkspaceNow(ctx context.Context, workspaceID string, now time.Time, dryRun bool) (ManualDispatchResult, error) {
 	channels, err := s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
 	if err != nil {
 		return ManualDispatchResult{}, err
@@ -90,12 +153,17 @@ func (s *CelebrationService) RunWorkspaceNow(ctx context.Context, workspaceID st
 	}
 
 	for _, channel := range channels {
-		outcome, err := s.runChannelCelebrationWithResult(ctx, channel, now)
+		outcome, err := s.runChannelCelebrationWithResult(ctx, channel, now, channelRunOptions{DryRun: dryRun})
+		if errors.Is(err, ErrNotConnected) {
+			// Every other channel would fail the same way.
+			return ManualDispatchResult{}, err
+		}
 		if err != nil {
 			result.ChannelsWithErrors++
 			result.ChannelDispatches = append(result.ChannelDispatches, ManualChannelResult{
 				ChannelID:      channel.ID,
 				SlackChannelID: channel.SlackChannelID,
+				DryRun:         dryRun,
 				Error:          err.Error(),
 			})
 			continue
@@ -115,6 +183,9 @@ func (s *CelebrationService) RunWorkspaceNow(ctx context.Context, workspaceID st
 			AnniversaryCount:  outcome.AnniversaryCount,
 			BirthdayPosted:    outcome.BirthdayPosted,
 			AnniversaryPosted: outcome.AnniversaryPosted,
+			MessageURL:        outcome.MessageURL,
+			DryRun:            dryRun,
+			PreviewMessage:    strings.Join(outcome.PreviewMessages, "\n\n"),
 		})
 	}
 
@@ -122,83 +193,692 @@ func (s *CelebrationService) RunWorkspaceNow(ctx context.Context, workspaceID st
 }
 
 type channelRunOutcome struct {
-	BirthdayCount     int
-	AnniversaryCount  int
-	BirthdayPosted    bool
-	AnniversaryPosted bool
+	BirthdayCount      int
+	AnniversaryCount   int
+	BirthdayPosted     bool
+	AnniversaryPosted  bool
+	BirthdayUserIDs    []string
+	AnniversaryUserIDs []string
+	MessageTS          string
+	MessageTimestamps  []string
+	MessageURL         string
+	PreviewMessages    []string
+	SkippedWeekend     bool
+	ScheduledIDs       []string
 }
 
-func (s *CelebrationService) runChannelCelebrationWithResult(ctx context.Context, channel domain.WorkspaceChannel, now time.Time) (channelRunOutcome, error) {
+func (s *CelebrationService) runChannelCelebrationWithResult(ctx context.Context, channel domain.WorkspaceChannel, now time.Time, opts channelRunOptions) (channelRunOutcome, error) {
 	outcome := channelRunOutcome{}
 
 	loc, err := time.LoadLocation(channel.Timezone)
 	if err != nil {
 		return channelRunOutcome{}, fmt.Errorf("invalid channel timezone %q: %w", channel.Timezone, err)
 	}
+	if skipsWeekendDay(channel, now.In(loc)) {
+		outcome.SkippedWeekend = true
+		return outcome, nil
+	}
 
-	localNow := now.In(loc)
-	month := int(localNow.Month())
-	day := localNow.Day()
-	year := localNow.Year()
+	celebrants, err := s.loadCelebrants(ctx, channel, now)
+	if err != nil {
+		return channelRunOutcome{}, err
+	}
+	localNow := celebrants.LocalNow
+
+	if opts.DryRun {
+		outcome.BirthdayCount = len(celebrants.Birthdays)
+		outcome.AnniversaryCount = len(celebrants.Anniversaries)
+		posts := append(birthdayPosts(channel, celebrants.Birthdays, localNow), anniversaryPosts(channel, celebrants.Anniversaries, localNow)...)
+		for _, post := range posts {
+			outcome.PreviewMessages = append(outcome.PreviewMessages, post.Text)
+		}
+		return outcome, nil
+	}
 
-	if channel.BirthdaysEnabled {
-		birthdays, err := s.peopleRepo.FindBirthdaysByWorkspaceAndDate(ctx, channel.WorkspaceID, month, day)
+	// Scheduling only helps while the posting time is still ahead; Slack
+	// rejects a post_at in the past.
+	postAt, scheduled := time.Time{}, false
+	if opts.AllowSchedule && s.useScheduledMessages {
+		postAt, err = channelPostAt(channel, localNow)
 		if err != nil {
 			return channelRunOutcome{}, err
 		}
-		outcome.BirthdayCount = len(birthdays)
-		if len(birthdays) > 0 {
-			message := renderTemplate(channel.BirthdayTemplate, birthdays, nil)
-			message = appendBrandingEmoji(message, channel.BrandingEmoji)
+		scheduled = postAt.Sub(now) >= minScheduleLead
+	}
 
-			if err := s.slackClient.PostMessage(ctx, channel.WorkspaceID, channel.SlackChannelID, message, avatarURLs(birthdays)); err != nil {
-				return channelRunOutcome{}, fmt.Errorf("post birthday message: %w", err)
-			}
-			outcome.BirthdayPosted = true
+	outcome.BirthdayCount = len(celebrants.Birthdays)
+	for _, post := range birthdayPosts(channel, celebrants.Birthdays, localNow) {
+		ts, scheduledID, err := s.deliverCelebration(ctx, channel, "Happy birthday!", post, postAt, scheduled)
+		if err != nil {
+			metrics.CelebrationsFailed.Inc()
+			return channelRunOutcome{}, s.abortChannelRun(ctx, channel, localNow, outcome, fmt.Errorf("post birthday message: %w", err))
+		}
+		metrics.CelebrationsDispatched.WithLabelValues(domain.CelebrationTypeBirthday).Add(float64(len(post.UserIDs)))
+		s.recordPost(ctx, channel, domain.CelebrationTypeBirthday, post, ts)
+		s.addReaction(ctx, channel, ts)
+		outcome.BirthdayPosted = true
+		outcome.BirthdayUserIDs = append(outcome.BirthdayUserIDs, post.UserIDs...)
+		if scheduledID != "" {
+			outcome.ScheduledIDs = append(outcome.ScheduledIDs, scheduledID)
+		}
+		if ts != "" {
+			outcome.MessageTimestamps = append(outcome.MessageTimestamps, ts)
+		}
+		if outcome.MessageTS == "" {
+			outcome.MessageTS = ts
 		}
 	}
 
-	if channel.AnniversariesEnabled {
-		anniversaries, err := s.peopleRepo.FindAnniversariesByWorkspaceAndDate(ctx, channel.WorkspaceID, month, day, year)
+	outcome.AnniversaryCount = len(celebrants.Anniversaries)
+	for _, post := range anniversaryPosts(channel, celebrants.Anniversaries, localNow) {
+		ts, scheduledID, err := s.deliverCelebration(ctx, channel, "Happy work anniversary!", post, postAt, scheduled)
 		if err != nil {
-			return channelRunOutcome{}, err
+			metrics.CelebrationsFailed.Inc()
+			return channelRunOutcome{}, s.abortChannelRun(ctx, channel, localNow, outcome, fmt.Errorf("post anniversary message: %w", err))
+		}
+		metrics.CelebrationsDispatched.WithLabelValues(domain.CelebrationTypeAnniversary).Add(float64(len(post.UserIDs)))
+		s.recordPost(ctx, channel, domain.CelebrationTypeAnniversary, post, ts)
+		s.addReaction(ctx, channel, ts)
+		outcome.AnniversaryPosted = true
+		outcome.AnniversaryUserIDs = append(outcome.AnniversaryUserIDs, post.UserIDs...)
+		if scheduledID != "" {
+			outcome.ScheduledIDs = append(outcome.ScheduledIDs, scheduledID)
 		}
-		outcome.AnniversaryCount = len(anniversaries)
-		if len(anniversaries) > 0 {
-			message := renderAnniversaryTemplate(channel.AnniversaryTemplate, anniversaries)
-			message = appendBrandingEmoji(message, channel.BrandingEmoji)
+		if ts != "" {
+			outcome.MessageTimestamps = append(outcome.MessageTimestamps, ts)
+		}
+		if outcome.MessageTS == "" {
+			outcome.MessageTS = ts
+		}
+	}
 
-			if err := s.slackClient.PostMessage(ctx, channel.WorkspaceID, channel.SlackChannelID, message, avatarURLsFromAnniversaries(anniversaries)); err != nil {
-				return channelRunOutcome{}, fmt.Errorf("post anniversary message: %w", err)
-			}
-			outcome.AnniversaryPosted = true
+	if outcome.MessageTS != "" {
+		// The permalink only makes the log easier to follow; a failure here must
+		// not turn a successful post into a failed dispatch.
+		permalink, err := s.slackClient.GetPermalink(ctx, channel.WorkspaceID, channel.SlackChannelID, outcome.MessageTS)
+		if err != nil {
+			s.logger.WarnContext(ctx, "get message permalink failed",
+				slog.String("channel_id", channel.ID),
+				slog.String("workspace_id", channel.WorkspaceID),
+				slog.String("error", err.Error()),
+			)
 		}
+		outcome.MessageURL = permalink
 	}
 
-	if err := s.workspaceRepo.MarkChannelDispatched(ctx, channel.ID, localNow); err != nil {
+	if err := s.markDispatched(ctx, channel, localNow, outcome); err != nil {
 		return channelRunOutcome{}, err
 	}
 
+	if channel.PostDispatchWebhookURL != "" {
+		if err := s.notifyDispatchWebhook(ctx, channel, localNow, outcome); err != nil {
+			s.logger.WarnContext(ctx, "post-dispatch webhook failed",
+				slog.String("channel_id", channel.ID),
+				slog.String("workspace_id", channel.WorkspaceID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	return outcome, nil
 }
 
-func renderTemplate(template string, people []domain.Person, _ []domain.AnniversaryPerson) string {
-	users := mentionPeople(people)
-	msg := strings.ReplaceAll(template, "{users}", users)
-	msg = strings.ReplaceAll(msg, "{years}", "")
+func (s *CelebrationService) markDispatched(ctx context.Context, channel domain.WorkspaceChannel, localNow time.Time, outcome channelRunOutcome) error {
+	return s.workspaceRepo.MarkChannelDispatched(ctx, repository.MarkChannelDispatchedInput{
+		ChannelID:           channel.ID,
+		DispatchDate:        localNow,
+		BirthdayCount:       outcome.BirthdayCount,
+		AnniversaryCount:    outcome.AnniversaryCount,
+		BirthdayUserIDs:     outcome.BirthdayUserIDs,
+		AnniversaryUserIDs:  outcome.AnniversaryUserIDs,
+		MessageTS:           outcome.MessageTS,
+		MessageTimestamps:   outcome.MessageTimestamps,
+		MessageURL:          outcome.MessageURL,
+		ScheduledMessageIDs: outcome.ScheduledIDs,
+	})
+}
+
+// abortChannelRun handles a post that failed partway through a channel run.
+// When earlier batches already reached Slack the channel is still marked
+// dispatched with what was delivered, so the next tick does not post them
+// again; the remaining celebrants are left out and the error is returned.
+func (s *CelebrationService) abortChannelRun(ctx context.Context, channel domain.WorkspaceChannel, localNow time.Time, outcome channelRunOutcome, runErr error) error {
+	if !outcome.BirthdayPosted && !outcome.AnniversaryPosted {
+		return runErr
+	}
+	if err := s.markDispatched(ctx, channel, localNow, outcome); err != nil {
+		return fmt.Errorf("%w; mark partial dispatch: %v", runErr, err)
+	}
+	return runErr
+}
+
+// minScheduleLead is how far ahead the posting time must be for a post to be
+// scheduled through Slack instead of sent right away.
+const minScheduleLead = time.Minute
+
+// channelPostAt is the channel's posting time on localNow's date.
+func channelPostAt(channel domain.WorkspaceChannel, localNow time.Time) (time.Time, error) {
+	clock, err := time.Parse("15:04", channel.PostingTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid channel posting time %q: %w", channel.PostingTime, err)
+	}
+	return time.Date(localNow.Year(), localNow.Month(), localNow.Day(), clock.Hour(), clock.Minute(), 0, 0, localNow.Location()), nil
+}
+
+func skipsWeekendDay(channel domain.WorkspaceChannel, localNow time.Time) bool {
+	if !channel.SkipWeekends {
+		return false
+	}
+	weekday := localNow.Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// deliverCelebration either schedules the post through Slack for postAt,
+// returning the scheduled message ID, or posts it now and returns its ts.
+func (s *CelebrationService) deliverCelebration(ctx context.Context, channel domain.WorkspaceChannel, title string, post celebrationPost, postAt time.Time, scheduled bool) (string, string, error) {
+	if scheduled {
+		id, err := s.slackClient.ScheduleMessage(ctx, channel.WorkspaceID, channel.SlackChannelID, post.Text, postAt, post.AvatarURLs)
+		return "", id, err
+	}
+	ts, err := s.postCelebration(ctx, channel, title, post)
+	return ts, "", err
+}
+
+// addReaction reacts to a posted celebration with the channel's emoji. The
+// reaction is decoration, so a failure is logged and the dispatch goes on.
+// Scheduled posts have no ts yet and are left alone.
+func (s *CelebrationService) addReaction(ctx context.Context, channel domain.WorkspaceChannel, ts string) {
+	if channel.ReactionEmoji == "" || ts == "" {
+		return
+	}
+	if err := s.slackClient.AddReaction(ctx, channel.WorkspaceID, channel.SlackChannelID, ts, channel.ReactionEmoji); err != nil {
+		s.logger.WarnContext(ctx, "add celebration reaction failed",
+			slog.String("channel_id", channel.ID),
+			slog.String("workspace_id", channel.WorkspaceID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// postCelebration posts a Block Kit layout for the celebration, falling back to
+// the plain text message when the blocks cannot be built.
+func (s *CelebrationService) postCelebration(ctx context.Context, channel domain.WorkspaceChannel, title string, post celebrationPost) (string, error) {
+	blocks, err := celebrationBlocks(headerText(title, channel.BrandingEmoji), post.Text, post.AvatarURLs)
+	if err != nil {
+		s.logger.WarnContext(ctx, "build celebration blocks failed, posting plain text",
+			slog.String("channel_id", channel.ID),
+			slog.String("workspace_id", channel.WorkspaceID),
+			slog.String("error", err.Error()),
+		)
+		return s.slackClient.PostMessage(ctx, channel.WorkspaceID, channel.SlackChannelID, post.Text, post.AvatarURLs)
+	}
+	return s.slackClient.PostMessageBlocks(ctx, channel.WorkspaceID, channel.SlackChannelID, blocks)
+}
+
+// Slack rejects header text over 150 characters, section text over 3000 and
+// context blocks with more than 10 elements.
+const (
+	maxHeaderTextLength  = 150
+	maxSectionTextLength = 3000
+	maxContextElements   = 10
+)
+
+func headerText(title, brandingEmoji string) string {
+	emoji := strings.TrimSpace(brandingEmoji)
+	if emoji == "" {
+		emoji = "🎉"
+	}
+	return emoji + " " + title
+}
+
+// celebrationBlocks builds a header, the mrkdwn message and a context row of
+// up to ten avatar thumbnails.
+func celebrationBlocks(header, text string, avatarURLs []string) ([]map[string]any, error) {
+	header = strings.TrimSpace(header)
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("celebration message is empty")
+	}
+	if utf8.RuneCountInString(header) > maxHeaderTextLength {
+		return nil, fmt.Errorf("header text exceeds %d characters", maxHeaderTextLength)
+	}
+	if utf8.RuneCountInString(text) > maxSectionTextLength {
+		return nil, fmt.Errorf("message text exceeds %d characters", maxSectionTextLength)
+	}
+
+	blocks := make([]map[string]any, 0, 3)
+	if header != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "header",
+			"text": map[string]any{
+				"type":  "plain_text",
+				"text":  header,
+				"emoji": true,
+			},
+		})
+	}
+	blocks = append(blocks, map[string]any{
+		"type": "section",
+		"text": map[string]any{
+			"type": "mrkdwn",
+			"text": text,
+		},
+	})
+
+	images := make([]map[string]any, 0, maxContextElements)
+	for _, avatar := range avatarURLs {
+		avatar = strings.TrimSpace(avatar)
+		if avatar == "" {
+			continue
+		}
+		images = append(images, map[string]any{
+			"type":      "image",
+			"image_url": avatar,
+			"alt_text":  "celebrant_avatar",
+		})
+		if len(images) == maxContextElements {
+			break
+		}
+	}
+	if len(images) > 0 {
+		blocks = append(blocks, map[string]any{
+			"type":     "context",
+			"elements": images,
+		})
+	}
+
+	return blocks, nil
+}
+
+// recordPost adds a posted message to the celebration post log. The message is
+// already in Slack, so a logging failure is only reported.
+func (s *CelebrationService) recordPost(ctx context.Context, channel domain.WorkspaceChannel, celebrationType string, post celebrationPost, ts string) {
+	if err := s.postLogRepo.Insert(ctx, repository.InsertCelebrationPostInput{
+		ChannelID:       channel.ID,
+		CelebrationType: celebrationType,
+		SlackUserIDs:    post.UserIDs,
+		MessageText:     post.Text,
+		SlackMessageTS:  ts,
+	}); err != nil {
+		s.logger.WarnContext(ctx, "record celebration post failed",
+			slog.String("channel_id", channel.ID),
+			slog.String("workspace_id", channel.WorkspaceID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// MaxBackfillDays caps how many calendar days one backfill request may cover.
+const MaxBackfillDays = 90
+
+type BackfillDayResult struct {
+	Date               time.Time
+	ChannelsDispatched int
+	ChannelsSkipped    int
+	BirthdayCount      int
+	AnniversaryCount   int
+	Errors             []string
+}
+
+type BackfillResult struct {
+	WorkspaceID string
+	Days        []BackfillDayResult
+}
+
+// BackfillCelebrations posts the celebrations each channel missed between from
+// and to, inclusive. A channel/day pair is skipped when it is already in the
+// dispatch log, falls on or after the channel's local today or predates the
+// channel. Channels that are currently paused are not posted to.
+func (s *CelebrationService) BackfillCelebrations(ctx context.Context, workspaceID string, from, to, now time.Time) (BackfillResult, error) {
+	days, err := backfillDays(from, to, now)
+	if err != nil {
+		return BackfillResult{}, err
+	}
+
+	channels, err := s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return BackfillResult{}, err
+	}
+
+	result := BackfillResult{WorkspaceID: workspaceID, Days: make([]BackfillDayResult, 0, len(days))}
+	for _, day := range days {
+		dayResult := BackfillDayResult{Date: day, Errors: make([]string, 0)}
+
+		for _, channel := range channels {
+			loc, err := time.LoadLocation(channel.Timezone)
+			if err != nil {
+				dayResult.Errors = append(dayResult.Errors, fmt.Sprintf("channel %s: invalid timezone %q", channel.SlackChannelID, channel.Timezone))
+				continue
+			}
+			localDay := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc)
+			if !backfillEligible(channel, localDay, now) {
+				dayResult.ChannelsSkipped++
+				continue
+			}
+
+			dispatched, err := s.workspaceRepo.HasChannelDispatched(ctx, channel.ID, localDay)
+			if err != nil {
+				dayResult.Errors = append(dayResult.Errors, fmt.Sprintf("channel %s: %v", channel.SlackChannelID, err))
+				continue
+			}
+			if dispatched {
+				dayResult.ChannelsSkipped++
+				continue
+			}
+
+			outcome, err := s.runChannelCelebrationWithResult(ctx, channel, localDay, channelRunOptions{})
+			if err != nil {
+				dayResult.Errors = append(dayResult.Errors, fmt.Sprintf("channel %s: %v", channel.SlackChannelID, err))
+				continue
+			}
+			if outcome.SkippedWeekend {
+				dayResult.ChannelsSkipped++
+				continue
+			}
+			dayResult.ChannelsDispatched++
+			dayResult.BirthdayCount += outcome.BirthdayCount
+			dayResult.AnniversaryCount += outcome.AnniversaryCount
+		}
+
+		result.Days = append(result.Days, dayResult)
+	}
+
+	return result, nil
+}
+
+// backfillDays lists the calendar days from..to, rejecting empty, future or
+// overlong ranges.
+func backfillDays(from, to, now time.Time) ([]time.Time, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidInput)
+	}
+	if to.After(today) {
+		return nil, fmt.Errorf("%w: to must not be in the future", ErrInvalidInput)
+	}
+
+	count := int(to.Sub(from).Hours()/24) + 1
+	if count > MaxBackfillDays {
+		return nil, fmt.Errorf("%w: backfill range is limited to %d days", ErrInvalidInput, MaxBackfillDays)
+	}
+
+	days := make([]time.Time, 0, count)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func backfillEligible(channel domain.WorkspaceChannel, localDay, now time.Time) bool {
+	loc := localDay.Location()
+	if !dateBefore(localDay, now.In(loc)) {
+		return false
+	}
+	if !channel.CreatedAt.IsZero() && dateBefore(localDay, channel.CreatedAt.In(loc)) {
+		return false
+	}
+	if channel.PausedUntil != nil && channel.PausedUntil.After(now) {
+		return false
+	}
+	return true
+}
+
+// dateBefore compares only the calendar dates of a and b.
+func dateBefore(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC).Before(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC))
+}
+
+type ChannelPreviewResult struct {
+	ChannelID        string `json:"channel_id"`
+	SlackChannelID   string `json:"slack_channel_id"`
+	AdminUserID      string `json:"admin_user_id"`
+	PreviewDate      string `json:"preview_date"`
+	BirthdayCount    int    `json:"birthday_count"`
+	AnniversaryCount int    `json:"anniversary_count"`
+	MessagesSent     int    `json:"messages_sent"`
+}
+
+// PreviewEphemeral builds today's celebration posts for the channel and sends
+// them as ephemeral messages visible only to adminUserID. Nothing is recorded
+// as dispatched.
+func (s *CelebrationService) PreviewEphemeral(ctx context.Context, workspaceID, channelID, adminUserID string, now time.Time) (ChannelPreviewResult, error) {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return ChannelPreviewResult{}, err
+	}
+
+	celebrants, err := s.loadCelebrants(ctx, channel, now)
+	if err != nil {
+		return ChannelPreviewResult{}, err
+	}
+
+	result := ChannelPreviewResult{
+		ChannelID:        channel.ID,
+		SlackChannelID:   channel.SlackChannelID,
+		AdminUserID:      adminUserID,
+		PreviewDate:      celebrants.LocalNow.Format("2006-01-02"),
+		BirthdayCount:    len(celebrants.Birthdays),
+		AnniversaryCount: len(celebrants.Anniversaries),
+	}
+
+	posts := append(birthdayPosts(channel, celebrants.Birthdays, celebrants.LocalNow), anniversaryPosts(channel, celebrants.Anniversaries, celebrants.LocalNow)...)
+	if len(posts) == 0 {
+		posts = []celebrationPost{{
+			Text: fmt.Sprintf("Preview: no birthdays or work anniversaries to celebrate in this channel on %s.", result.PreviewDate),
+		}}
+	}
+
+	for _, post := range posts {
+		if err := s.slackClient.PostEphemeral(ctx, channel.WorkspaceID, channel.SlackChannelID, adminUserID, post.Text, post.AvatarURLs); err != nil {
+			return ChannelPreviewResult{}, fmt.Errorf("post preview message: %w", err)
+		}
+		result.MessagesSent++
+	}
+
+	return result, nil
+}
+
+type channelCelebrants struct {
+	LocalNow      time.Time
+	Birthdays     []domain.Person
+	Anniversaries []domain.AnniversaryPerson
+}
+
+func (s *CelebrationService) loadCelebrants(ctx context.Context, channel domain.WorkspaceChannel, now time.Time) (channelCelebrants, error) {
+	loc, err := time.LoadLocation(channel.Timezone)
+	if err != nil {
+		return channelCelebrants{}, fmt.Errorf("invalid channel timezone %q: %w", channel.Timezone, err)
+	}
+
+	out := channelCelebrants{LocalNow: now.In(loc)}
+	month := int(out.LocalNow.Month())
+	day := out.LocalNow.Day()
+	year := out.LocalNow.Year()
+
+	if channel.BirthdaysEnabled {
+		out.Birthdays, err = s.peopleRepo.FindBirthdaysByWorkspaceAndDate(ctx, channel.WorkspaceID, month, day)
+		if err != nil {
+			return channelCelebrants{}, err
+		}
+	}
+
+	if channel.AnniversariesEnabled {
+		anniversaries, err := s.peopleRepo.FindAnniversariesByWorkspaceAndDate(ctx, channel.WorkspaceID, month, day, year, channel.MinAnniversaryTenureMonths)
+		if err != nil {
+			return channelCelebrants{}, err
+		}
+		anniversaries = filterAnniversariesByTenure(anniversaries, out.LocalNow, channel.MinAnniversaryTenureMonths)
+		out.Anniversaries = filterAnniversariesByMilestones(anniversaries, channel.AnniversaryMilestones)
+	}
+
+	return out, nil
+}
+
+type celebrationPost struct {
+	Text       string
+	AvatarURLs []string
+	UserIDs    []string
+}
+
+func birthdayPosts(channel domain.WorkspaceChannel, birthdays []domain.Person, on time.Time) []celebrationPost {
+	template := pickTemplateAlternative(channel.BirthdayTemplate, on)
+	batches := batchRecipients(birthdays, channel.MaxRecipientsPerPost)
+	posts := make([]celebrationPost, 0, len(batches))
+	for i, batch := range batches {
+		message := renderTemplate(template, birthdayTemplateVars(batch))
+		if len(batches) > 1 {
+			message = fmt.Sprintf("🎂 Birthday celebrations (%d/%d): %s", i+1, len(batches), message)
+		}
+		posts = append(posts, celebrationPost{
+			Text:       appendBrandingEmoji(message, channel.BrandingEmoji),
+			AvatarURLs: avatarURLs(batch),
+			UserIDs:    personUserIDs(batch),
+		})
+	}
+	return posts
+}
+
+func anniversaryPosts(channel domain.WorkspaceChannel, anniversaries []domain.AnniversaryPerson, on time.Time) []celebrationPost {
+	template := pickTemplateAlternative(channel.AnniversaryTemplate, on)
+	batches := batchRecipients(anniversaries, channel.MaxRecipientsPerPost)
+	posts := make([]celebrationPost, 0, len(batches))
+	for i, batch := range batches {
+		message := renderTemplate(template, anniversaryTemplateVars(batch))
+		if len(batches) > 1 {
+			message = fmt.Sprintf("🎉 Work anniversaries (%d/%d): %s", i+1, len(batches), message)
+		}
+		posts = append(posts, celebrationPost{
+			Text:       appendBrandingEmoji(message, channel.BrandingEmoji),
+			AvatarURLs: avatarURLsFromAnniversaries(batch),
+			UserIDs:    anniversaryUserIDs(batch),
+		})
+	}
+	return posts
+}
+
+type dispatchWebhookPayload struct {
+	Type             string `json:"type"`
+	WorkspaceID      string `json:"workspace_id"`
+	ChannelID        string `json:"channel_id"`
+	SlackChannelID   string `json:"slack_channel_id"`
+	DispatchDate     string `json:"dispatch_date"`
+	BirthdayCount    int    `json:"birthday_count"`
+	AnniversaryCount int    `json:"anniversary_count"`
+	MessageTS        string `json:"message_ts,omitempty"`
+	MessageURL       string `json:"message_url,omitempty"`
+}
+
+const dispatchWebhookTimeout = 5 * time.Second
+
+// notifyDispatchWebhook posts a dispatch summary to the channel's webhook. The
+// URL is checked again before sending because rows saved before the internal
+// address check was added were never validated.
+func (s *CelebrationService) notifyDispatchWebhook(ctx context.Context, channel domain.WorkspaceChannel, dispatchedAt time.Time, outcome channelRunOutcome) error {
+	if err := validateWebhookURL(channel.PostDispatchWebhookURL); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(dispatchWebhookPayload{
+		Type:             "dispatch",
+		WorkspaceID:      channel.WorkspaceID,
+		ChannelID:        channel.ID,
+		SlackChannelID:   channel.SlackChannelID,
+		DispatchDate:     dispatchedAt.Format("2006-01-02"),
+		BirthdayCount:    outcome.BirthdayCount,
+		AnniversaryCount: outcome.AnniversaryCount,
+		MessageTS:        outcome.MessageTS,
+		MessageURL:       outcome.MessageURL,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.PostDispatchWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func renderTemplate(template string, vars map[string]string) string {
+	// Non-strict rendering cannot fail; unknown tokens are kept as written.
+	msg, _ := RenderTemplate(template, vars)
 	return strings.TrimSpace(msg)
 }
 
-func renderAnniversaryTemplate(template string, anniversaries []domain.AnniversaryPerson) string {
-	mentions := make([]string, 0, len(anniversaries))
-	years := make([]string, 0, len(anniversaries))
+func filterAnniversariesByTenure(anniversaries []domain.AnniversaryPerson, on time.Time, minMonths int) []domain.AnniversaryPerson {
+	if minMonths <= 0 {
+		return anniversaries
+	}
+
+	filtered := make([]domain.AnniversaryPerson, 0, len(anniversaries))
 	for _, a := range anniversaries {
-		mentions = append(mentions, fmt.Sprintf("<@%s>", a.SlackUserID))
-		years = append(years, fmt.Sprintf("%d", a.Years))
+		if a.HireDate != nil && meetsMinAnniversaryTenure(*a.HireDate, on, minMonths) {
+			filtered = append(filtered, a)
+		}
 	}
-	msg := strings.ReplaceAll(template, "{users}", strings.Join(mentions, ", "))
-	msg = strings.ReplaceAll(msg, "{years}", strings.Join(years, ", "))
-	return strings.TrimSpace(msg)
+	return filtered
+}
+
+// filterAnniversariesByMilestones keeps only anniversaries whose year count is
+// one of the channel's milestones. No milestones means every year counts.
+func filterAnniversariesByMilestones(anniversaries []domain.AnniversaryPerson, milestones []int) []domain.AnniversaryPerson {
+	if len(milestones) == 0 {
+		return anniversaries
+	}
+
+	filtered := make([]domain.AnniversaryPerson, 0, len(anniversaries))
+	for _, a := range anniversaries {
+		if slices.Contains(milestones, a.Years) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+func meetsMinAnniversaryTenure(hireDate, on time.Time, minMonths int) bool {
+	months := (on.Year()-hireDate.Year())*12 + int(on.Month()) - int(hireDate.Month())
+	if on.Day() < hireDate.Day() {
+		months--
+	}
+	return months >= minMonths
+}
+
+// batchRecipients splits recipients into posts of at most max people. A max of
+// zero or less keeps everyone in a single post.
+func batchRecipients[T any](recipients []T, max int) [][]T {
+	if len(recipients) == 0 {
+		return nil
+	}
+	if max <= 0 || len(recipients) <= max {
+		return [][]T{recipients}
+	}
+
+	batches := make([][]T, 0, (len(recipients)+max-1)/max)
+	for start := 0; start < len(recipients); start += max {
+		end := start + max
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		batches = append(batches, recipients[start:end])
+	}
+	return batches
 }
 
 func mentionPeople(people []domain.Person) string {
@@ -209,6 +889,22 @@ func mentionPeople(people []domain.Person) string {
 	return strings.Join(mentions, ", ")
 }
 
+func personUserIDs(people []domain.Person) []string {
+	ids := make([]string, 0, len(people))
+	for _, p := range people {
+		ids = append(ids, p.SlackUserID)
+	}
+	return ids
+}
+
+func anniversaryUserIDs(people []domain.AnniversaryPerson) []string {
+	ids := make([]string, 0, len(people))
+	for _, p := range people {
+		ids = append(ids, p.SlackUserID)
+	}
+	return ids
+}
+
 func avatarURLs(people []domain.Person) []string {
 	urls := make([]string, 0, len(people))
 	for _, p := range people {
@@ -0,0 +1,545 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+func TestMeetsMinAnniversaryTenure(t *testing.T) {
+	on := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		hireDate  time.Time
+		minMonths int
+		want      bool
+	}{
+		{
+			name:      "eleven months excluded at twelve month minimum",
+			hireDate:  time.Date(2024, time.July, 15, 0, 0, 0, 0, time.UTC),
+			minMonths: 12,
+			want:      false,
+		},
+		{
+			name:      "twelve months included at twelve month minimum",
+			hireDate:  time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC),
+			minMonths: 12,
+			want:      true,
+		},
+		{
+			name:      "one day short of twelve months excluded",
+			hireDate:  time.Date(2024, time.June, 16, 0, 0, 0, 0, time.UTC),
+			minMonths: 12,
+			want:      false,
+		},
+		{
+			name:      "no minimum",
+			hireDate:  time.Date(2025, time.May, 15, 0, 0, 0, 0, time.UTC),
+			minMonths: 0,
+			want:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := meetsMinAnniversaryTenure(tc.hireDate, on, tc.minMonths)
+			if got != tc.want {
+				t.Fatalf("meetsMinAnniversaryTenure(%s, %s, %d) = %v, want %v", tc.hireDate.Format("2006-01-02"), on.Format("2006-01-02"), tc.minMonths, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterAnniversariesByMilestones_SuppressesNonMilestoneYears(t *testing.T) {
+	anniversaries := []domain.AnniversaryPerson{
+		{Person: domain.Person{SlackUserID: "U1"}, Years: 1},
+		{Person: domain.Person{SlackUserID: "U3"}, Years: 3},
+		{Person: domain.Person{SlackUserID: "U5"}, Years: 5},
+	}
+
+	filtered := filterAnniversariesByMilestones(anniversaries, []int{1, 5, 10})
+	if len(filtered) != 2 || filtered[0].SlackUserID != "U1" || filtered[1].SlackUserID != "U5" {
+		t.Fatalf("expected only the 1 and 5 year anniversaries, got %#v", filtered)
+	}
+
+	if all := filterAnniversariesByMilestones(anniversaries, nil); len(all) != 3 {
+		t.Fatalf("expected every anniversary without milestones, got %d", len(all))
+	}
+}
+
+func TestBatchRecipients_SplitsSevenPeopleIntoBatchesOfThree(t *testing.T) {
+	people := make([]domain.Person, 0, 7)
+	for i := 1; i <= 7; i++ {
+		people = append(people, domain.Person{SlackUserID: fmt.Sprintf("U%d", i)})
+	}
+
+	batches := batchRecipients(people, 3)
+	if len(batches) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(batches))
+	}
+
+	wantSizes := []int{3, 3, 1}
+	for i, batch := range batches {
+		if len(batch) != wantSizes[i] {
+			t.Fatalf("batch %d has %d people, want %d", i+1, len(batch), wantSizes[i])
+		}
+	}
+	if batches[2][0].SlackUserID != "U7" {
+		t.Fatalf("expected last batch to contain U7, got %s", batches[2][0].SlackUserID)
+	}
+}
+
+func TestBatchRecipients_UnlimitedKeepsSinglePost(t *testing.T) {
+	people := make([]domain.Person, 7)
+	if batches := batchRecipients(people, 0); len(batches) != 1 || len(batches[0]) != 7 {
+		t.Fatalf("expected a single batch of 7, got %d batches", len(batches))
+	}
+	if batches := batchRecipients([]domain.Person{}, 3); len(batches) != 0 {
+		t.Fatalf("expected no batches for no recipients, got %d", len(batches))
+	}
+}
+
+func TestCelebrationBlocks_LimitsContextToTenAvatars(t *testing.T) {
+	avatars := make([]string, 0, 12)
+	for i := 1; i <= 12; i++ {
+		avatars = append(avatars, fmt.Sprintf("https://example.com/%d.png", i))
+	}
+
+	blocks, err := celebrationBlocks("🎉 Happy birthday!", "Happy birthday <@U1>!", avatars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected header, section and context blocks, got %d", len(blocks))
+	}
+	if blocks[0]["type"] != "header" || blocks[1]["type"] != "section" || blocks[2]["type"] != "context" {
+		t.Fatalf("unexpected block order: %v", blocks)
+	}
+	if elements := blocks[2]["elements"].([]map[string]any); len(elements) != 10 {
+		t.Fatalf("expected 10 avatar elements, got %d", len(elements))
+	}
+}
+
+func TestCelebrationBlocks_RejectsEmptyText(t *testing.T) {
+	if _, err := celebrationBlocks("🎉 Happy birthday!", "  ", nil); err == nil {
+		t.Fatalf("expected an error for empty text")
+	}
+}
+
+func TestBackfillDays(t *testing.T) {
+	now := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+	days, err := backfillDays(day(time.June, 10), day(time.June, 12), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 3 || !days[0].Equal(day(time.June, 10)) || !days[2].Equal(day(time.June, 12)) {
+		t.Fatalf("unexpected days: %v", days)
+	}
+
+	if _, err := backfillDays(day(time.March, 17), day(time.June, 14), now); err != nil {
+		t.Fatalf("expected a 90 day range to be allowed, got %v", err)
+	}
+
+	invalid := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"from after to", day(time.June, 12), day(time.June, 10)},
+		{"future end", day(time.June, 14), day(time.June, 16)},
+		{"over ninety days", day(time.March, 16), day(time.June, 14)},
+	}
+	for _, tc := range invalid {
+		if _, err := backfillDays(tc.from, tc.to, now); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("%s: expected ErrInvalidInput, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestBackfillEligible(t *testing.T) {
+	now := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.UTC)
+	channel := domain.WorkspaceChannel{CreatedAt: time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)}
+	at := func(d int) time.Time { return time.Date(2025, time.June, d, 12, 0, 0, 0, time.UTC) }
+
+	if !backfillEligible(channel, at(1), now) {
+		t.Fatal("expected the channel's creation day to be eligible")
+	}
+	if backfillEligible(channel, time.Date(2025, time.May, 31, 12, 0, 0, 0, time.UTC), now) {
+		t.Fatal("expected days before the channel existed to be skipped")
+	}
+	if backfillEligible(channel, at(15), now) {
+		t.Fatal("expected today to be left to the scheduler")
+	}
+
+	pausedUntil := now.Add(24 * time.Hour)
+	channel.PausedUntil = &pausedUntil
+	if backfillEligible(channel, at(10), now) {
+		t.Fatal("expected a paused channel to be skipped")
+	}
+}
+
+func TestRunChannelCelebration_SkipsSaturdayWhenSkipWeekends(t *testing.T) {
+	// No repositories or Slack client: reaching them would panic, so a clean
+	// return proves nothing was loaded, posted or marked dispatched.
+	s := &CelebrationService{}
+	channel := domain.WorkspaceChannel{ID: "ch-1", Timezone: "America/New_York", SkipWeekends: true, BirthdaysEnabled: true}
+	saturday := time.Date(2025, time.June, 14, 13, 0, 0, 0, time.UTC)
+
+	outcome, err := s.runChannelCelebrationWithResult(context.Background(), channel, saturday, channelRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !outcome.SkippedWeekend || outcome.BirthdayPosted || outcome.AnniversaryPosted || outcome.MessageTS != "" {
+		t.Fatalf("expected a skipped zero outcome, got %#v", outcome)
+	}
+}
+
+func TestSkipsWeekendDay(t *testing.T) {
+	friday := time.Date(2025, time.June, 13, 9, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.UTC)
+
+	if skipsWeekendDay(domain.WorkspaceChannel{SkipWeekends: true}, friday) {
+		t.Fatal("expected Friday to post")
+	}
+	if !skipsWeekendDay(domain.WorkspaceChannel{SkipWeekends: true}, sunday) {
+		t.Fatal("expected Sunday to be skipped")
+	}
+	if skipsWeekendDay(domain.WorkspaceChannel{}, sunday) {
+		t.Fatal("expected Sunday to post when skip_weekends is off")
+	}
+}
+
+func TestChannelPostAt_UsesChannelLocalPostingTime(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	localNow := time.Date(2025, time.June, 14, 6, 30, 0, 0, loc)
+
+	postAt, err := channelPostAt(domain.WorkspaceChannel{PostingTime: "09:15"}, localNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2025, time.June, 14, 7, 15, 0, 0, time.UTC)
+	if !postAt.Equal(want) {
+		t.Fatalf("channelPostAt() = %s, want %s", postAt.UTC(), want)
+	}
+
+	if _, err := channelPostAt(domain.WorkspaceChannel{PostingTime: "9am"}, localNow); err == nil {
+		t.Fatal("expected an error for an invalid posting time")
+	}
+}
+
+// fakeCelebrationStore serves channels and celebrants from memory and records
+// what a run writes back.
+type fakeCelebrationStore struct {
+	channels      []domain.WorkspaceChannel
+	birthdays     []domain.Person
+	anniversaries []domain.AnniversaryPerson
+	dispatched    []repository.MarkChannelDispatchedInput
+	posts         []repository.InsertCelebrationPostInput
+}
+
+func (f *fakeCelebrationStore) ListDueChannels(context.Context, time.Time) ([]domain.WorkspaceChannel, error) {
+	return f.channels, nil
+}
+
+func (f *fakeCelebrationStore) ListSchedulableChannels(context.Context, time.Time) ([]domain.WorkspaceChannel, error) {
+	return nil, nil
+}
+
+func (f *fakeCelebrationStore) ListChannelsByWorkspace(context.Context, string) ([]domain.WorkspaceChannel, error) {
+	return f.channels, nil
+}
+
+func (f *fakeCelebrationStore) GetChannel(_ context.Context, _, channelID string) (domain.WorkspaceChannel, error) {
+	for _, ch := range f.channels {
+		if ch.ID == channelID {
+			return ch, nil
+		}
+	}
+	return domain.WorkspaceChannel{}, repository.ErrNotFound
+}
+
+func (f *fakeCelebrationStore) HasChannelDispatched(context.Context, string, time.Time) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeCelebrationStore) MarkChannelDispatched(_ context.Context, in repository.MarkChannelDispatchedInput) error {
+	f.dispatched = append(f.dispatched, in)
+	return nil
+}
+
+func (f *fakeCelebrationStore) FindBirthdaysByWorkspaceAndDate(context.Context, string, int, int) ([]domain.Person, error) {
+	return f.birthdays, nil
+}
+
+func (f *fakeCelebrationStore) FindAnniversariesByWorkspaceAndDate(context.Context, string, int, int, int, int) ([]domain.AnniversaryPerson, error) {
+	return f.anniversaries, nil
+}
+
+func (f *fakeCelebrationStore) Insert(_ context.Context, in repository.InsertCelebrationPostInput) error {
+	f.posts = append(f.posts, in)
+	return nil
+}
+
+// fakeSlackClient records posts and scheduled messages. failPostAt makes the
+// nth post (1-based) fail with postErr, or channel_not_found when it is nil.
+type fakeSlackClient struct {
+	slack.Client
+	posted     []string
+	scheduled  []time.Time
+	failPostAt int
+	postErr    error
+}
+
+func (f *fakeSlackClient) post(text string) (string, error) {
+	if f.failPostAt > 0 && len(f.posted)+1 == f.failPostAt {
+		if f.postErr != nil {
+			return "", f.postErr
+		}
+		return "", errors.New("channel_not_found")
+	}
+	f.posted = append(f.posted, text)
+	return fmt.Sprintf("1700000000.%06d", len(f.posted)), nil
+}
+
+func (f *fakeSlackClient) PostMessage(_ context.Context, _, _, text string, _ []string) (string, error) {
+	return f.post(text)
+}
+
+func (f *fakeSlackClient) PostMessageBlocks(_ context.Context, _, _ string, blocks []map[string]any) (string, error) {
+	return f.post(fmt.Sprint(blocks))
+}
+
+func (f *fakeSlackClient) ScheduleMessage(_ context.Context, _, _, _ string, postAt time.Time, _ []string) (string, error) {
+	f.scheduled = append(f.scheduled, postAt)
+	return fmt.Sprintf("Q%d", len(f.scheduled)), nil
+}
+
+func (f *fakeSlackClient) GetPermalink(context.Context, string, string, string) (string, error) {
+	return "https://example.slack.com/archives/C1/p1", nil
+}
+
+func (f *fakeSlackClient) AddReaction(context.Context, string, string, string, string) error {
+	return nil
+}
+
+func newFakeCelebrationService(store *fakeCelebrationStore, slackClient *fakeSlackClient, useScheduledMessages bool) *CelebrationService {
+	return &CelebrationService{
+		workspaceRepo:        store,
+		peopleRepo:           store,
+		postLogRepo:          store,
+		slackClient:          slackClient,
+		logger:               slog.New(slog.NewTextHandler(io.Discard, nil)),
+		useScheduledMessages: useScheduledMessages,
+	}
+}
+
+func birthdayChannel() domain.WorkspaceChannel {
+	return domain.WorkspaceChannel{
+		ID:               "ch-1",
+		WorkspaceID:      "W1",
+		SlackChannelID:   "C1",
+		Timezone:         "UTC",
+		PostingTime:      "09:00",
+		BirthdaysEnabled: true,
+		BirthdayTemplate: "Happy birthday {{mentions}}!",
+	}
+}
+
+func TestRunWorkspaceNow_PostsImmediatelyBeforePostingTime(t *testing.T) {
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{birthdayChannel()},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	slackClient := &fakeSlackClient{}
+	s := newFakeCelebrationService(store, slackClient, true)
+	beforePostingTime := time.Date(2025, time.June, 12, 7, 0, 0, 0, time.UTC)
+
+	result, err := s.RunWorkspaceNow(context.Background(), "W1", beforePostingTime, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(slackClient.scheduled) != 0 || len(slackClient.posted) != 1 {
+		t.Fatalf("expected one immediate post and nothing scheduled, got %d posted and %d scheduled", len(slackClient.posted), len(slackClient.scheduled))
+	}
+	if result.BirthdayPosts != 1 || len(store.dispatched) != 1 || store.dispatched[0].MessageTS == "" {
+		t.Fatalf("expected the post to be recorded with its ts, got %+v and %+v", result, store.dispatched)
+	}
+}
+
+func TestRunWorkspaceNow_DryRunNeitherPostsNorMarksDispatched(t *testing.T) {
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{birthdayChannel()},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	slackClient := &fakeSlackClient{}
+	s := newFakeCelebrationService(store, slackClient, false)
+
+	result, err := s.RunWorkspaceNow(context.Background(), "W1", time.Date(2025, time.June, 12, 9, 5, 0, 0, time.UTC), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(slackClient.posted) != 0 || len(slackClient.scheduled) != 0 {
+		t.Fatalf("expected a dry run not to post, got %d posted and %d scheduled", len(slackClient.posted), len(slackClient.scheduled))
+	}
+	if len(store.dispatched) != 0 {
+		t.Fatalf("expected a dry run not to mark the channel dispatched, got %+v", store.dispatched)
+	}
+	if len(result.ChannelDispatches) != 1 || !result.ChannelDispatches[0].DryRun || result.ChannelDispatches[0].PreviewMessage == "" {
+		t.Fatalf("expected a rendered preview for the channel, got %+v", result.ChannelDispatches)
+	}
+}
+
+func TestRunWorkspaceNow_MissingBotTokenReturnsNotConnected(t *testing.T) {
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{birthdayChannel(), birthdayChannel()},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	slackClient := &fakeSlackClient{failPostAt: 1, postErr: fmt.Errorf("%w: no bot token for workspace %q", slack.ErrNotConnected, "W1")}
+	s := newFakeCelebrationService(store, slackClient, false)
+
+	_, err := s.RunWorkspaceNow(context.Background(), "W1", time.Date(2025, time.June, 12, 9, 5, 0, 0, time.UTC), false)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("expected ErrNotConnected, got %v", err)
+	}
+	if len(store.dispatched) != 0 {
+		t.Fatalf("expected nothing to be marked dispatched, got %+v", store.dispatched)
+	}
+}
+
+func TestRunDueCelebrations_SchedulesBeforePostingTime(t *testing.T) {
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{birthdayChannel()},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	slackClient := &fakeSlackClient{}
+	s := newFakeCelebrationService(store, slackClient, true)
+
+	if err := s.RunDueCelebrations(context.Background(), time.Date(2025, time.June, 12, 7, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2025, time.June, 12, 9, 0, 0, 0, time.UTC)
+	if len(slackClient.posted) != 0 || len(slackClient.scheduled) != 1 || !slackClient.scheduled[0].Equal(want) {
+		t.Fatalf("expected one post scheduled for %s, got posted=%d scheduled=%v", want, len(slackClient.posted), slackClient.scheduled)
+	}
+}
+
+func TestBackfillCelebrations_PostsPastDaysImmediately(t *testing.T) {
+	channel := birthdayChannel()
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{channel},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	slackClient := &fakeSlackClient{}
+	s := newFakeCelebrationService(store, slackClient, true)
+	now := time.Date(2025, time.June, 12, 7, 0, 0, 0, time.UTC)
+	day := time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	result, err := s.BackfillCelebrations(context.Background(), "W1", day, day, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(slackClient.scheduled) != 0 || len(slackClient.posted) != 1 {
+		t.Fatalf("expected a backfilled day to post right away, got %d posted and %d scheduled", len(slackClient.posted), len(slackClient.scheduled))
+	}
+	if len(result.Days) != 1 || result.Days[0].ChannelsDispatched != 1 {
+		t.Fatalf("unexpected backfill result: %+v", result)
+	}
+}
+
+func TestRunChannelCelebration_MarksDeliveredBatchesWhenALaterBatchFails(t *testing.T) {
+	channel := birthdayChannel()
+	channel.MaxRecipientsPerPost = 1
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{channel},
+		birthdays: []domain.Person{{SlackUserID: "U1"}, {SlackUserID: "U2"}, {SlackUserID: "U3"}},
+	}
+	slackClient := &fakeSlackClient{failPostAt: 2}
+	s := newFakeCelebrationService(store, slackClient, false)
+	now := time.Date(2025, time.June, 12, 9, 5, 0, 0, time.UTC)
+
+	if _, err := s.runChannelCelebrationWithResult(context.Background(), channel, now, channelRunOptions{}); err == nil {
+		t.Fatal("expected the failed batch to be reported")
+	}
+
+	if len(slackClient.posted) != 1 {
+		t.Fatalf("expected only the first batch to be posted, got %d", len(slackClient.posted))
+	}
+	if len(store.dispatched) != 1 {
+		t.Fatalf("expected the channel to be marked dispatched once, got %d", len(store.dispatched))
+	}
+	got := store.dispatched[0]
+	if len(got.BirthdayUserIDs) != 1 || got.BirthdayUserIDs[0] != "U1" || got.MessageTS == "" {
+		t.Fatalf("expected the delivered batch to be recorded, got %+v", got)
+	}
+}
+
+func TestRunChannelCelebration_RecordsEveryBatchTS(t *testing.T) {
+	channel := birthdayChannel()
+	channel.MaxRecipientsPerPost = 1
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{channel},
+		birthdays: []domain.Person{{SlackUserID: "U1"}, {SlackUserID: "U2"}, {SlackUserID: "U3"}},
+	}
+	s := newFakeCelebrationService(store, &fakeSlackClient{}, false)
+	now := time.Date(2025, time.June, 12, 9, 5, 0, 0, time.UTC)
+
+	if _, err := s.runChannelCelebrationWithResult(context.Background(), channel, now, channelRunOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(store.dispatched) != 1 {
+		t.Fatalf("expected the channel to be marked dispatched once, got %d", len(store.dispatched))
+	}
+	got := store.dispatched[0]
+	want := []string{"1700000000.000001", "1700000000.000002", "1700000000.000003"}
+	if !slices.Equal(got.MessageTimestamps, want) || got.MessageTS != want[0] {
+		t.Fatalf("expected every batch ts to be recorded, got %q (first %q)", got.MessageTimestamps, got.MessageTS)
+	}
+}
+
+func TestRunChannelCelebration_FirstPostFailureLeavesChannelDue(t *testing.T) {
+	channel := birthdayChannel()
+	store := &fakeCelebrationStore{
+		channels:  []domain.WorkspaceChannel{channel},
+		birthdays: []domain.Person{{SlackUserID: "U1"}},
+	}
+	s := newFakeCelebrationService(store, &fakeSlackClient{failPostAt: 1}, false)
+
+	if _, err := s.runChannelCelebrationWithResult(context.Background(), channel, time.Date(2025, time.June, 12, 9, 5, 0, 0, time.UTC), channelRunOptions{}); err == nil {
+		t.Fatal("expected the failed post to be reported")
+	}
+	if len(store.dispatched) != 0 {
+		t.Fatalf("expected nothing to be marked dispatched, got %+v", store.dispatched)
+	}
+}
+
+func TestNotifyDispatchWebhook_RefusesInternalAddress(t *testing.T) {
+	s := &CelebrationService{webhookClient: &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("expected no request to an internal address")
+		return nil, nil
+	})}}
+	channel := domain.WorkspaceChannel{ID: "ch-1", PostDispatchWebhookURL: "https://169.254.169.254/latest"}
+
+	if err := s.notifyDispatchWebhook(context.Background(), channel, time.Now(), channelRunOutcome{}); err == nil {
+		t.Fatal("expected the webhook to be refused")
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+var templateTokenPattern = regexp.MustCompile(`\{([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)\}`)
+
+// templateAlternativeSeparator splits a template into alternatives, one of
+// which is picked per dispatch day.
+const templateAlternativeSeparator = "|||"
+
+type TemplateOptions struct {
+	// Strict makes rendering fail when the template uses a token that has no
+	// value in vars. Otherwise unknown tokens are left as written.
+	Strict bool
+}
+
+// RenderTemplate replaces each {name} token in the template with vars[name].
+// Unknown tokens are left untouched.
+func RenderTemplate(template string, vars map[string]string) (string, error) {
+	return RenderTemplateWithOptions(template, vars, TemplateOptions{})
+}
+
+func RenderTemplateWithOptions(template string, vars map[string]string, opts TemplateOptions) (string, error) {
+	missing := make(map[string]struct{})
+	rendered := templateTokenPattern.ReplaceAllStringFunc(template, func(token string) string {
+		name := token[1 : len(token)-1]
+		value, ok := vars[name]
+		if !ok {
+			missing[name] = struct{}{}
+			return token
+		}
+		return value
+	})
+
+	if opts.Strict && len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, "{"+name+"}")
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("%w: unknown template variables %s", ErrInvalidInput, strings.Join(names, ", "))
+	}
+
+	return rendered, nil
+}
+
+// pickTemplateAlternative returns one of the |||-separated alternatives in
+// template. The choice is seeded from on's date, so every run for the same day,
+// dry runs included, picks the same entry. A template without alternatives is
+// returned unchanged.
+func pickTemplateAlternative(template string, on time.Time) string {
+	alternatives := strings.Split(template, templateAlternativeSeparator)
+	if len(alternatives) == 1 {
+		return template
+	}
+	rng := rand.New(rand.NewPCG(uint64(on.Year()), uint64(on.YearDay())))
+	return strings.TrimSpace(alternatives[rng.IntN(len(alternatives))])
+}
+
+// validateTemplateAlternatives rejects templates with an empty alternative,
+// such as a trailing |||.
+func validateTemplateAlternatives(template string) error {
+	for _, alternative := range strings.Split(template, templateAlternativeSeparator) {
+		if strings.TrimSpace(alternative) == "" {
+			return fmt.Errorf("template alternatives separated by %s cannot be empty", templateAlternativeSeparator)
+		}
+	}
+	return nil
+}
+
+// validateTemplateVariables strictly renders every |||-separated alternative in
+// template, so an unknown token is caught even in an alternative that today's
+// pick would skip.
+func validateTemplateVariables(template string, vars map[string]string) error {
+	alternatives := strings.Split(template, templateAlternativeSeparator)
+	for i, alternative := range alternatives {
+		if _, err := RenderTemplateWithOptions(alternative, vars, TemplateOptions{Strict: true}); err != nil {
+			if len(alternatives) > 1 {
+				return fmt.Errorf("alternative %d: %w", i+1, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func birthdayTemplateVars(people []domain.Person) map[string]string {
+	firstNames := make([]string, 0, len(people))
+	for _, p := range people {
+		firstNames = append(firstNames, firstName(p))
+	}
+
+	return map[string]string{
+		"users":         mentionPeople(people),
+		"first_name":    strings.Join(firstNames, ", "),
+		"years":         "",
+		"years_ordinal": "",
+		"count":         strconv.Itoa(len(people)),
+		"milestone":     "",
+	}
+}
+
+func anniversaryTemplateVars(anniversaries []domain.AnniversaryPerson) map[string]string {
+	mentions := make([]string, 0, len(anniversaries))
+	firstNames := make([]string, 0, len(anniversaries))
+	years := make([]string, 0, len(anniversaries))
+	ordinals := make([]string, 0, len(anniversaries))
+	milestones := make([]string, 0)
+	for _, a := range anniversaries {
+		mentions = append(mentions, fmt.Sprintf("<@%s>", a.SlackUserID))
+		firstNames = append(firstNames, firstName(a.Person))
+		years = append(years, strconv.Itoa(a.Years))
+		ordinals = append(ordinals, strconv.Itoa(a.Years)+ordinalSuffix(a.Years))
+		if isMilestoneYear(a.Years) {
+			milestones = append(milestones, fmt.Sprintf("%d-year", a.Years))
+		}
+	}
+
+	return map[string]string{
+		"users":         strings.Join(mentions, ", "),
+		"first_name":    strings.Join(firstNames, ", "),
+		"years":         strings.Join(years, ", "),
+		"years_ordinal": strings.Join(ordinals, ", "),
+		"count":         strconv.Itoa(len(anniversaries)),
+		"milestone":     strings.Join(milestones, ", "),
+	}
+}
+
+// firstName is the first word of the display name, falling back to the Slack
+// handle and then the user ID.
+func firstName(p domain.Person) string {
+	if fields := strings.Fields(p.DisplayName); len(fields) > 0 {
+		return fields[0]
+	}
+	if handle := strings.TrimSpace(p.SlackHandle); handle != "" {
+		return handle
+	}
+	return p.SlackUserID
+}
+
+// ordinalSuffix returns the English ordinal suffix for n: st, nd, rd or th.
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// isMilestoneYear marks the first anniversary and every fifth one after it.
+func isMilestoneYear(years int) bool {
+	return years == 1 || (years > 0 && years%5 == 0)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+func TestRenderTemplate_ReplacesKnownTokens(t *testing.T) {
+	got, err := RenderTemplate("Happy birthday {users}! ({count}) {custom.team}", map[string]string{
+		"users":       "<@U1>, <@U2>",
+		"count":       "2",
+		"custom.team": "Platform",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "Happy birthday <@U1>, <@U2>! (2) Platform"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderTemplate_KeepsUnknownTokensWhenNotStrict(t *testing.T) {
+	got, err := RenderTemplate("Hi {users} {unknown}", map[string]string{"users": "<@U1>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Hi <@U1> {unknown}" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRenderTemplateWithOptions_StrictRejectsUnknownTokens(t *testing.T) {
+	_, err := RenderTemplateWithOptions("Hi {users} {note} {custom.x}", map[string]string{"users": "<@U1>"}, TemplateOptions{Strict: true})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	if err.Error() != "invalid input: unknown template variables {custom.x}, {note}" {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
+func TestAnniversaryTemplateVars(t *testing.T) {
+	vars := anniversaryTemplateVars([]domain.AnniversaryPerson{
+		{Person: domain.Person{SlackUserID: "U1"}, Years: 5},
+		{Person: domain.Person{SlackUserID: "U2"}, Years: 3},
+	})
+
+	got := renderTemplate("Congrats {users} on {years} years! {milestone}", vars)
+	want := "Congrats <@U1>, <@U2> on 5, 3 years! 5-year"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestBirthdayTemplateVars_BlanksYears(t *testing.T) {
+	vars := birthdayTemplateVars([]domain.Person{{SlackUserID: "U1"}})
+	if got := renderTemplate("Happy birthday {users} {years}", vars); got != "Happy birthday <@U1>" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestFirstName_FallsBackToHandleThenUserID(t *testing.T) {
+	tests := []struct {
+		person domain.Person
+		want   string
+	}{
+		{domain.Person{SlackUserID: "U1", SlackHandle: "alice", DisplayName: "  Alice Smith"}, "Alice"},
+		{domain.Person{SlackUserID: "U2", SlackHandle: "bob", DisplayName: " "}, "bob"},
+		{domain.Person{SlackUserID: "U3"}, "U3"},
+	}
+	for _, tc := range tests {
+		if got := firstName(tc.person); got != tc.want {
+			t.Fatalf("firstName(%+v) = %q, want %q", tc.person, got, tc.want)
+		}
+	}
+}
+
+func TestBirthdayTemplateVars_FirstNameAndCount(t *testing.T) {
+	one := []domain.Person{{SlackUserID: "U1", DisplayName: "Alice Smith"}}
+	three := []domain.Person{
+		{SlackUserID: "U1", DisplayName: "Alice Smith"},
+		{SlackUserID: "U2", SlackHandle: "bob"},
+		{SlackUserID: "U3", DisplayName: "Carol"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		people   []domain.Person
+		want     string
+	}{
+		{"first name only", "Happy birthday {first_name}!", one, "Happy birthday Alice!"},
+		{"count only", "🎂 {count} birthdays today", three, "🎂 3 birthdays today"},
+		{"first name and count", "🎂 {count} birthdays today: {first_name}", three, "🎂 3 birthdays today: Alice, bob, Carol"},
+		{"neither", "Happy birthday {users}!", one, "Happy birthday <@U1>!"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := renderTemplate(tc.template, birthdayTemplateVars(tc.people)); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnniversaryTemplateVars_FirstName(t *testing.T) {
+	vars := anniversaryTemplateVars([]domain.AnniversaryPerson{
+		{Person: domain.Person{SlackUserID: "U1", DisplayName: "Alice Smith"}, Years: 2},
+		{Person: domain.Person{SlackUserID: "U2", DisplayName: "Bob"}, Years: 4},
+	})
+
+	if got := renderTemplate("{count} anniversaries: {first_name}", vars); got != "2 anniversaries: Alice, Bob" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestOrdinalSuffix(t *testing.T) {
+	want := []string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", 6: "6th", 7: "7th", 8: "8th", 9: "9th", 10: "10th",
+		11: "11th", 12: "12th", 13: "13th", 14: "14th", 15: "15th", 16: "16th", 17: "17th", 18: "18th", 19: "19th", 20: "20th",
+		21: "21st", 22: "22nd", 23: "23rd", 24: "24th", 25: "25th",
+	}
+	for n := 1; n <= 25; n++ {
+		if got := strconv.Itoa(n) + ordinalSuffix(n); got != want[n] {
+			t.Fatalf("ordinal of %d = %q, want %q", n, got, want[n])
+		}
+	}
+
+	edges := map[int]string{101: "101st", 111: "111th", 112: "112th", 113: "113th", 122: "122nd"}
+	for n, w := range edges {
+		if got := strconv.Itoa(n) + ordinalSuffix(n); got != w {
+			t.Fatalf("ordinal of %d = %q, want %q", n, got, w)
+		}
+	}
+}
+
+func TestAnniversaryTemplateVars_YearsOrdinalFollowsUsers(t *testing.T) {
+	vars := anniversaryTemplateVars([]domain.AnniversaryPerson{
+		{Person: domain.Person{SlackUserID: "U1"}, Years: 3},
+		{Person: domain.Person{SlackUserID: "U2"}, Years: 11},
+		{Person: domain.Person{SlackUserID: "U3"}, Years: 21},
+	})
+
+	got := renderTemplate("Happy {years_ordinal} work anniversary {users}!", vars)
+	want := "Happy 3rd, 11th, 21st work anniversary <@U1>, <@U2>, <@U3>!"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestPickTemplateAlternative_SameDateSameChoice(t *testing.T) {
+	template := "Happy birthday {users}! 🎂|||Wishing {users} the best birthday! 🎉|||Celebrate {users} today! 🎈"
+	day := time.Date(2025, time.June, 15, 9, 0, 0, 0, time.UTC)
+
+	first := pickTemplateAlternative(template, day)
+	if !slices.Contains(strings.Split(template, "|||"), first) {
+		t.Fatalf("picked %q, which is not one of the alternatives", first)
+	}
+	if again := pickTemplateAlternative(template, day.Add(8*time.Hour)); again != first {
+		t.Fatalf("expected the same alternative later on the same day, got %q and %q", first, again)
+	}
+
+	seen := map[string]struct{}{}
+	for d := 0; d < 60; d++ {
+		seen[pickTemplateAlternative(template, day.AddDate(0, 0, d))] = struct{}{}
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected every alternative to be picked over 60 days, got %d", len(seen))
+	}
+}
+
+func TestPickTemplateAlternative_SingleTemplateUnchanged(t *testing.T) {
+	template := "  Happy birthday {users}!  "
+	if got := pickTemplateAlternative(template, time.Now()); got != template {
+		t.Fatalf("got %q, want the template unchanged", got)
+	}
+}
+
+func TestValidateTemplateAlternatives(t *testing.T) {
+	if err := validateTemplateAlternatives("Happy birthday {users}!|||Hooray {users}!"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, template := range []string{"Happy birthday {users}!|||", "|||Hooray", "A||| |||B"} {
+		if err := validateTemplateAlternatives(template); err == nil {
+			t.Fatalf("expected %q to be rejected", template)
+		}
+	}
+}
+
+func TestValidateTemplateVariables_ChecksEveryAlternative(t *testing.T) {
+	vars := birthdayTemplateVars(nil)
+	if err := validateTemplateVariables("Happy birthday {users}!|||Hooray {first_name}!", vars); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := validateTemplateVariables("Happy birthday {users}!|||Hooray {nickname}!", vars)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "alternative 2") || !strings.Contains(err.Error(), "{nickname}") {
+		t.Fatalf("expected the bad alternative to be named, got %q", err.Error())
+	}
+}
+
+func TestValidateChannelTemplates_RejectsUnknownVariableInAnyAlternative(t *testing.T) {
+	if err := validateChannelTemplates("Happy birthday {users}!", "Congrats {users} on {years} years!|||{years_ordinal} for {first_name}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateChannelTemplates("Happy birthday {users}!|||Hi {typo}", "Congrats {users}!"); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected an unknown birthday variable to be rejected, got %v", err)
+	}
+	if err := validateChannelTemplates("Happy birthday {users}!", "Congrats {users}!|||{yeras} years"); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected an unknown anniversary variable to be rejected, got %v", err)
+	}
+}
@@ -2,116 +2,954 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"slackcheers/internal/domain"
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
 type DashboardService struct {
-	workspaceRepo *repository.WorkspaceRepository
-	peopleRepo    *repository.PeopleRepository
-	httpClient    *http.Client
+	workspaceRepo   *repository.WorkspaceRepository
+	peopleRepo      *repository.PeopleRepository
+	dispatchLogRepo *repository.DispatchLogRepository
+	postLogRepo     *repository.CelebrationPostLogRepository
+	onboardingRepo  *repository.OnboardingRepository
+	slackChannels   *SlackChannelsService
+	slackClient     slack.Client
+	webhookClient   *http.Client
+	logger          *slog.Logger
+
+	forecastMu    sync.Mutex
+	forecastCache map[string]forecastCacheEntry
 }
 
-func NewDashboardService(workspaceRepo *repository.WorkspaceRepository, peopleRepo *repository.PeopleRepository) *DashboardService {
+func NewDashboardService(
+	workspaceRepo *repository.WorkspaceRepository,
+	peopleRepo *repository.PeopleRepository,
+	dispatchLogRepo *repository.DispatchLogRepository,
+	postLogRepo *repository.CelebrationPostLogRepository,
+	onboardingRepo *repository.OnboardingRepository,
+	slackChannels *SlackChannelsService,
+	slackClient slack.Client,
+	logger *slog.Logger,
+) *DashboardService {
 	return &DashboardService{
-		workspaceRepo: workspaceRepo,
-		peopleRepo:    peopleRepo,
-		httpClient: &http.Client{
-			Timeout: 12 * time.Second,
-		},
+		workspaceRepo:   workspaceRepo,
+		peopleRepo:      peopleRepo,
+		dispatchLogRepo: dispatchLogRepo,
+		postLogRepo:     postLogRepo,
+		onboardingRepo:  onboardingRepo,
+		slackChannels:   slackChannels,
+		slackClient:     slackClient,
+		webhookClient:   newWebhookClient(webhookPingTimeout),
+		logger:          logger,
+		forecastCache:   make(map[string]forecastCacheEntry),
+	}
+}
+
+const DefaultPeoplePageSize = 100
+
+// ListPeople returns one page of a workspace's people and the total count.
+// Slack members reach the people table through SlackMemberSyncService, so
+// this reads the database only.
+func (s *DashboardService) ListPeople(ctx context.Context, workspaceID string, limit, offset int) ([]domain.Person, int, error) {
+	if limit <= 0 {
+		limit = DefaultPeoplePageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, limit, offset)
+}
+
+// SearchPeople filters saved people by display name or Slack handle.
+func (s *DashboardService) SearchPeople(ctx context.Context, workspaceID, query string, limit, offset int) ([]domain.Person, int, error) {
+	if limit <= 0 {
+		limit = DefaultPeoplePageSize
 	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.peopleRepo.SearchByWorkspace(ctx, workspaceID, query, limit, offset)
+}
+
+// ListPeopleMissingData pages through saved people lacking the data named by
+// missing.
+func (s *DashboardService) ListPeopleMissingData(ctx context.Context, workspaceID string, missing repository.MissingData, limit, offset int) ([]domain.Person, int, error) {
+	if !missing.Valid() || missing == repository.MissingNone {
+		return nil, 0, fmt.Errorf("%w: missing must be birthday, hire_date or any", ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = DefaultPeoplePageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.peopleRepo.ListByWorkspace(ctx, workspaceID, missing, limit, offset)
 }
 
-func (s *DashboardService) ListPeople(ctx context.Context, workspaceID string) ([]domain.Person, error) {
-	existing, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID)
+// ListChannelPeople returns the people celebrated in a channel. Every channel
+// currently celebrates the whole workspace, so this is the workspace list once
+// the channel is confirmed to exist.
+func (s *DashboardService) ListChannelPeople(ctx context.Context, workspaceID, channelID string, limit, offset int) ([]domain.Person, int, error) {
+	if _, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID); err != nil {
+		return nil, 0, err
+	}
+	return s.ListPeople(ctx, workspaceID, limit, offset)
+}
+
+func (s *DashboardService) UpsertPerson(ctx context.Context, in repository.UpsertPersonInput) (domain.Person, error) {
+	if in.RemindersMode == "" {
+		in.RemindersMode = "same_day"
+	}
+
+	if err := validateBirthday(in.BirthdayDay, in.BirthdayMonth); err != nil {
+		return domain.Person{}, err
+	}
+
+	privacy, err := s.workspaceRepo.GetPrivacySettings(ctx, in.WorkspaceID)
 	if err != nil {
-		return nil, err
+		return domain.Person{}, err
+	}
+	if privacy.BirthdayYearPrivacy == domain.BirthdayYearPrivacyDiscard {
+		in.BirthdayYear = nil
 	}
 
-	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	return s.peopleRepo.Upsert(ctx, in)
+}
+
+// BulkImportResult summarizes a people import. Errors holds one message per
+// skipped row.
+type BulkImportResult struct {
+	Imported int
+	Skipped  int
+	Errors   []string
+}
+
+// BulkImportPeople upserts each row on its own so one bad row does not abort
+// the import. For people who already exist the avatar and an unset reminders
+// mode are kept, and an import never re-enables public celebrations for
+// someone who opted out.
+func (s *DashboardService) BulkImportPeople(ctx context.Context, workspaceID string, rows []repository.UpsertPersonInput) (BulkImportResult, error) {
+	privacy, err := s.workspaceRepo.GetPrivacySettings(ctx, workspaceID)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, repository.ErrNotFound
+		return BulkImportResult{}, err
+	}
+
+	result := BulkImportResult{Errors: make([]string, 0)}
+	skip := func(slackUserID string, err error) {
+		result.Skipped++
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", slackUserID, err))
+	}
+
+	for _, in := range rows {
+		in.WorkspaceID = workspaceID
+		if strings.TrimSpace(in.SlackUserID) == "" {
+			result.Skipped++
+			result.Errors = append(result.Errors, "slack_user_id is required")
+			continue
 		}
+		if err := validateBirthday(in.BirthdayDay, in.BirthdayMonth); err != nil {
+			skip(in.SlackUserID, err)
+			continue
+		}
+
+		existing, err := s.peopleRepo.GetByWorkspaceAndSlackUserID(ctx, workspaceID, in.SlackUserID)
+		switch {
+		case err == nil:
+			in = mergeImportedPerson(in, existing)
+		case errors.Is(err, repository.ErrNotFound):
+		default:
+			skip(in.SlackUserID, err)
+			continue
+		}
+		if privacy.BirthdayYearPrivacy == domain.BirthdayYearPrivacyDiscard {
+			in.BirthdayYear = nil
+		}
+		if in.RemindersMode == "" {
+			in.RemindersMode = "same_day"
+		}
+
+		if _, err := s.peopleRepo.Upsert(ctx, in); err != nil {
+			skip(in.SlackUserID, err)
+			continue
+		}
+		result.Imported++
+	}
+
+	return result, nil
+}
+
+// mergeImportedPerson fills the blanks in an imported row from the stored
+// person, so a CSV that omits a column or leaves a cell empty keeps what is
+// already saved. An import never re-enables public celebrations for someone
+// who opted out.
+func mergeImportedPerson(in repository.UpsertPersonInput, existing domain.Person) repository.UpsertPersonInput {
+	if strings.TrimSpace(in.SlackHandle) == "" {
+		in.SlackHandle = existing.SlackHandle
+	}
+	if strings.TrimSpace(in.DisplayName) == "" {
+		in.DisplayName = existing.DisplayName
+	}
+	if in.AvatarURL == "" {
+		in.AvatarURL = existing.AvatarURL
+	}
+	if in.BirthdayDay == nil && in.BirthdayMonth == nil {
+		in.BirthdayDay = existing.BirthdayDay
+		in.BirthdayMonth = existing.BirthdayMonth
+		in.BirthdayYear = existing.BirthdayYear
+	} else if in.BirthdayYear == nil && sameIntPtr(in.BirthdayDay, existing.BirthdayDay) && sameIntPtr(in.BirthdayMonth, existing.BirthdayMonth) {
+		in.BirthdayYear = existing.BirthdayYear
+	}
+	if in.HireDate == nil {
+		in.HireDate = existing.HireDate
+	}
+	if in.RemindersMode == "" {
+		in.RemindersMode = existing.RemindersMode
+	}
+	if !existing.PublicCelebrationOptIn {
+		in.PublicCelebrationOptIn = false
+	}
+	return in
+}
+
+func sameIntPtr(a, b *int) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// ExportPeople returns every saved person in the workspace.
+func (s *DashboardService) ExportPeople(ctx context.Context, workspaceID string) ([]domain.Person, error) {
+	if _, err := s.workspaceRepo.GetPrivacySettings(ctx, workspaceID); err != nil {
 		return nil, err
 	}
-	if strings.TrimSpace(install.BotToken) == "" {
-		return existing, nil
+	people, _, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 0, 0)
+	return people, err
+}
+
+// WorkspaceImportResult summarizes a workspace archive import. Errors holds
+// one message per skipped person or channel.
+type WorkspaceImportResult struct {
+	PeopleImported   int      `json:"people_imported"`
+	PeopleSkipped    int      `json:"people_skipped"`
+	ChannelsImported int      `json:"channels_imported"`
+	ChannelsSkipped  int      `json:"channels_skipped"`
+	Errors           []string `json:"errors"`
+}
+
+// ImportWorkspace loads an archive from WriteWorkspaceArchive. People go
+// through BulkImportPeople; channels are created or updated by Slack channel
+// ID, then get their settings and templates from the archive.
+func (s *DashboardService) ImportWorkspace(ctx context.Context, workspaceID string, archive WorkspaceArchive) (WorkspaceImportResult, error) {
+	people, err := s.BulkImportPeople(ctx, workspaceID, archive.People)
+	if err != nil {
+		return WorkspaceImportResult{}, err
+	}
+
+	result := WorkspaceImportResult{
+		PeopleImported: people.Imported,
+		PeopleSkipped:  people.Skipped + len(archive.PeopleErrors),
+		Errors:         append(append(make([]string, 0), archive.PeopleErrors...), people.Errors...),
+	}
+	skip := func(slackChannelID string, err error) {
+		result.ChannelsSkipped++
+		result.Errors = append(result.Errors, fmt.Sprintf("channel %s: %v", slackChannelID, err))
+	}
+
+	for _, ch := range archive.Channels {
+		if strings.TrimSpace(ch.SlackChannelID) == "" {
+			skip(ch.ID, errors.New("slack_channel_id is required"))
+			continue
+		}
+		if err := s.importChannel(ctx, workspaceID, ch, archive.Templates); err != nil {
+			skip(ch.SlackChannelID, err)
+			continue
+		}
+		result.ChannelsImported++
+	}
+
+	return result, nil
+}
+
+// importChannel validates the archived settings the same way as
+// UpdateChannelSettings before anything is written, so an archive cannot carry
+// settings the API would refuse. The Slack channel itself is not checked; the
+// archive may come from before the bot joined it.
+func (s *DashboardService) importChannel(ctx context.Context, workspaceID string, ch domain.WorkspaceChannel, templates map[string]ChannelTemplates) error {
+	if ch.Timezone == "" {
+		ch.Timezone = "UTC"
+	}
+	if ch.PostingTime == "" {
+		ch.PostingTime = "09:00"
+	}
+
+	milestones := ch.AnniversaryMilestones
+	if milestones == nil {
+		milestones = []int{}
+	}
+	settings, err := normalizeChannelSettings(repository.UpdateChannelSettingsInput{
+		WorkspaceID:                workspaceID,
+		PostingTime:                ch.PostingTime,
+		Timezone:                   ch.Timezone,
+		BirthdaysEnabled:           ch.BirthdaysEnabled,
+		AnniversariesEnabled:       ch.AnniversariesEnabled,
+		MinAnniversaryTenureMonths: &ch.MinAnniversaryTenureMonths,
+		PostDispatchWebhookURL:     &ch.PostDispatchWebhookURL,
+		MaxRecipientsPerPost:       &ch.MaxRecipientsPerPost,
+		AnniversaryMilestones:      milestones,
+		SkipWeekends:               &ch.SkipWeekends,
+		ReactionEmoji:              &ch.ReactionEmoji,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
 	}
 
-	members, err := s.listWorkspaceMembers(ctx, install.BotToken)
+	tmpl, ok := templates[ch.ID]
+	if !ok {
+		tmpl = ChannelTemplates{
+			BirthdayTemplate:    ch.BirthdayTemplate,
+			AnniversaryTemplate: ch.AnniversaryTemplate,
+			BrandingEmoji:       ch.BrandingEmoji,
+		}
+	}
+	for _, template := range []string{tmpl.BirthdayTemplate, tmpl.AnniversaryTemplate} {
+		if template == "" {
+			continue
+		}
+		if err := validateTemplateAlternatives(template); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	}
+
+	created, err := s.workspaceRepo.CreateDefaultChannel(ctx, workspaceID, ch.SlackChannelID, ch.SlackChannelName, settings.Timezone, settings.PostingTime)
+	if err != nil {
+		return err
+	}
+
+	settings.ChannelID = created.ID
+	if _, err := s.workspaceRepo.UpdateChannelSettings(ctx, settings); err != nil {
+		return err
+	}
+
+	if tmpl.BirthdayTemplate == "" {
+		tmpl.BirthdayTemplate = created.BirthdayTemplate
+	}
+	if tmpl.AnniversaryTemplate == "" {
+		tmpl.AnniversaryTemplate = created.AnniversaryTemplate
+	}
+	_, err = s.workspaceRepo.UpdateChannelTemplates(ctx, workspaceID, created.ID, tmpl.BirthdayTemplate, tmpl.AnniversaryTemplate, tmpl.BrandingEmoji)
+	return err
+}
+
+func (s *DashboardService) FindBirthdayDuplicates(ctx context.Context, workspaceID string) ([]repository.BirthdayGroup, error) {
+	return s.peopleRepo.FindBirthdayDuplicates(ctx, workspaceID)
+}
+
+func (s *DashboardService) ClearBirthday(ctx context.Context, workspaceID, slackUserID string) (domain.Person, error) {
+	return s.peopleRepo.ClearBirthday(ctx, workspaceID, slackUserID)
+}
+
+// DeleteWorkspace permanently removes a workspace and everything stored for
+// it. There is no archive to restore from.
+func (s *DashboardService) DeleteWorkspace(ctx context.Context, workspaceID string) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.DeleteWorkspace(ctx, workspace.ID); err != nil {
+		return err
+	}
+	s.slackClient.InvalidateTokenCache(workspace.ID)
+
+	s.logger.WarnContext(ctx, "workspace deleted",
+		slog.String("workspace_id", workspace.ID),
+		slog.String("workspace_name", workspace.Name),
+	)
+	return nil
+}
+
+func (s *DashboardService) DeletePerson(ctx context.Context, workspaceID, slackUserID string) error {
+	return s.peopleRepo.SoftDeletePerson(ctx, workspaceID, slackUserID)
+}
+
+func (s *DashboardService) RestorePerson(ctx context.Context, workspaceID, slackUserID string) error {
+	return s.peopleRepo.RestorePerson(ctx, workspaceID, slackUserID)
+}
+
+// PersonDataExport is everything stored about one person, for data access
+// requests.
+type PersonDataExport struct {
+	Person             domain.Person
+	OnboardingDMSentAt []time.Time
+}
+
+func (s *DashboardService) ExportPersonData(ctx context.Context, workspaceID, slackUserID string) (PersonDataExport, error) {
+	person, err := s.peopleRepo.GetByWorkspaceAndSlackUserID(ctx, workspaceID, slackUserID)
+	if err != nil {
+		return PersonDataExport{}, err
+	}
+
+	sentAt, err := s.onboardingRepo.GetSentTimestamps(ctx, workspaceID, slackUserID)
+	if err != nil {
+		return PersonDataExport{}, err
+	}
+
+	return PersonDataExport{Person: person, OnboardingDMSentAt: sentAt}, nil
+}
+
+func (s *DashboardService) BirthdaysAcrossWorkspaces(ctx context.Context, month, day int) (map[string][]domain.Person, error) {
+	if !validDayMonth(day, month) {
+		return nil, fmt.Errorf("%w: day %d is invalid for month %d", ErrInvalidInput, day, month)
+	}
+
+	people, err := s.peopleRepo.FindAllBirthdaysByDate(ctx, month, day)
 	if err != nil {
 		return nil, err
 	}
+	return groupPeopleByWorkspace(people), nil
+}
+
+func groupPeopleByWorkspace(people []domain.Person) map[string][]domain.Person {
+	grouped := make(map[string][]domain.Person)
+	for _, p := range people {
+		grouped[p.WorkspaceID] = append(grouped[p.WorkspaceID], p)
+	}
+	return grouped
+}
 
-	return mergePeopleWithWorkspaceMembers(existing, members, workspaceID), nil
+func validateBirthday(day, month *int) error {
+	if day == nil || month == nil {
+		return nil
+	}
+	if !validDayMonth(*day, *month) {
+		return fmt.Errorf("%w: birthday day %d is invalid for month %d", ErrInvalidInput, *day, *month)
+	}
+	return nil
 }
 
-func (s *DashboardService) UpsertPerson(ctx context.Context, in repository.UpsertPersonInput) (domain.Person, error) {
-	if in.RemindersMode == "" {
-		in.RemindersMode = "same_day"
+func (s *DashboardService) GetPrivacySettings(ctx context.Context, workspaceID string) (repository.WorkspacePrivacySettings, error) {
+	return s.workspaceRepo.GetPrivacySettings(ctx, workspaceID)
+}
+
+func (s *DashboardService) UpdatePrivacySettings(ctx context.Context, workspaceID string, in repository.WorkspacePrivacySettings) (repository.WorkspacePrivacySettings, error) {
+	switch in.BirthdayYearPrivacy {
+	case domain.BirthdayYearPrivacyStore, domain.BirthdayYearPrivacyDiscard:
+	default:
+		return repository.WorkspacePrivacySettings{}, fmt.Errorf("%w: birthday_year_privacy must be store|discard", ErrInvalidInput)
 	}
-	return s.peopleRepo.Upsert(ctx, in)
+	return s.workspaceRepo.UpdatePrivacySettings(ctx, workspaceID, in)
+}
+
+func (s *DashboardService) BulkUpdateRemindersMode(ctx context.Context, workspaceID, mode string, userIDs []string) (int, error) {
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return 0, err
+	}
+	return s.peopleRepo.BulkUpdateRemindersMode(ctx, workspaceID, mode, userIDs)
 }
 
 func (s *DashboardService) ListChannels(ctx context.Context, workspaceID string) ([]domain.WorkspaceChannel, error) {
 	return s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
 }
 
+// DeleteChannel removes a configured channel and its dispatch history.
+// Removing the last channel is allowed but logged, since nothing will be
+// posted for the workspace until a channel is added again.
+func (s *DashboardService) DeleteChannel(ctx context.Context, workspaceID, channelID string) error {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.DeleteChannel(ctx, workspaceID, channel.ID); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "channel deleted",
+		slog.String("workspace_id", workspaceID),
+		slog.String("channel_id", channel.ID),
+		slog.String("slack_channel_id", channel.SlackChannelID),
+	)
+
+	remaining, err := s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if len(remaining) == 0 {
+		s.logger.WarnContext(ctx, "workspace has no celebration channels left",
+			slog.String("workspace_id", workspaceID),
+		)
+	}
+	return nil
+}
+
+// ClearDispatchLogEntry forgets that a channel dispatched on the given date so
+// it can be dispatched again the same day.
+func (s *DashboardService) ClearDispatchLogEntry(ctx context.Context, workspaceID, channelID, dispatchDate string) error {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.DeleteDispatchLogEntry(ctx, channel.ID, dispatchDate); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "dispatch log entry cleared",
+		slog.String("workspace_id", workspaceID),
+		slog.String("channel_id", channel.ID),
+		slog.String("dispatch_date", dispatchDate),
+	)
+	return nil
+}
+
+func (s *DashboardService) GetChannel(ctx context.Context, workspaceID, channelID string) (domain.WorkspaceChannel, error) {
+	return s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+}
+
+func (s *DashboardService) ListDispatchLog(ctx context.Context, channelID string, from, to *time.Time) ([]domain.DispatchLogEntry, error) {
+	return s.dispatchLogRepo.List(ctx, channelID, from, to)
+}
+
+func (s *DashboardService) ExportDispatchLogCSV(ctx context.Context, channelID string, from, to *time.Time, w io.Writer) error {
+	return s.dispatchLogRepo.ExportCSV(ctx, channelID, from, to, w)
+}
+
+func (s *DashboardService) ListChannelHistory(ctx context.Context, workspaceID, channelID string, limit, offset int) ([]repository.ChannelDispatchEntry, int, error) {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.dispatchLogRepo.ListByChannel(ctx, channel.ID, limit, offset)
+}
+
+func (s *DashboardService) ListCelebrationHistory(ctx context.Context, workspaceID string, limit, offset int) ([]domain.CelebrationPostLogEntry, int, error) {
+	return s.postLogRepo.ListByWorkspace(ctx, workspaceID, limit, offset)
+}
+
+type UpdateChannelSettingsOptions struct {
+	PingWebhook           bool
+	SkipChannelValidation bool
+}
+
 func (s *DashboardService) UpdateChannelSettings(
 	ctx context.Context,
-	workspaceID, channelID, postingTime, timezone string,
-	birthdaysEnabled, anniversariesEnabled bool,
+	in repository.UpdateChannelSettingsInput,
+	opts UpdateChannelSettingsOptions,
 ) (domain.WorkspaceChannel, error) {
-	if _, err := time.Parse("15:04", postingTime); err != nil {
-		return domain.WorkspaceChannel{}, fmt.Errorf("posting time must use HH:MM format")
+	in, err := normalizeChannelSettings(in)
+	if err != nil {
+		return domain.WorkspaceChannel{}, err
+	}
+
+	if !opts.SkipChannelValidation {
+		if err := s.validateSlackChannel(ctx, in.WorkspaceID, in.ChannelID); err != nil {
+			return domain.WorkspaceChannel{}, err
+		}
 	}
 
-	if _, err := time.LoadLocation(timezone); err != nil {
-		return domain.WorkspaceChannel{}, fmt.Errorf("invalid timezone")
+	if opts.PingWebhook && in.PostDispatchWebhookURL != nil && *in.PostDispatchWebhookURL != "" {
+		if err := s.pingWebhook(ctx, *in.PostDispatchWebhookURL); err != nil {
+			return domain.WorkspaceChannel{}, err
+		}
 	}
 
-	return s.workspaceRepo.UpdateChannelSettings(
-		ctx,
-		workspaceID,
-		channelID,
-		postingTime,
-		timezone,
-		birthdaysEnabled,
-		anniversariesEnabled,
-	)
+	return s.workspaceRepo.UpdateChannelSettings(ctx, in)
+}
+
+// normalizeChannelSettings checks channel settings without contacting Slack or
+// the webhook, returning them in the form they are stored.
+func normalizeChannelSettings(in repository.UpdateChannelSettingsInput) (repository.UpdateChannelSettingsInput, error) {
+	if _, err := time.Parse("15:04", in.PostingTime); err != nil {
+		return in, fmt.Errorf("posting time must use HH:MM format")
+	}
+
+	if _, err := time.LoadLocation(in.Timezone); err != nil {
+		return in, fmt.Errorf("invalid timezone")
+	}
+
+	if in.MinAnniversaryTenureMonths != nil && *in.MinAnniversaryTenureMonths < 0 {
+		return in, fmt.Errorf("min anniversary tenure months must be zero or greater")
+	}
+
+	if in.MaxRecipientsPerPost != nil && *in.MaxRecipientsPerPost < 0 {
+		return in, fmt.Errorf("max recipients per post must be zero or greater")
+	}
+
+	if in.AnniversaryMilestones != nil {
+		milestones, err := normalizeMilestones(in.AnniversaryMilestones)
+		if err != nil {
+			return in, err
+		}
+		in.AnniversaryMilestones = milestones
+	}
+
+	if in.PostDispatchWebhookURL != nil {
+		webhookURL := strings.TrimSpace(*in.PostDispatchWebhookURL)
+		in.PostDispatchWebhookURL = &webhookURL
+		if webhookURL != "" {
+			if err := validateWebhookURL(webhookURL); err != nil {
+				return in, err
+			}
+		}
+	}
+
+	if in.ReactionEmoji != nil {
+		emoji, err := normalizeReactionEmoji(*in.ReactionEmoji)
+		if err != nil {
+			return in, err
+		}
+		in.ReactionEmoji = &emoji
+	}
+
+	return in, nil
+}
+
+var reactionEmojiPattern = regexp.MustCompile(`^[a-z0-9_+'-]+(::skin-tone-[2-6])?$`)
+
+// normalizeReactionEmoji accepts an emoji name with or without the
+// surrounding colons and returns it in the bare form reactions.add expects.
+func normalizeReactionEmoji(raw string) (string, error) {
+	emoji := strings.Trim(strings.TrimSpace(raw), ":")
+	if emoji == "" {
+		return "", nil
+	}
+	if !reactionEmojiPattern.MatchString(emoji) {
+		return "", fmt.Errorf("reaction emoji must be a Slack emoji name such as tada or :tada:")
+	}
+	return emoji, nil
+}
+
+// UpdateAnnouncementChannel sets the workspace-wide channel used for digest
+// posts. An empty channel ID clears it without contacting Slack.
+func (s *DashboardService) UpdateAnnouncementChannel(ctx context.Context, workspaceID, slackChannelID string) error {
+	slackChannelID = strings.TrimSpace(slackChannelID)
+	if slackChannelID != "" {
+		info, err := s.slackChannels.GetChannelInfo(ctx, workspaceID, slackChannelID)
+		if err != nil {
+			return err
+		}
+		if info.IsArchived {
+			return ErrChannelArchived
+		}
+		if !info.IsMember {
+			return ErrBotNotChannelMember
+		}
+	}
+
+	return s.workspaceRepo.UpdateAnnouncementChannel(ctx, workspaceID, slackChannelID)
+}
+
+// validateSlackChannel confirms the configured Slack channel is live and the bot
+// can post to it. Workspaces without a bot token yet are skipped.
+func (s *DashboardService) validateSlackChannel(ctx context.Context, workspaceID, channelID string) error {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return err
+	}
+
+	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if strings.TrimSpace(install.BotToken) == "" {
+		return nil
+	}
+
+	info, err := s.slackChannels.GetChannelInfo(ctx, workspaceID, channel.SlackChannelID)
+	if err != nil {
+		return err
+	}
+	if info.IsArchived {
+		return ErrChannelArchived
+	}
+	if !info.IsMember {
+		return ErrBotNotChannelMember
+	}
+	return nil
+}
+
+const webhookPingTimeout = 3 * time.Second
+
+// normalizeMilestones sorts and de-duplicates anniversary milestone years.
+func normalizeMilestones(years []int) ([]int, error) {
+	out := make([]int, 0, len(years))
+	for _, y := range years {
+		if y < 1 {
+			return nil, fmt.Errorf("anniversary milestones must be positive years")
+		}
+		out = append(out, y)
+	}
+	sort.Ints(out)
+	return slices.Compact(out), nil
+}
+
+// validateWebhookURL accepts only https URLs whose host is not this machine or
+// a private, link-local or otherwise internal address, so a channel webhook
+// cannot be pointed at services behind the firewall.
+func validateWebhookURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("webhook url must use https")
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "" {
+		return fmt.Errorf("webhook url must include a host")
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return fmt.Errorf("webhook url must not point to an internal host")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicAddr(addr) {
+		return fmt.Errorf("webhook url must not point to an internal address")
+	}
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast()
+}
+
+var errWebhookRedirect = errors.New("webhook redirects are not followed")
+
+// newWebhookClient returns the client for channel webhooks. validateWebhookURL
+// only sees the hostname, so the dialer checks every resolved address as well
+// and redirects are refused rather than followed to an unchecked host.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: refuseInternalAddr}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be the only address dialed, leaving the target unchecked.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return errWebhookRedirect
+		},
+	}
+}
+
+// refuseInternalAddr is a net.Dialer Control hook; it runs after DNS
+// resolution with the address about to be dialed.
+func refuseInternalAddr(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublicAddr(addr) {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
+
+func (s *DashboardService) pingWebhook(ctx context.Context, webhookURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookPingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, strings.NewReader(`{"type":"ping"}`))
+	if err != nil {
+		return fmt.Errorf("build webhook ping request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook url is not reachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook ping returned status %d", resp.StatusCode)
+	}
+	return nil
 }
 
 func (s *DashboardService) UpdateChannelTemplates(
 	ctx context.Context,
 	workspaceID, channelID, birthdayTemplate, anniversaryTemplate, brandingEmoji string,
 ) (domain.WorkspaceChannel, error) {
-	if birthdayTemplate == "" || anniversaryTemplate == "" {
-		return domain.WorkspaceChannel{}, fmt.Errorf("templates cannot be empty")
+	if err := validateChannelTemplates(birthdayTemplate, anniversaryTemplate); err != nil {
+		return domain.WorkspaceChannel{}, err
 	}
 
 	return s.workspaceRepo.UpdateChannelTemplates(ctx, workspaceID, channelID, birthdayTemplate, anniversaryTemplate, brandingEmoji)
 }
 
+func validateChannelTemplates(birthdayTemplate, anniversaryTemplate string) error {
+	if birthdayTemplate == "" || anniversaryTemplate == "" {
+		return fmt.Errorf("templates cannot be empty")
+	}
+	for _, template := range []string{birthdayTemplate, anniversaryTemplate} {
+		if err := validateTemplateAlternatives(template); err != nil {
+			return err
+		}
+	}
+	if err := validateTemplateVariables(birthdayTemplate, birthdayTemplateVars(nil)); err != nil {
+		return err
+	}
+	return validateTemplateVariables(anniversaryTemplate, anniversaryTemplateVars(nil))
+}
+
+type PreviewTemplateInput struct {
+	BirthdayTemplate    string
+	AnniversaryTemplate string
+	BrandingEmoji       string
+	SlackUserID         string
+}
+
+type TemplatePreview struct {
+	BirthdayPreview    string
+	AnniversaryPreview string
+	SampleUserID       string
+}
+
+// PreviewCelebrationMessage renders channel templates for a sample celebrant
+// without posting anything. Empty fields fall back to the channel's saved
+// templates and emoji. Unknown template variables in any alternative are
+// rejected.
+func (s *DashboardService) PreviewCelebrationMessage(ctx context.Context, workspaceID, channelID string, in PreviewTemplateInput, now time.Time) (TemplatePreview, error) {
+	channel, err := s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		return TemplatePreview{}, err
+	}
+
+	sample, err := s.samplePerson(ctx, workspaceID, strings.TrimSpace(in.SlackUserID))
+	if err != nil {
+		return TemplatePreview{}, err
+	}
+
+	birthdayTemplate := fallbackString(in.BirthdayTemplate, channel.BirthdayTemplate)
+	anniversaryTemplate := fallbackString(in.AnniversaryTemplate, channel.AnniversaryTemplate)
+	emoji := fallbackString(in.BrandingEmoji, channel.BrandingEmoji)
+
+	birthdayVars := birthdayTemplateVars([]domain.Person{sample})
+	anniversaryVars := anniversaryTemplateVars([]domain.AnniversaryPerson{sampleAnniversary(sample, now)})
+	if err := validateTemplateVariables(birthdayTemplate, birthdayVars); err != nil {
+		return TemplatePreview{}, err
+	}
+	if err := validateTemplateVariables(anniversaryTemplate, anniversaryVars); err != nil {
+		return TemplatePreview{}, err
+	}
+
+	birthday, err := RenderTemplate(pickTemplateAlternative(birthdayTemplate, now), birthdayVars)
+	if err != nil {
+		return TemplatePreview{}, err
+	}
+	anniversary, err := RenderTemplate(pickTemplateAlternative(anniversaryTemplate, now), anniversaryVars)
+	if err != nil {
+		return TemplatePreview{}, err
+	}
+
+	return TemplatePreview{
+		BirthdayPreview:    appendBrandingEmoji(strings.TrimSpace(birthday), emoji),
+		AnniversaryPreview: appendBrandingEmoji(strings.TrimSpace(anniversary), emoji),
+		SampleUserID:       sample.SlackUserID,
+	}, nil
+}
+
+// samplePerson returns the requested person, or the first saved person in the
+// workspace, or a placeholder when nobody is saved yet.
+func (s *DashboardService) samplePerson(ctx context.Context, workspaceID, slackUserID string) (domain.Person, error) {
+	if slackUserID != "" {
+		return s.peopleRepo.GetByWorkspaceAndSlackUserID(ctx, workspaceID, slackUserID)
+	}
+
+	people, _, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 1, 0)
+	if err != nil {
+		return domain.Person{}, err
+	}
+	if len(people) == 0 {
+		return domain.Person{WorkspaceID: workspaceID, SlackUserID: "USAMPLE", DisplayName: "Sample Person"}, nil
+	}
+	return people[0], nil
+}
+
+func sampleAnniversary(p domain.Person, now time.Time) domain.AnniversaryPerson {
+	years := 1
+	if p.HireDate != nil && now.Year()-p.HireDate.Year() > 0 {
+		years = now.Year() - p.HireDate.Year()
+	}
+	return domain.AnniversaryPerson{Person: p, Years: years}
+}
+
+const maxChannelPause = 90 * 24 * time.Hour
+
+func (s *DashboardService) PauseChannel(ctx context.Context, workspaceID, channelID string, until, now time.Time) (domain.WorkspaceChannel, error) {
+	if err := validatePauseUntil(until, now); err != nil {
+		return domain.WorkspaceChannel{}, err
+	}
+	return s.workspaceRepo.PauseChannel(ctx, workspaceID, channelID, until)
+}
+
+func (s *DashboardService) UnpauseChannel(ctx context.Context, workspaceID, channelID string) (domain.WorkspaceChannel, error) {
+	return s.workspaceRepo.UnpauseChannel(ctx, workspaceID, channelID)
+}
+
+func validatePauseUntil(until, now time.Time) error {
+	if !until.After(now) {
+		return fmt.Errorf("%w: until must be in the future", ErrInvalidInput)
+	}
+	if until.Sub(now) > maxChannelPause {
+		return fmt.Errorf("%w: until must be at most 90 days in the future", ErrInvalidInput)
+	}
+	return nil
+}
+
 func (s *DashboardService) Overview(ctx context.Context, workspaceID string, days int, celebrationType string) ([]domain.UpcomingCelebration, error) {
 	if days <= 0 {
 		days = 30
 	}
 
-	people, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID)
+	people, _, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 0, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now().UTC().Truncate(24 * time.Hour)
+	return upcomingCelebrations(people, time.Now().UTC().Truncate(24*time.Hour), days, celebrationType), nil
+}
+
+// upcomingCelebrations lists the birthdays and work anniversaries falling
+// within days of now, soonest first. celebrationType is "all", "birthdays"
+// or "anniversaries".
+func upcomingCelebrations(people []domain.Person, now time.Time, days int, celebrationType string) []domain.UpcomingCelebration {
 	end := now.AddDate(0, 0, days)
 
 	items := make([]domain.UpcomingCelebration, 0)
@@ -156,154 +994,145 @@ func (s *DashboardService) Overview(ctx context.Context, workspaceID string, day
 		return items[i].Date.Before(items[j].Date)
 	})
 
-	return items, nil
+	return items
 }
 
-func nextOccurrence(from time.Time, month, day int) time.Time {
-	candidate := time.Date(from.Year(), time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	if candidate.Before(from) {
-		candidate = candidate.AddDate(1, 0, 0)
-	}
-	return candidate
+const (
+	DefaultForecastDays = 90
+	MaxForecastDays     = 365
+	forecastCacheTTL    = time.Hour
+	// maxForecastCacheEntries bounds the forecast cache; past it the entry
+	// closest to expiry is evicted.
+	maxForecastCacheEntries = 1000
+)
+
+type ForecastDay struct {
+	Date             time.Time
+	BirthdayCount    int
+	AnniversaryCount int
 }
 
-type dashboardWorkspaceMember struct {
-	ID          string
-	Handle      string
-	DisplayName string
-	AvatarURL   string
+type forecastCacheEntry struct {
+	from      time.Time
+	days      []ForecastDay
+	expiresAt time.Time
 }
 
-func (s *DashboardService) listWorkspaceMembers(ctx context.Context, botToken string) ([]dashboardWorkspaceMember, error) {
-	members := make([]dashboardWorkspaceMember, 0)
-	cursor := ""
+// Forecast returns per-day celebration counts for the next `days` days. The
+// full MaxForecastDays window is computed once per workspace and cached, so
+// shorter requests are served by slicing the cached result.
+func (s *DashboardService) Forecast(ctx context.Context, workspaceID string, days int) ([]ForecastDay, error) {
+	if days <= 0 || days > MaxForecastDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidInput, MaxForecastDays)
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
 
-	for page := 0; page < 10; page++ {
-		pageMembers, nextCursor, err := s.listUsersPage(ctx, botToken, cursor)
+	s.forecastMu.Lock()
+	entry, ok := s.forecastCache[workspaceID]
+	s.forecastMu.Unlock()
+
+	if !ok || now.After(entry.expiresAt) || !entry.from.Equal(today) {
+		people, _, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 0, 0)
 		if err != nil {
 			return nil, err
 		}
-		members = append(members, pageMembers...)
-		if strings.TrimSpace(nextCursor) == "" {
-			break
-		}
-		cursor = nextCursor
-	}
 
-	return members, nil
-}
+		entry = forecastCacheEntry{
+			from:      today,
+			days:      buildForecast(people, today, MaxForecastDays),
+			expiresAt: now.Add(forecastCacheTTL),
+		}
 
-func (s *DashboardService) listUsersPage(ctx context.Context, botToken, cursor string) ([]dashboardWorkspaceMember, string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, slackUsersListURL, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("build users.list request: %w", err)
+		s.storeForecast(workspaceID, entry, now)
 	}
 
-	q := req.URL.Query()
-	q.Set("limit", "200")
-	if strings.TrimSpace(cursor) != "" {
-		q.Set("cursor", cursor)
+	end := today.AddDate(0, 0, days)
+	out := make([]ForecastDay, 0, len(entry.days))
+	for _, d := range entry.days {
+		if d.Date.After(end) {
+			break
+		}
+		out = append(out, d)
 	}
-	req.URL.RawQuery = q.Encode()
-	req.Header.Set("Authorization", "Bearer "+botToken)
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, "", fmt.Errorf("call users.list: %w", err)
-	}
-	defer resp.Body.Close()
+	return out, nil
+}
 
-	var payload slackUsersListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, "", fmt.Errorf("decode users.list response: %w", err)
-	}
-	if !payload.OK {
-		if payload.Error == "" {
-			payload.Error = "users.list failed"
-		}
-		return nil, "", fmt.Errorf("slack api error: %s%s", payload.Error, slackScopeHint(payload.Needed, payload.Provided))
-	}
+// storeForecast caches entry, first dropping expired entries and, when the
+// cache is still full, the entry that would expire soonest.
+func (s *DashboardService) storeForecast(workspaceID string, entry forecastCacheEntry, now time.Time) {
+	s.forecastMu.Lock()
+	defer s.forecastMu.Unlock()
 
-	members := make([]dashboardWorkspaceMember, 0, len(payload.Members))
-	for _, m := range payload.Members {
-		if m.ID == "" || m.Deleted || m.IsBot || m.IsAppUser || m.ID == "USLACKBOT" || strings.EqualFold(strings.TrimSpace(m.Name), "slackbot") {
-			continue
+	for id, cached := range s.forecastCache {
+		if now.After(cached.expiresAt) {
+			delete(s.forecastCache, id)
 		}
+	}
 
-		displayName := strings.TrimSpace(m.Profile.DisplayName)
-		if displayName == "" {
-			displayName = strings.TrimSpace(m.Profile.RealName)
-		}
-		if displayName == "" {
-			displayName = strings.TrimSpace(m.Name)
+	if _, ok := s.forecastCache[workspaceID]; !ok && len(s.forecastCache) >= maxForecastCacheEntries {
+		oldestID := ""
+		var oldest time.Time
+		for id, cached := range s.forecastCache {
+			if oldestID == "" || cached.expiresAt.Before(oldest) {
+				oldestID, oldest = id, cached.expiresAt
+			}
 		}
-
-		members = append(members, dashboardWorkspaceMember{
-			ID:          strings.TrimSpace(m.ID),
-			Handle:      strings.TrimSpace(m.Name),
-			DisplayName: displayName,
-			AvatarURL:   strings.TrimSpace(m.Profile.Image192),
-		})
+		delete(s.forecastCache, oldestID)
 	}
 
-	return members, payload.ResponseMetadata.NextCursor, nil
+	s.forecastCache[workspaceID] = entry
 }
 
-func mergePeopleWithWorkspaceMembers(existing []domain.Person, members []dashboardWorkspaceMember, workspaceID string) []domain.Person {
-	byUserID := make(map[string]domain.Person, len(existing))
-	for _, p := range existing {
-		byUserID[p.SlackUserID] = p
+func buildForecast(people []domain.Person, from time.Time, days int) []ForecastDay {
+	end := from.AddDate(0, 0, days)
+	byDate := make(map[time.Time]*ForecastDay)
+	bucket := func(date time.Time) *ForecastDay {
+		day, ok := byDate[date]
+		if !ok {
+			day = &ForecastDay{Date: date}
+			byDate[date] = day
+		}
+		return day
 	}
 
-	merged := make([]domain.Person, 0, len(existing)+len(members))
-	for _, m := range members {
-		if p, ok := byUserID[m.ID]; ok {
-			if strings.TrimSpace(p.SlackHandle) == "" {
-				p.SlackHandle = m.Handle
-			}
-			if strings.TrimSpace(p.DisplayName) == "" {
-				p.DisplayName = m.DisplayName
-			}
-			if strings.TrimSpace(p.AvatarURL) == "" {
-				p.AvatarURL = m.AvatarURL
-			}
-			if p.WorkspaceID == "" {
-				p.WorkspaceID = workspaceID
-			}
-			if strings.TrimSpace(p.RemindersMode) == "" {
-				p.RemindersMode = "same_day"
-			}
-			merged = append(merged, p)
-			delete(byUserID, m.ID)
+	for _, p := range people {
+		if !p.PublicCelebrationOptIn {
 			continue
 		}
 
-		merged = append(merged, domain.Person{
-			WorkspaceID:            workspaceID,
-			SlackUserID:            m.ID,
-			SlackHandle:            m.Handle,
-			DisplayName:            m.DisplayName,
-			AvatarURL:              m.AvatarURL,
-			PublicCelebrationOptIn: true,
-			RemindersMode:          "same_day",
-		})
-	}
+		if p.BirthdayMonth != nil && p.BirthdayDay != nil {
+			next := nextOccurrence(from, *p.BirthdayMonth, *p.BirthdayDay)
+			if !next.After(end) {
+				bucket(next).BirthdayCount++
+			}
+		}
 
-	for _, p := range byUserID {
-		if strings.TrimSpace(p.RemindersMode) == "" {
-			p.RemindersMode = "same_day"
+		if p.HireDate != nil {
+			next := nextOccurrence(from, int(p.HireDate.Month()), p.HireDate.Day())
+			if !next.After(end) && next.Year()-p.HireDate.Year() > 0 {
+				bucket(next).AnniversaryCount++
+			}
 		}
-		merged = append(merged, p)
 	}
 
-	sort.Slice(merged, func(i, j int) bool {
-		left := strings.ToLower(strings.TrimSpace(fallbackString(merged[i].DisplayName, merged[i].SlackHandle, merged[i].SlackUserID)))
-		right := strings.ToLower(strings.TrimSpace(fallbackString(merged[j].DisplayName, merged[j].SlackHandle, merged[j].SlackUserID)))
-		if left == right {
-			return strings.ToLower(merged[i].SlackUserID) < strings.ToLower(merged[j].SlackUserID)
-		}
-		return left < right
+	out := make([]ForecastDay, 0, len(byDate))
+	for _, day := range byDate {
+		out = append(out, *day)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Date.Before(out[j].Date)
 	})
 
-	return merged
+	return out
+}
+
+func nextOccurrence(from time.Time, month, day int) time.Time {
+	candidate := time.Date(from.Year(), time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if candidate.Before(from) {
+		candidate = candidate.AddDate(1, 0, 0)
+	}
+	return candidate
 }
@@ -1,85 +1,370 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
 )
 
-func TestMergePeopleWithWorkspaceMembers_BuildsPeopleFromMembers(t *testing.T) {
-	merged := mergePeopleWithWorkspaceMembers(nil, []dashboardWorkspaceMember{
-		{ID: "U1", Handle: "alpha", DisplayName: "Alpha"},
-		{ID: "U2", Handle: "beta", DisplayName: "Beta"},
-	}, "W1")
+func TestBuildForecast_GroupsCountsByDate(t *testing.T) {
+	from := time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC)
+	day, month := 12, 6
+	otherDay := 20
+	hired := time.Date(2022, time.June, 12, 0, 0, 0, 0, time.UTC)
+	hiredThisYear := time.Date(2025, time.June, 20, 0, 0, 0, 0, time.UTC)
 
-	if len(merged) != 2 {
-		t.Fatalf("expected 2 people, got %d", len(merged))
+	people := []domain.Person{
+		{SlackUserID: "U1", BirthdayDay: &day, BirthdayMonth: &month, PublicCelebrationOptIn: true},
+		{SlackUserID: "U2", HireDate: &hired, PublicCelebrationOptIn: true},
+		{SlackUserID: "U3", BirthdayDay: &day, BirthdayMonth: &month, PublicCelebrationOptIn: false},
+		{SlackUserID: "U4", BirthdayDay: &otherDay, BirthdayMonth: &month, HireDate: &hiredThisYear, PublicCelebrationOptIn: true},
 	}
-	if merged[0].SlackUserID != "U1" || merged[1].SlackUserID != "U2" {
-		t.Fatalf("unexpected people order/content: %#v", merged)
+
+	forecast := buildForecast(people, from, 30)
+	if len(forecast) != 2 {
+		t.Fatalf("expected 2 forecast days, got %#v", forecast)
+	}
+
+	first := forecast[0]
+	if !first.Date.Equal(time.Date(2025, time.June, 12, 0, 0, 0, 0, time.UTC)) || first.BirthdayCount != 1 || first.AnniversaryCount != 1 {
+		t.Fatalf("unexpected first forecast day: %#v", first)
+	}
+
+	second := forecast[1]
+	if !second.Date.Equal(time.Date(2025, time.June, 20, 0, 0, 0, 0, time.UTC)) || second.BirthdayCount != 1 || second.AnniversaryCount != 0 {
+		t.Fatalf("unexpected second forecast day: %#v", second)
+	}
+}
+
+func TestStoreForecast_EvictsExpiredAndOldestEntries(t *testing.T) {
+	now := time.Date(2025, time.June, 12, 9, 0, 0, 0, time.UTC)
+	s := &DashboardService{forecastCache: make(map[string]forecastCacheEntry)}
+
+	s.forecastCache["expired"] = forecastCacheEntry{expiresAt: now.Add(-time.Minute)}
+	for i := 0; i < maxForecastCacheEntries-1; i++ {
+		s.forecastCache[fmt.Sprintf("W%d", i)] = forecastCacheEntry{expiresAt: now.Add(time.Duration(i+1) * time.Minute)}
+	}
+
+	s.storeForecast("new", forecastCacheEntry{expiresAt: now.Add(forecastCacheTTL)}, now)
+	if _, ok := s.forecastCache["expired"]; ok {
+		t.Fatal("expected the expired entry to be dropped")
+	}
+	if len(s.forecastCache) != maxForecastCacheEntries {
+		t.Fatalf("cache size = %d, want %d", len(s.forecastCache), maxForecastCacheEntries)
+	}
+
+	s.storeForecast("newer", forecastCacheEntry{expiresAt: now.Add(forecastCacheTTL)}, now)
+	if _, ok := s.forecastCache["W0"]; ok {
+		t.Fatal("expected the entry closest to expiry to be evicted")
+	}
+	if _, ok := s.forecastCache["newer"]; !ok || len(s.forecastCache) != maxForecastCacheEntries {
+		t.Fatalf("expected the new entry within the cap, got %d entries", len(s.forecastCache))
+	}
+}
+
+func TestForecast_RejectsOutOfRangeDays(t *testing.T) {
+	s := &DashboardService{forecastCache: make(map[string]forecastCacheEntry)}
+	for _, days := range []int{0, -1, MaxForecastDays + 1} {
+		if _, err := s.Forecast(context.Background(), "W1", days); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("days %d: expected ErrInvalidInput, got %v", days, err)
+		}
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "https with host", url: "https://hooks.example.com/dispatch", wantErr: false},
+		{name: "http scheme rejected", url: "http://hooks.example.com/dispatch", wantErr: true},
+		{name: "missing host", url: "https:///dispatch", wantErr: true},
+		{name: "no scheme", url: "hooks.example.com/dispatch", wantErr: true},
+		{name: "unparseable", url: "https://exa mple.com/%zz", wantErr: true},
+		{name: "localhost", url: "https://localhost:8443/dispatch", wantErr: true},
+		{name: "internal name", url: "https://metadata.google.internal/", wantErr: true},
+		{name: "loopback ip", url: "https://127.0.0.1/dispatch", wantErr: true},
+		{name: "private ip", url: "https://10.1.2.3/dispatch", wantErr: true},
+		{name: "link-local metadata ip", url: "https://169.254.169.254/latest", wantErr: true},
+		{name: "ipv6 loopback", url: "https://[::1]/dispatch", wantErr: true},
+		{name: "public ip", url: "https://203.0.113.10/dispatch", wantErr: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateWebhookURL(tc.url)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateWebhookURL(%q) error = %v, wantErr %v", tc.url, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookClient_RefusesInternalAddressesAfterResolution(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The loopback test server stands in for a public name that resolves to
+	// an internal address.
+	resp, err := newWebhookClient(time.Second).Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the loopback address to be refused")
+	}
+	if !strings.Contains(err.Error(), "is not public") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhookClient_RefusesRedirects(t *testing.T) {
+	client := newWebhookClient(time.Second)
+	req := httptest.NewRequest(http.MethodPost, "https://hooks.example.com/next", nil)
+	if err := client.CheckRedirect(req, []*http.Request{req}); !errors.Is(err, errWebhookRedirect) {
+		t.Fatalf("CheckRedirect error = %v, want errWebhookRedirect", err)
+	}
+}
+
+func TestValidateBirthday(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		day     *int
+		month   *int
+		wantErr string
+	}{
+		{name: "april 31 rejected", day: intPtr(31), month: intPtr(4), wantErr: "birthday day 31 is invalid for month 4"},
+		{name: "february 29 allowed", day: intPtr(29), month: intPtr(2)},
+		{name: "february 28 allowed", day: intPtr(28), month: intPtr(2)},
+		{name: "february 30 rejected", day: intPtr(30), month: intPtr(2), wantErr: "birthday day 30 is invalid for month 2"},
+		{name: "no birthday", day: nil, month: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBirthday(tc.day, tc.month)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("expected error to wrap ErrInvalidInput")
+			}
+		})
+	}
+}
+
+func TestGroupPeopleByWorkspace_KeepsRecordsFromEveryWorkspace(t *testing.T) {
+	people := []domain.Person{
+		{WorkspaceID: "ws-1", SlackUserID: "U1"},
+		{WorkspaceID: "ws-2", SlackUserID: "U2"},
+		{WorkspaceID: "ws-1", SlackUserID: "U3"},
+	}
+
+	grouped := groupPeopleByWorkspace(people)
+	if len(grouped) != 2 {
+		t.Fatalf("expected 2 workspaces, got %d", len(grouped))
+	}
+	if got := grouped["ws-1"]; len(got) != 2 || got[0].SlackUserID != "U1" || got[1].SlackUserID != "U3" {
+		t.Fatalf("unexpected ws-1 people: %+v", got)
 	}
-	if merged[0].WorkspaceID != "W1" || merged[1].WorkspaceID != "W1" {
-		t.Fatalf("expected workspace id to be set on synthetic people")
+	if got := grouped["ws-2"]; len(got) != 1 || got[0].SlackUserID != "U2" {
+		t.Fatalf("unexpected ws-2 people: %+v", got)
 	}
 }
 
-func TestMergePeopleWithWorkspaceMembers_PreservesSavedFields(t *testing.T) {
-	day := 14
-	month := 6
-	existing := []domain.Person{
-		{
-			WorkspaceID:   "W1",
-			SlackUserID:   "U1",
-			SlackHandle:   "alpha_saved",
-			DisplayName:   "Alpha Saved",
-			BirthdayDay:   &day,
-			BirthdayMonth: &month,
-			RemindersMode: "day_before",
-		},
+func TestValidatePauseUntil(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		until   time.Time
+		wantErr bool
+	}{
+		{name: "tomorrow", until: now.Add(24 * time.Hour)},
+		{name: "exactly ninety days", until: now.Add(90 * 24 * time.Hour)},
+		{name: "in the past", until: now.Add(-time.Minute), wantErr: true},
+		{name: "now", until: now, wantErr: true},
+		{name: "beyond ninety days", until: now.Add(91 * 24 * time.Hour), wantErr: true},
 	}
 
-	merged := mergePeopleWithWorkspaceMembers(existing, []dashboardWorkspaceMember{
-		{ID: "U1", Handle: "alpha", DisplayName: "Alpha"},
-	}, "W1")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePauseUntil(tc.until, now)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Fatalf("expected ErrInvalidInput, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
 
-	if len(merged) != 1 {
-		t.Fatalf("expected 1 person, got %d", len(merged))
+func TestSampleAnniversary_UsesHireDateYears(t *testing.T) {
+	now := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+	hired := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := sampleAnniversary(domain.Person{HireDate: &hired}, now); got.Years != 5 {
+		t.Fatalf("expected 5 years, got %d", got.Years)
 	}
-	if merged[0].SlackHandle != "alpha_saved" {
-		t.Fatalf("expected saved slack handle to remain, got %q", merged[0].SlackHandle)
+	if got := sampleAnniversary(domain.Person{}, now); got.Years != 1 {
+		t.Fatalf("expected 1 year without a hire date, got %d", got.Years)
 	}
-	if merged[0].DisplayName != "Alpha Saved" {
-		t.Fatalf("expected saved display name to remain, got %q", merged[0].DisplayName)
+}
+
+func TestNormalizeReactionEmoji(t *testing.T) {
+	valid := map[string]string{
+		":tada:":            "tada",
+		" birthday ":        "birthday",
+		"+1":                "+1",
+		"wave::skin-tone-3": "wave::skin-tone-3",
+		":party-parrot:":    "party-parrot",
+		"":                  "",
+		"::":                "",
 	}
-	if merged[0].BirthdayDay == nil || *merged[0].BirthdayDay != 14 {
-		t.Fatalf("expected birthday day to remain")
+	for raw, want := range valid {
+		got, err := normalizeReactionEmoji(raw)
+		if err != nil || got != want {
+			t.Fatalf("normalizeReactionEmoji(%q) = %q, %v; want %q", raw, got, err, want)
+		}
 	}
-	if merged[0].BirthdayMonth == nil || *merged[0].BirthdayMonth != 6 {
-		t.Fatalf("expected birthday month to remain")
+
+	for _, raw := range []string{"🎉", "tada party", "Tada"} {
+		if _, err := normalizeReactionEmoji(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
 	}
-	if merged[0].RemindersMode != "day_before" {
-		t.Fatalf("expected reminders mode to remain, got %q", merged[0].RemindersMode)
+}
+
+func TestListPeopleMissingData_RejectsUnknownFilter(t *testing.T) {
+	// No repositories: an accepted filter would panic on the workspace lookup.
+	s := &DashboardService{}
+	for _, missing := range []repository.MissingData{repository.MissingNone, "avatar"} {
+		if _, _, err := s.ListPeopleMissingData(context.Background(), "W1", missing, 10, 0); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("missing=%q: expected ErrInvalidInput, got %v", missing, err)
+		}
 	}
 }
 
-func TestMergePeopleWithWorkspaceMembers_KeepsExistingMissingFromSlack(t *testing.T) {
-	merged := mergePeopleWithWorkspaceMembers([]domain.Person{
-		{WorkspaceID: "W1", SlackUserID: "U_STALE", DisplayName: "Stale User"},
-	}, []dashboardWorkspaceMember{
-		{ID: "U1", Handle: "alpha", DisplayName: "Alpha"},
-	}, "W1")
+func TestMergeImportedPerson_PartialCSVKeepsStoredValues(t *testing.T) {
+	hireDate := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+	existing := domain.Person{
+		SlackUserID:            "U1",
+		SlackHandle:            "ada",
+		DisplayName:            "Ada Lovelace",
+		AvatarURL:              "https://example.com/ada.png",
+		BirthdayDay:            intPtr(10),
+		BirthdayMonth:          intPtr(12),
+		BirthdayYear:           intPtr(1990),
+		HireDate:               &hireDate,
+		PublicCelebrationOptIn: true,
+		RemindersMode:          "day_before",
+	}
 
-	if len(merged) != 2 {
-		t.Fatalf("expected 2 people, got %d", len(merged))
+	csv := "slack_user_id,display_name,birthday_day,birthday_month\nU1,,10,12\n"
+	rows, rowErrors, err := ParsePeopleCSV(strings.NewReader(csv), "W1", nil)
+	if err != nil || len(rowErrors) != 0 || len(rows) != 1 {
+		t.Fatalf("ParsePeopleCSV() = %v, %v, %v", rows, rowErrors, err)
 	}
 
-	foundStale := false
-	for _, p := range merged {
-		if p.SlackUserID == "U_STALE" {
-			foundStale = true
+	got := mergeImportedPerson(rows[0], existing)
+
+	if got.DisplayName != "Ada Lovelace" || got.SlackHandle != "ada" || got.AvatarURL != existing.AvatarURL {
+		t.Fatalf("expected profile fields to be kept, got %+v", got)
+	}
+	if got.HireDate == nil || !got.HireDate.Equal(hireDate) {
+		t.Fatalf("expected hire date to be kept, got %v", got.HireDate)
+	}
+	if got.BirthdayYear == nil || *got.BirthdayYear != 1990 {
+		t.Fatalf("expected birthday year to be kept, got %v", got.BirthdayYear)
+	}
+	if got.RemindersMode != "day_before" {
+		t.Fatalf("expected reminders mode to be kept, got %q", got.RemindersMode)
+	}
+}
+
+func TestMergeImportedPerson_ImportedValuesWin(t *testing.T) {
+	existing := domain.Person{
+		SlackUserID:   "U1",
+		DisplayName:   "Ada",
+		BirthdayDay:   intPtr(10),
+		BirthdayMonth: intPtr(12),
+		BirthdayYear:  intPtr(1990),
+	}
+	in := repository.UpsertPersonInput{
+		SlackUserID:            "U1",
+		DisplayName:            "Ada L.",
+		BirthdayDay:            intPtr(11),
+		BirthdayMonth:          intPtr(12),
+		PublicCelebrationOptIn: true,
+	}
+
+	got := mergeImportedPerson(in, existing)
+
+	if got.DisplayName != "Ada L." || *got.BirthdayDay != 11 {
+		t.Fatalf("expected imported values to win, got %+v", got)
+	}
+	if got.BirthdayYear != nil {
+		t.Fatalf("expected a changed birthday not to inherit the stored year, got %v", *got.BirthdayYear)
+	}
+	if got.PublicCelebrationOptIn {
+		t.Fatal("expected an import not to re-enable public celebrations")
+	}
+}
+
+func TestNormalizeChannelSettings(t *testing.T) {
+	valid := func() repository.UpdateChannelSettingsInput {
+		webhook := " https://hooks.example.com/dispatch "
+		emoji := ":tada:"
+		return repository.UpdateChannelSettingsInput{
+			PostingTime:            "09:00",
+			Timezone:               "UTC",
+			PostDispatchWebhookURL: &webhook,
+			ReactionEmoji:          &emoji,
 		}
 	}
-	if !foundStale {
-		t.Fatalf("expected stale saved person to remain in list")
+
+	got, err := normalizeChannelSettings(valid())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got.PostDispatchWebhookURL != "https://hooks.example.com/dispatch" || *got.ReactionEmoji != "tada" {
+		t.Fatalf("expected trimmed webhook and bare emoji, got %q and %q", *got.PostDispatchWebhookURL, *got.ReactionEmoji)
+	}
+
+	negative := -1
+	insecure := "http://hooks.example.com/dispatch"
+	for name, mutate := range map[string]func(*repository.UpdateChannelSettingsInput){
+		"posting time":   func(in *repository.UpdateChannelSettingsInput) { in.PostingTime = "9am" },
+		"timezone":       func(in *repository.UpdateChannelSettingsInput) { in.Timezone = "Mars/Base" },
+		"max recipients": func(in *repository.UpdateChannelSettingsInput) { in.MaxRecipientsPerPost = &negative },
+		"min tenure":     func(in *repository.UpdateChannelSettingsInput) { in.MinAnniversaryTenureMonths = &negative },
+		"webhook":        func(in *repository.UpdateChannelSettingsInput) { in.PostDispatchWebhookURL = &insecure },
+	} {
+		in := valid()
+		mutate(&in)
+		if _, err := normalizeChannelSettings(in); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
 	}
 }
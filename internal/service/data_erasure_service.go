@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+// DataErasureService permanently removes a person's data on request. Unlike
+// DeletePerson it keeps nothing to restore; only an audit row without PII is
+// left behind.
+type DataErasureService struct {
+	peopleRepo      *repository.PeopleRepository
+	dispatchLogRepo *repository.DispatchLogRepository
+	slackClient     slack.Client
+	logger          *slog.Logger
+	now             func() time.Time
+}
+
+func NewDataErasureService(
+	peopleRepo *repository.PeopleRepository,
+	dispatchLogRepo *repository.DispatchLogRepository,
+	slackClient slack.Client,
+	logger *slog.Logger,
+) *DataErasureService {
+	return &DataErasureService{
+		peopleRepo:      peopleRepo,
+		dispatchLogRepo: dispatchLogRepo,
+		slackClient:     slackClient,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// ErasePerson cancels pending scheduled celebrations naming the person, then
+// deletes their rows. Cancelling is best-effort: Slack failures are logged and
+// the erasure goes ahead.
+func (s *DataErasureService) ErasePerson(ctx context.Context, workspaceID, slackUserID string) (repository.PersonErasure, error) {
+	s.cancelScheduledPosts(ctx, workspaceID, slackUserID)
+
+	erasure, err := s.peopleRepo.ErasePerson(ctx, workspaceID, slackUserID)
+	if err != nil {
+		return repository.PersonErasure{}, err
+	}
+
+	s.logger.WarnContext(ctx, "person data erased",
+		slog.String("workspace_id", workspaceID),
+		slog.String("slack_user_id", slackUserID),
+		slog.Int64("rows_affected", erasure.RowsAffected),
+	)
+	return erasure, nil
+}
+
+func (s *DataErasureService) cancelScheduledPosts(ctx context.Context, workspaceID, slackUserID string) {
+	// Channels post in their own timezone, so start a day back to catch every
+	// post that may still be pending.
+	entries, err := s.dispatchLogRepo.ListScheduledForUser(ctx, workspaceID, slackUserID, s.now().AddDate(0, 0, -1))
+	if err != nil {
+		s.logger.WarnContext(ctx, "list scheduled celebrations for erasure failed",
+			slog.String("workspace_id", workspaceID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	for _, entry := range entries {
+		if err := s.slackClient.DeleteScheduledMessages(ctx, workspaceID, entry.SlackChannelID, entry.ScheduledMessageIDs); err != nil {
+			s.logger.WarnContext(ctx, "cancel scheduled celebration for erasure failed",
+				slog.String("workspace_id", workspaceID),
+				slog.String("channel_id", entry.WorkspaceChannelID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+// digestWeekday and digestTime are when the weekly digest goes out, in the
+// workspace's timezone.
+const (
+	digestWeekday = time.Monday
+	digestTime    = "09:00"
+)
+
+// DigestService sends each workspace a weekly summary of the coming week's
+// celebrations.
+type DigestService struct {
+	workspaceRepo *repository.WorkspaceRepository
+	peopleRepo    *repository.PeopleRepository
+	digestLogRepo *repository.DigestLogRepository
+	slackClient   slack.Client
+	logger        *slog.Logger
+}
+
+func NewDigestService(
+	workspaceRepo *repository.WorkspaceRepository,
+	peopleRepo *repository.PeopleRepository,
+	digestLogRepo *repository.DigestLogRepository,
+	slackClient slack.Client,
+	logger *slog.Logger,
+) *DigestService {
+	return &DigestService{
+		workspaceRepo: workspaceRepo,
+		peopleRepo:    peopleRepo,
+		digestLogRepo: digestLogRepo,
+		slackClient:   slackClient,
+		logger:        logger,
+	}
+}
+
+// RunDueDigests sends the weekly digest for every connected workspace whose
+// digest time has passed this week. Each digest is sent at most once per
+// workspace and week.
+func (s *DigestService) RunDueDigests(ctx context.Context, now time.Time) error {
+	workspaceIDs, err := s.workspaceRepo.ListConnectedWorkspaceIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workspaceID := range workspaceIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.runWorkspaceDigest(ctx, workspaceID, now); err != nil {
+			s.logger.ErrorContext(ctx, "failed workspace digest run",
+				slog.String("workspace_id", workspaceID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *DigestService) runWorkspaceDigest(ctx context.Context, workspaceID string, now time.Time) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(workspace.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	weekStart, due := digestWeekStart(now.In(loc))
+	if !due {
+		return nil
+	}
+
+	claimed, err := s.digestLogRepo.Claim(ctx, workspaceID, weekStart)
+	if err != nil || !claimed {
+		return err
+	}
+
+	if err := s.SendWeeklyDigest(ctx, workspaceID, weekStart); err != nil {
+		if releaseErr := s.digestLogRepo.Release(ctx, workspaceID, weekStart); releaseErr != nil {
+			s.logger.ErrorContext(ctx, "release digest failed",
+				slog.String("workspace_id", workspaceID),
+				slog.String("error", releaseErr.Error()),
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+// SendWeeklyDigest lists the celebrations in the seven days from weekStart.
+// The digest is posted to the workspace's announcement channel first, when
+// one is set, and then sent to the admin who installed the app. A week with
+// nothing to celebrate sends nothing.
+func (s *DigestService) SendWeeklyDigest(ctx context.Context, workspaceID string, weekStart time.Time) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(install.BotToken) == "" {
+		return ErrNotConnected
+	}
+
+	people, _, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	text := weeklyDigestMessage(workspace, people, weekStart)
+	if text == "" {
+		return nil
+	}
+
+	var errs []error
+	if workspace.AnnouncementChannelID != "" {
+		if _, err := s.slackClient.PostMessage(ctx, workspaceID, workspace.AnnouncementChannelID, text, nil); err != nil {
+			errs = append(errs, fmt.Errorf("post digest to announcement channel: %w", err))
+		}
+	}
+	if install.InstalledByUserID != "" {
+		if err := s.slackClient.SendDirectMessage(ctx, workspaceID, install.InstalledByUserID, text); err != nil {
+			errs = append(errs, fmt.Errorf("send digest dm: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// digestWeekStart returns the date, in UTC, of the digestWeekday starting
+// localNow's week, and whether digestTime on that day has passed.
+func digestWeekStart(localNow time.Time) (time.Time, bool) {
+	at, _ := time.Parse("15:04", digestTime)
+
+	daysSince := (int(localNow.Weekday()) - int(digestWeekday) + 7) % 7
+	start := time.Date(localNow.Year(), localNow.Month(), localNow.Day()-daysSince, at.Hour(), at.Minute(), 0, 0, localNow.Location())
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC), !localNow.Before(start)
+}
+
+// weeklyDigestMessage renders the digest for the week starting weekStart. It
+// is empty when the workspace has nothing to celebrate that week.
+func weeklyDigestMessage(workspace domain.Workspace, people []domain.Person, weekStart time.Time) string {
+	celebrationType := "all"
+	switch {
+	case !workspace.BirthdaysEnabled && !workspace.AnniversariesEnabled:
+		return ""
+	case !workspace.BirthdaysEnabled:
+		celebrationType = "anniversaries"
+	case !workspace.AnniversariesEnabled:
+		celebrationType = "birthdays"
+	}
+
+	var b strings.Builder
+	for _, item := range upcomingCelebrations(people, weekStart, 6, celebrationType) {
+		if item.Type == "anniversary" && (item.Years == nil || *item.Years <= 0) {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(":calendar: *Celebrations this week*")
+		}
+		day := item.Date.Format("Mon Jan 2")
+		if item.Type == "anniversary" {
+			fmt.Fprintf(&b, "\n• %s: <@%s>'s %d-year work anniversary", day, item.UserID, *item.Years)
+			continue
+		}
+		fmt.Fprintf(&b, "\n• %s: <@%s>'s birthday", day, item.UserID)
+	}
+	return b.String()
+}
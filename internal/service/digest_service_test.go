@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+func TestDigestWeekStart(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	monday := time.Date(2025, time.June, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		localNow time.Time
+		want     time.Time
+		wantDue  bool
+	}{
+		{name: "monday before digest time", localNow: time.Date(2025, time.June, 9, 8, 59, 0, 0, ny), want: monday, wantDue: false},
+		{name: "monday at digest time", localNow: time.Date(2025, time.June, 9, 9, 0, 0, 0, ny), want: monday, wantDue: true},
+		{name: "later in the week", localNow: time.Date(2025, time.June, 12, 7, 0, 0, 0, ny), want: monday, wantDue: true},
+		{name: "sunday belongs to the week before", localNow: time.Date(2025, time.June, 15, 23, 0, 0, 0, ny), want: monday, wantDue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, due := digestWeekStart(tt.localNow)
+			if !got.Equal(tt.want) || due != tt.wantDue {
+				t.Fatalf("digestWeekStart(%s) = %s, %v; want %s, %v", tt.localNow, got, due, tt.want, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestWeeklyDigestMessage(t *testing.T) {
+	weekStart := time.Date(2025, time.June, 9, 0, 0, 0, 0, time.UTC)
+	hire := time.Date(2022, time.June, 11, 0, 0, 0, 0, time.UTC)
+	newHire := time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC)
+	people := []domain.Person{
+		{SlackUserID: "U1", DisplayName: "Alice", BirthdayMonth: intPtr(6), BirthdayDay: intPtr(15)},
+		{SlackUserID: "U2", DisplayName: "Bob", HireDate: &hire},
+		{SlackUserID: "U3", DisplayName: "Carol", BirthdayMonth: intPtr(6), BirthdayDay: intPtr(16)},
+		{SlackUserID: "U4", DisplayName: "Dan", HireDate: &newHire},
+	}
+
+	tests := []struct {
+		name      string
+		workspace domain.Workspace
+		want      string
+	}{
+		{
+			name:      "both celebration types",
+			workspace: domain.Workspace{BirthdaysEnabled: true, AnniversariesEnabled: true},
+			want:      ":calendar: *Celebrations this week*\n• Wed Jun 11: <@U2>'s 3-year work anniversary\n• Sun Jun 15: <@U1>'s birthday",
+		},
+		{
+			name:      "anniversaries disabled",
+			workspace: domain.Workspace{BirthdaysEnabled: true},
+			want:      ":calendar: *Celebrations this week*\n• Sun Jun 15: <@U1>'s birthday",
+		},
+		{
+			name:      "everything disabled",
+			workspace: domain.Workspace{},
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weeklyDigestMessage(tt.workspace, people, weekStart); got != tt.want {
+				t.Fatalf("weeklyDigestMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"errors"
+
+	"slackcheers/internal/slack"
+)
+
+var (
+	ErrBotNotInChannel          = errors.New("bot is not a member of the channel and could not auto-join")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+	ErrChannelArchived          = errors.New("slack channel is archived")
+	ErrBotNotChannelMember      = errors.New("bot is not a member of the slack channel")
+	ErrInvalidInput             = errors.New("invalid input")
+	ErrInvalidOAuthState        = errors.New("invalid or expired oauth state")
+	// ErrSlackAPIError is shared with the slack package so errors returned by
+	// slack.Client match it too.
+	ErrSlackAPIError = slack.ErrAPIError
+	// ErrNotConnected is shared with the slack package so a missing bot token
+	// reported by slack.Client matches it too.
+	ErrNotConnected = slack.ErrNotConnected
+)
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"slackcheers/internal/repository"
+)
+
+const IdempotencyKeyTTL = 24 * time.Hour
+
+type IdempotencyService struct {
+	workspaceRepo   *repository.WorkspaceRepository
+	idempotencyRepo *repository.IdempotencyRepository
+}
+
+func NewIdempotencyService(workspaceRepo *repository.WorkspaceRepository, idempotencyRepo *repository.IdempotencyRepository) *IdempotencyService {
+	return &IdempotencyService{
+		workspaceRepo:   workspaceRepo,
+		idempotencyRepo: idempotencyRepo,
+	}
+}
+
+// Begin claims key for workspaceID. It returns the cached response body when
+// the key was already completed within IdempotencyKeyTTL, and
+// ErrIdempotencyKeyInProgress when the original request is still running.
+func (s *IdempotencyService) Begin(ctx context.Context, workspaceID, key string) ([]byte, error) {
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	claimed, cached, err := s.idempotencyRepo.Claim(ctx, workspaceID, key, time.Now().Add(-IdempotencyKeyTTL))
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+	if cached == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	return cached, nil
+}
+
+func (s *IdempotencyService) Complete(ctx context.Context, workspaceID, key string, response any) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("encode idempotent response: %w", err)
+	}
+	return s.idempotencyRepo.SaveResponse(ctx, workspaceID, key, body)
+}
+
+func (s *IdempotencyService) Release(ctx context.Context, workspaceID, key string) error {
+	return s.idempotencyRepo.Release(ctx, workspaceID, key)
+}
+
+func (s *IdempotencyService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.idempotencyRepo.DeleteOlderThan(ctx, now.Add(-IdempotencyKeyTTL))
+}
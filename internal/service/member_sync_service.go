@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+// SlackMemberSyncService copies each workspace's Slack members into people so
+// listings can be served from the database alone.
+type SlackMemberSyncService struct {
+	workspaceRepo *repository.WorkspaceRepository
+	peopleRepo    *repository.PeopleRepository
+	httpClient    *http.Client
+	paging        slackPaging
+	now           func() time.Time
+	logger        *slog.Logger
+}
+
+func NewSlackMemberSyncService(
+	workspaceRepo *repository.WorkspaceRepository,
+	peopleRepo *repository.PeopleRepository,
+	pageSize, maxPages int,
+	logger *slog.Logger,
+) *SlackMemberSyncService {
+	return &SlackMemberSyncService{
+		workspaceRepo: workspaceRepo,
+		peopleRepo:    peopleRepo,
+		httpClient:    slack.NewHTTPClient(12*time.Second, logger),
+		paging:        newSlackPaging(pageSize, maxPages),
+		now:           time.Now,
+		logger:        logger,
+	}
+}
+
+// SyncWorkspaceMembers fetches every Slack member of the workspace and upserts
+// them into people. Only the handle, display name and avatar of existing
+// people are refreshed.
+func (s *SlackMemberSyncService) SyncWorkspaceMembers(ctx context.Context, workspaceID string) error {
+	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(install.BotToken) == "" {
+		return ErrNotConnected
+	}
+
+	members, err := s.listWorkspaceMembers(ctx, install.BotToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.peopleRepo.SyncSlackMembers(ctx, workspaceID, memberProfiles(members), s.now().UTC()); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Slack members synced.",
+		slog.String("workspace_id", workspaceID),
+		slog.Int("members", len(members)),
+	)
+	return nil
+}
+
+// SyncAllWorkspaces syncs every connected workspace. A failing workspace is
+// logged and skipped.
+func (s *SlackMemberSyncService) SyncAllWorkspaces(ctx context.Context) error {
+	workspaceIDs, err := s.workspaceRepo.ListConnectedWorkspaceIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workspaceID := range workspaceIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.SyncWorkspaceMembers(ctx, workspaceID); err != nil {
+			s.logger.ErrorContext(ctx, "Slack member sync failed.",
+				slog.String("workspace_id", workspaceID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// SyncStatus returns the workspace's last member sync. SyncedAt is zero when
+// the workspace has never been synced.
+func (s *SlackMemberSyncService) SyncStatus(ctx context.Context, workspaceID string) (repository.MemberSyncStatus, error) {
+	if _, err := s.workspaceRepo.GetByID(ctx, workspaceID); err != nil {
+		return repository.MemberSyncStatus{}, err
+	}
+
+	status, err := s.peopleRepo.GetMemberSyncStatus(ctx, workspaceID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return repository.MemberSyncStatus{WorkspaceID: workspaceID}, nil
+	}
+	return status, err
+}
+
+// memberProfiles drops repeated user IDs, which a single upsert statement
+// cannot apply twice.
+func memberProfiles(members []workspaceMember) []repository.SlackMemberProfile {
+	seen := make(map[string]struct{}, len(members))
+	out := make([]repository.SlackMemberProfile, 0, len(members))
+	for _, m := range members {
+		if _, ok := seen[m.ID]; ok {
+			continue
+		}
+		seen[m.ID] = struct{}{}
+		out = append(out, repository.SlackMemberProfile{
+			SlackUserID: m.ID,
+			SlackHandle: m.Handle,
+			DisplayName: m.DisplayName,
+			AvatarURL:   m.AvatarURL,
+		})
+	}
+	return out
+}
+
+type workspaceMember struct {
+	ID          string
+	Handle      string
+	DisplayName string
+	AvatarURL   string
+}
+
+func (s *SlackMemberSyncService) listWorkspaceMembers(ctx context.Context, botToken string) ([]workspaceMember, error) {
+	members := make([]workspaceMember, 0)
+	cursor := ""
+
+	for page := 0; page < s.paging.maxPages; page++ {
+		pageMembers, nextCursor, err := s.listUsersPage(ctx, botToken, cursor)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, pageMembers...)
+		cursor = strings.TrimSpace(nextCursor)
+		if cursor == "" {
+			break
+		}
+	}
+	if cursor != "" {
+		s.logger.WarnContext(ctx, "Slack member page limit reached; results may be incomplete.",
+			slog.Int("max_pages", s.paging.maxPages),
+			slog.Int("members", len(members)),
+		)
+	}
+
+	return members, nil
+}
+
+func (s *SlackMemberSyncService) listUsersPage(ctx context.Context, botToken, cursor string) ([]workspaceMember, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, slackUsersListURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("build users.list request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Set("limit", s.paging.limit())
+	if strings.TrimSpace(cursor) != "" {
+		q.Set("cursor", cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("call users.list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload slackUsersListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, "", fmt.Errorf("decode users.list response: %w", err)
+	}
+	if !payload.OK {
+		if payload.Error == "" {
+			payload.Error = "users.list failed"
+		}
+		return nil, "", &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
+	}
+
+	members := make([]workspaceMember, 0, len(payload.Members))
+	for _, m := range payload.Members {
+		if m.ID == "" || m.Deleted || m.IsBot || m.IsAppUser || m.ID == "USLACKBOT" || strings.EqualFold(strings.TrimSpace(m.Name), "slackbot") {
+			continue
+		}
+
+		displayName := strings.TrimSpace(m.Profile.DisplayName)
+		if displayName == "" {
+			displayName = strings.TrimSpace(m.Profile.RealName)
+		}
+		if displayName == "" {
+			displayName = strings.TrimSpace(m.Name)
+		}
+
+		members = append(members, workspaceMember{
+			ID:          strings.TrimSpace(m.ID),
+			Handle:      strings.TrimSpace(m.Name),
+			DisplayName: displayName,
+			AvatarURL:   strings.TrimSpace(m.Profile.Image192),
+		})
+	}
+
+	return members, payload.ResponseMetadata.NextCursor, nil
+}
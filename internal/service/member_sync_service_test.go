@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"slackcheers/internal/slack"
+)
+
+func TestMemberProfiles_DropsRepeatedUserIDs(t *testing.T) {
+	profiles := memberProfiles([]workspaceMember{
+		{ID: "U1", Handle: "alpha", DisplayName: "Alpha"},
+		{ID: "U2", Handle: "beta", DisplayName: "Beta"},
+		{ID: "U1", Handle: "alpha2", DisplayName: "Alpha Again"},
+	})
+
+	if len(profiles) != 2 {
+		t.Fatalf("expected 2 profiles, got %#v", profiles)
+	}
+	if profiles[0].SlackUserID != "U1" || profiles[0].SlackHandle != "alpha" || profiles[1].SlackUserID != "U2" {
+		t.Fatalf("unexpected profiles: %#v", profiles)
+	}
+}
+
+// usersListClient answers users.list with one page per cursor.
+func usersListClient(t *testing.T, pages map[string]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("Authorization"); got != "Bearer xoxb-test" {
+			t.Fatalf("Authorization = %q", got)
+		}
+		body, ok := pages[req.URL.Query().Get("cursor")]
+		if !ok {
+			t.Fatalf("unexpected cursor %q", req.URL.Query().Get("cursor"))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+}
+
+func newTestMemberSyncService(client *http.Client, maxPages int) *SlackMemberSyncService {
+	return &SlackMemberSyncService{
+		httpClient: client,
+		paging:     newSlackPaging(2, maxPages),
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestListWorkspaceMembers_FollowsCursorsAndSkipsNonPeople(t *testing.T) {
+	client := usersListClient(t, map[string]string{
+		"": `{"ok":true,"members":[
+			{"id":"U1","name":"ada","profile":{"display_name":"Ada","image_192":"https://img/ada.png"}},
+			{"id":"B1","name":"helper","is_bot":true},
+			{"id":"USLACKBOT","name":"slackbot"}
+		],"response_metadata":{"next_cursor":"page2"}}`,
+		"page2": `{"ok":true,"members":[
+			{"id":"U2","name":"grace","profile":{"real_name":"Grace Hopper"}},
+			{"id":"U3","name":"gone","deleted":true},
+			{"id":"U4","name":"linus","profile":{}}
+		],"response_metadata":{"next_cursor":""}}`,
+	})
+
+	members, err := newTestMemberSyncService(client, 5).listWorkspaceMembers(context.Background(), "xoxb-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []workspaceMember{
+		{ID: "U1", Handle: "ada", DisplayName: "Ada", AvatarURL: "https://img/ada.png"},
+		{ID: "U2", Handle: "grace", DisplayName: "Grace Hopper"},
+		{ID: "U4", Handle: "linus", DisplayName: "linus"},
+	}
+	if len(members) != len(want) {
+		t.Fatalf("got %d members, want %d: %#v", len(members), len(want), members)
+	}
+	for i := range want {
+		if members[i] != want[i] {
+			t.Fatalf("member %d = %#v, want %#v", i, members[i], want[i])
+		}
+	}
+}
+
+func TestListWorkspaceMembers_StopsAtPageLimit(t *testing.T) {
+	client := usersListClient(t, map[string]string{
+		"": `{"ok":true,"members":[{"id":"U1","name":"ada"}],"response_metadata":{"next_cursor":"page2"}}`,
+	})
+
+	members, err := newTestMemberSyncService(client, 1).listWorkspaceMembers(context.Background(), "xoxb-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 1 {
+		t.Fatalf("expected only the first page, got %#v", members)
+	}
+}
+
+func TestListWorkspaceMembers_ReturnsSlackErrors(t *testing.T) {
+	client := usersListClient(t, map[string]string{
+		"": `{"ok":false,"error":"missing_scope","needed":"users:read","provided":"chat:write"}`,
+	})
+
+	_, err := newTestMemberSyncService(client, 5).listWorkspaceMembers(context.Background(), "xoxb-test")
+	var apiErr *slack.SlackAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "missing_scope" || apiErr.Needed != "users:read" {
+		t.Fatalf("expected a missing_scope SlackAPIError, got %v", err)
+	}
+}
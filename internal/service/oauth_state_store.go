@@ -0,0 +1,88 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// OAuthStateTTL is how long an install has to reach the callback.
+	OAuthStateTTL = 10 * time.Minute
+	// maxOAuthStates bounds MemoryStateStore; the oldest state is dropped to
+	// make room, so a flood of install requests cannot grow memory.
+	maxOAuthStates = 10000
+)
+
+// StateStore remembers OAuth state values issued by InstallURL so the callback
+// can confirm it answers a flow this server started.
+type StateStore interface {
+	Save(state string) error
+	// Validate reports whether state was saved and has not expired. A state
+	// validates at most once.
+	Validate(state string) (bool, error)
+}
+
+// MemoryStateStore is an in-process StateStore. States do not survive a
+// restart or cross instances, so every install must finish on the instance
+// that started it.
+type MemoryStateStore struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.Mutex
+	// order holds states oldest first; with a fixed TTL that is also expiry
+	// order, so expired states are always at the front.
+	order  *list.List
+	states map[string]*list.Element
+}
+
+type storedState struct {
+	state     string
+	expiresAt time.Time
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		ttl:        OAuthStateTTL,
+		maxEntries: maxOAuthStates,
+		now:        time.Now,
+		order:      list.New(),
+		states:     make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryStateStore) Save(state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for front := s.order.Front(); front != nil; front = s.order.Front() {
+		entry := front.Value.(storedState)
+		if now.Before(entry.expiresAt) && s.order.Len() < s.maxEntries {
+			break
+		}
+		s.order.Remove(front)
+		delete(s.states, entry.state)
+	}
+
+	if el, ok := s.states[state]; ok {
+		s.order.Remove(el)
+	}
+	s.states[state] = s.order.PushBack(storedState{state: state, expiresAt: now.Add(s.ttl)})
+	return nil
+}
+
+func (s *MemoryStateStore) Validate(state string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.states[state]
+	if !ok {
+		return false, nil
+	}
+	s.order.Remove(el)
+	delete(s.states, state)
+	return s.now().Before(el.Value.(storedState).expiresAt), nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStateStore_RejectsReplayedState(t *testing.T) {
+	store := NewMemoryStateStore()
+	if err := store.Save("abc"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	ok, err := store.Validate("abc")
+	if err != nil || !ok {
+		t.Fatalf("first Validate = %v, %v; want true, nil", ok, err)
+	}
+
+	ok, err = store.Validate("abc")
+	if err != nil || ok {
+		t.Fatalf("replayed Validate = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestMemoryStateStore_RejectsExpiredAndUnknownState(t *testing.T) {
+	now := time.Date(2025, time.March, 25, 9, 0, 0, 0, time.UTC)
+	store := NewMemoryStateStore()
+	store.now = func() time.Time { return now }
+
+	if err := store.Save("abc"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	now = now.Add(OAuthStateTTL + time.Second)
+
+	if ok, _ := store.Validate("abc"); ok {
+		t.Fatalf("expected expired state to be rejected")
+	}
+	if ok, _ := store.Validate("never-issued"); ok {
+		t.Fatalf("expected unknown state to be rejected")
+	}
+}
+
+func TestMemoryStateStore_DropsOldestWhenFull(t *testing.T) {
+	store := NewMemoryStateStore()
+	store.maxEntries = 2
+
+	for _, state := range []string{"a", "b", "c"} {
+		if err := store.Save(state); err != nil {
+			t.Fatalf("save %s: %v", state, err)
+		}
+	}
+
+	if len(store.states) != 2 || store.order.Len() != 2 {
+		t.Fatalf("expected 2 stored states, got %d", len(store.states))
+	}
+	if ok, _ := store.Validate("a"); ok {
+		t.Fatal("expected the oldest state to be dropped")
+	}
+	if ok, _ := store.Validate("c"); !ok {
+		t.Fatal("expected the newest state to validate")
+	}
+}
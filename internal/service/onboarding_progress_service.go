@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"slackcheers/internal/repository"
+)
+
+type OnboardingProgressService struct {
+	workspaceRepo  *repository.WorkspaceRepository
+	onboardingRepo *repository.OnboardingRepository
+	peopleRepo     *repository.PeopleRepository
+}
+
+type OnboardingProgress struct {
+	TotalSent         int `json:"total_sent"`
+	RespondedBirthday int `json:"responded_birthday"`
+	RespondedHireDate int `json:"responded_hire_date"`
+	RespondedBoth     int `json:"responded_both"`
+	Pending           int `json:"pending"`
+	NotSent           int `json:"not_sent"`
+}
+
+func NewOnboardingProgressService(
+	workspaceRepo *repository.WorkspaceRepository,
+	onboardingRepo *repository.OnboardingRepository,
+	peopleRepo *repository.PeopleRepository,
+) *OnboardingProgressService {
+	return &OnboardingProgressService{
+		workspaceRepo:  workspaceRepo,
+		onboardingRepo: onboardingRepo,
+		peopleRepo:     peopleRepo,
+	}
+}
+
+func (s *OnboardingProgressService) GetProgress(ctx context.Context, workspaceID string) (OnboardingProgress, error) {
+	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return OnboardingProgress{}, err
+	}
+	if strings.TrimSpace(install.BotToken) == "" {
+		return OnboardingProgress{}, ErrNotConnected
+	}
+
+	counts, err := s.onboardingRepo.GetProgressCounts(ctx, workspaceID)
+	if err != nil {
+		return OnboardingProgress{}, err
+	}
+
+	memberCount, err := s.memberCount(ctx, workspaceID)
+	if err != nil {
+		return OnboardingProgress{}, err
+	}
+
+	return buildOnboardingProgress(counts, memberCount), nil
+}
+
+// memberCount is the Slack member count recorded by the last member sync. A
+// workspace that has not been synced yet falls back to its people count.
+func (s *OnboardingProgressService) memberCount(ctx context.Context, workspaceID string) (int, error) {
+	status, err := s.peopleRepo.GetMemberSyncStatus(ctx, workspaceID)
+	if err == nil {
+		return status.MemberCount, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return 0, err
+	}
+
+	_, total, err := s.peopleRepo.ListByWorkspace(ctx, workspaceID, repository.MissingNone, 1, 0)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func buildOnboardingProgress(counts repository.OnboardingProgressCounts, memberCount int) OnboardingProgress {
+	progress := OnboardingProgress{
+		TotalSent:         counts.TotalSent,
+		RespondedBirthday: counts.RespondedBirthday,
+		RespondedHireDate: counts.RespondedHireDate,
+		RespondedBoth:     counts.RespondedBoth,
+	}
+
+	progress.Pending = counts.TotalSent - counts.RespondedBirthday - counts.RespondedHireDate - counts.RespondedBoth
+	if progress.Pending < 0 {
+		progress.Pending = 0
+	}
+	progress.NotSent = memberCount - counts.TotalSent
+	if progress.NotSent < 0 {
+		progress.NotSent = 0
+	}
+
+	return progress
+}
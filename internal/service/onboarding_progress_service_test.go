@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"slackcheers/internal/repository"
+)
+
+func TestBuildOnboardingProgress(t *testing.T) {
+	got := buildOnboardingProgress(repository.OnboardingProgressCounts{
+		TotalSent:         10,
+		RespondedBirthday: 3,
+		RespondedHireDate: 1,
+		RespondedBoth:     2,
+	}, 25)
+
+	want := OnboardingProgress{
+		TotalSent:         10,
+		RespondedBirthday: 3,
+		RespondedHireDate: 1,
+		RespondedBoth:     2,
+		Pending:           4,
+		NotSent:           15,
+	}
+	if got != want {
+		t.Fatalf("buildOnboardingProgress() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildOnboardingProgress_MembersLeftAfterSend(t *testing.T) {
+	got := buildOnboardingProgress(repository.OnboardingProgressCounts{TotalSent: 5}, 3)
+	if got.NotSent != 0 {
+		t.Fatalf("NotSent = %d, want 0", got.NotSent)
+	}
+	if got.Pending != 5 {
+		t.Fatalf("Pending = %d, want 5", got.Pending)
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
+)
+
+var peopleCSVColumns = []string{
+	"slack_user_id",
+	"display_name",
+	"slack_handle",
+	"birthday_day",
+	"birthday_month",
+	"birthday_year",
+	"hire_date",
+	"public_celebration_opt_in",
+	"reminders_mode",
+}
+
+var requiredPeopleCSVColumns = []string{"slack_user_id"}
+
+// ColumnMap renames CSV header cells to people fields, for example
+// {"Employee ID": "slack_user_id", "Start Date": "hire_date"}.
+type ColumnMap map[string]string
+
+// mapPeopleCSVHeader resolves the header row of a people CSV to the column
+// index of each known field. Without a column map the header must use the
+// field names themselves. Unknown columns are ignored.
+func mapPeopleCSVHeader(header []string, columnMap ColumnMap) (map[string]int, error) {
+	known := make(map[string]struct{}, len(peopleCSVColumns))
+	for _, field := range peopleCSVColumns {
+		known[field] = struct{}{}
+	}
+
+	normalizedMap := make(map[string]string, len(columnMap))
+	for from, to := range columnMap {
+		to = strings.ToLower(strings.TrimSpace(to))
+		if _, ok := known[to]; !ok {
+			return nil, fmt.Errorf("column_map target %q is not a known field (expected one of %s)", to, strings.Join(peopleCSVColumns, ", "))
+		}
+		normalizedMap[normalizeCSVHeader(from)] = to
+	}
+
+	indexes := make(map[string]int, len(peopleCSVColumns))
+	for i, cell := range header {
+		name := normalizeCSVHeader(cell)
+		field := name
+		if mapped, ok := normalizedMap[name]; ok {
+			field = mapped
+		}
+		if _, ok := known[field]; !ok {
+			continue
+		}
+		if _, dup := indexes[field]; dup {
+			return nil, fmt.Errorf("column %q is mapped more than once", field)
+		}
+		indexes[field] = i
+	}
+
+	missing := make([]string, 0)
+	for _, field := range requiredPeopleCSVColumns {
+		if _, ok := indexes[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing required column(s): %s", strings.Join(missing, ", "))
+	}
+
+	return indexes, nil
+}
+
+// ParsePeopleCSV reads a people CSV with a header row into upsert inputs.
+// Rows that cannot be parsed are skipped and reported as "row N: reason",
+// where N is the line number in the file. Only an unreadable file or header fails the
+// whole parse.
+func ParsePeopleCSV(r io.Reader, workspaceID string, columnMap ColumnMap) ([]repository.UpsertPersonInput, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: csv is empty, a header row is required", ErrInvalidInput)
+		}
+		return nil, nil, fmt.Errorf("%w: read csv header: %v", ErrInvalidInput, err)
+	}
+
+	indexes, err := mapPeopleCSVHeader(header, columnMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	rows := make([]repository.UpsertPersonInput, 0)
+	rowErrors := make([]string, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, err.Error())
+			continue
+		}
+		if isBlankCSVRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		in, err := parsePeopleCSVRecord(record, indexes, workspaceID)
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("row %d: %v", line, err))
+			continue
+		}
+		rows = append(rows, in)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func parsePeopleCSVRecord(record []string, indexes map[string]int, workspaceID string) (repository.UpsertPersonInput, error) {
+	cell := func(field string) string {
+		i, ok := indexes[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	in := repository.UpsertPersonInput{
+		WorkspaceID:            workspaceID,
+		SlackUserID:            cell("slack_user_id"),
+		DisplayName:            cell("display_name"),
+		SlackHandle:            strings.TrimPrefix(cell("slack_handle"), "@"),
+		PublicCelebrationOptIn: true,
+	}
+	if in.SlackUserID == "" {
+		return repository.UpsertPersonInput{}, errors.New("slack_user_id is required")
+	}
+
+	var err error
+	if in.BirthdayDay, err = parseOptionalCSVInt(cell("birthday_day"), "birthday_day"); err != nil {
+		return repository.UpsertPersonInput{}, err
+	}
+	if in.BirthdayMonth, err = parseOptionalCSVInt(cell("birthday_month"), "birthday_month"); err != nil {
+		return repository.UpsertPersonInput{}, err
+	}
+	if in.BirthdayYear, err = parseOptionalCSVInt(cell("birthday_year"), "birthday_year"); err != nil {
+		return repository.UpsertPersonInput{}, err
+	}
+	if (in.BirthdayDay == nil) != (in.BirthdayMonth == nil) {
+		return repository.UpsertPersonInput{}, errors.New("birthday_day and birthday_month must be provided together")
+	}
+	if in.BirthdayDay != nil && !validDayMonth(*in.BirthdayDay, *in.BirthdayMonth) {
+		return repository.UpsertPersonInput{}, fmt.Errorf("birthday day %d is invalid for month %d", *in.BirthdayDay, *in.BirthdayMonth)
+	}
+
+	if raw := cell("public_celebration_opt_in"); raw != "" {
+		optIn, err := strconv.ParseBool(raw)
+		if err != nil {
+			return repository.UpsertPersonInput{}, fmt.Errorf("public_celebration_opt_in %q must be true or false", raw)
+		}
+		in.PublicCelebrationOptIn = optIn
+	}
+
+	switch mode := strings.ToLower(cell("reminders_mode")); mode {
+	case "", RemindersModeNone, RemindersModeSameDay, RemindersModeDayBefore, RemindersModeWeekBefore:
+		in.RemindersMode = mode
+	default:
+		return repository.UpsertPersonInput{}, fmt.Errorf("reminders_mode %q must be none|same_day|day_before|week_before", mode)
+	}
+
+	if raw := cell("hire_date"); raw != "" {
+		hireDate, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return repository.UpsertPersonInput{}, fmt.Errorf("hire_date %q must use YYYY-MM-DD", raw)
+		}
+		in.HireDate = &hireDate
+	}
+
+	return in, nil
+}
+
+// WritePeopleCSV writes people using the import column schema, so the output
+// can be imported again. With no people only the header row is written.
+func WritePeopleCSV(w io.Writer, people []domain.Person) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(peopleCSVColumns); err != nil {
+		return fmt.Errorf("write people csv header: %w", err)
+	}
+
+	for _, p := range people {
+		hireDate := ""
+		if p.HireDate != nil {
+			hireDate = p.HireDate.Format("2006-01-02")
+		}
+		if err := cw.Write([]string{
+			p.SlackUserID,
+			p.DisplayName,
+			p.SlackHandle,
+			formatOptionalCSVInt(p.BirthdayDay),
+			formatOptionalCSVInt(p.BirthdayMonth),
+			formatOptionalCSVInt(p.BirthdayYear),
+			hireDate,
+			strconv.FormatBool(p.PublicCelebrationOptIn),
+			p.RemindersMode,
+		}); err != nil {
+			return fmt.Errorf("write people csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush people csv: %w", err)
+	}
+	return nil
+}
+
+func formatOptionalCSVInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func parseOptionalCSVInt(raw, field string) (*int, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q is not a number", field, raw)
+	}
+	return &n, nil
+}
+
+func isBlankCSVRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeCSVHeader(cell string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+func TestMapPeopleCSVHeader_StrictHeaders(t *testing.T) {
+	got, err := mapPeopleCSVHeader([]string{"\ufeffSlack_User_ID", "display_name", "hire_date", "notes"}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]int{"slack_user_id": 0, "display_name": 1, "hire_date": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mapPeopleCSVHeader() = %v, want %v", got, want)
+	}
+}
+
+func TestMapPeopleCSVHeader_WithColumnMap(t *testing.T) {
+	header := []string{"Full Name", "Employee ID", "Start Date", "birthday_month", "Birth Day"}
+	columnMap := ColumnMap{
+		"employee id": "slack_user_id",
+		"Start Date":  "hire_date",
+		"Full Name":   "display_name",
+		"Birth Day":   "birthday_day",
+	}
+
+	got, err := mapPeopleCSVHeader(header, columnMap)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]int{
+		"display_name":   0,
+		"slack_user_id":  1,
+		"hire_date":      2,
+		"birthday_month": 3,
+		"birthday_day":   4,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mapPeopleCSVHeader() = %v, want %v", got, want)
+	}
+}
+
+func TestMapPeopleCSVHeader_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    []string
+		columnMap ColumnMap
+		wantErr   string
+	}{
+		{
+			name:    "strict headers without slack_user_id",
+			header:  []string{"Employee ID", "display_name"},
+			wantErr: "missing required column(s): slack_user_id",
+		},
+		{
+			name:      "column map does not cover required field",
+			header:    []string{"Employee ID", "Start Date"},
+			columnMap: ColumnMap{"Start Date": "hire_date"},
+			wantErr:   "missing required column(s): slack_user_id",
+		},
+		{
+			name:      "unknown target field",
+			header:    []string{"Employee ID"},
+			columnMap: ColumnMap{"Employee ID": "employee_number"},
+			wantErr:   "is not a known field",
+		},
+		{
+			name:      "two columns mapped to one field",
+			header:    []string{"Employee ID", "slack_user_id"},
+			columnMap: ColumnMap{"Employee ID": "slack_user_id"},
+			wantErr:   "mapped more than once",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := mapPeopleCSVHeader(tc.header, tc.columnMap)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %q, want it to contain %q", err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePeopleCSV_SkipsInvalidRows(t *testing.T) {
+	csv := strings.Join([]string{
+		"slack_user_id,display_name,slack_handle,birthday_day,birthday_month,birthday_year,hire_date",
+		"U1,Alice,@alice,14,3,1990,2021-06-01",
+		",Missing ID,nobody,,,,",
+		"U3,Bad Date,bad,31,2,,",
+		"U4,Bad Hire,hire,,,,06/01/2021",
+		"",
+		"U5,Only Name,,,,,",
+		"U6,,,0,1,,",
+	}, "\n")
+
+	rows, rowErrors, err := ParsePeopleCSV(strings.NewReader(csv), "ws-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rows) != 2 || rows[0].SlackUserID != "U1" || rows[1].SlackUserID != "U5" {
+		t.Fatalf("expected rows U1 and U5, got %#v", rows)
+	}
+	first := rows[0]
+	if first.WorkspaceID != "ws-1" || first.SlackHandle != "alice" || *first.BirthdayDay != 14 || *first.BirthdayMonth != 3 || *first.BirthdayYear != 1990 {
+		t.Fatalf("unexpected first row: %#v", first)
+	}
+	if first.HireDate == nil || first.HireDate.Format("2006-01-02") != "2021-06-01" {
+		t.Fatalf("unexpected hire date: %v", first.HireDate)
+	}
+	if rows[1].BirthdayDay != nil || rows[1].HireDate != nil {
+		t.Fatalf("expected omitted optional fields to stay nil, got %#v", rows[1])
+	}
+
+	wantPrefixes := []string{"row 3:", "row 4:", "row 5:", "row 8:"}
+	if len(rowErrors) != len(wantPrefixes) {
+		t.Fatalf("expected %d row errors, got %v", len(wantPrefixes), rowErrors)
+	}
+	for i, prefix := range wantPrefixes {
+		if !strings.HasPrefix(rowErrors[i], prefix) {
+			t.Fatalf("row error %d = %q, want prefix %q", i, rowErrors[i], prefix)
+		}
+	}
+}
+
+func TestParsePeopleCSV_RequiresHeader(t *testing.T) {
+	if _, _, err := ParsePeopleCSV(strings.NewReader(""), "ws-1", nil); err == nil {
+		t.Fatal("expected an error for an empty file")
+	}
+	if _, _, err := ParsePeopleCSV(strings.NewReader("display_name\nAlice\n"), "ws-1", nil); err == nil {
+		t.Fatal("expected an error when slack_user_id is missing from the header")
+	}
+}
+
+func TestWritePeopleCSV_RoundTripsThroughImport(t *testing.T) {
+	day, month := 14, 3
+	hireDate := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	people := []domain.Person{
+		{SlackUserID: "U1", DisplayName: "Alice, A.", SlackHandle: "alice", BirthdayDay: &day, BirthdayMonth: &month, HireDate: &hireDate, PublicCelebrationOptIn: false, RemindersMode: RemindersModeWeekBefore},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePeopleCSV(&buf, people); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "slack_user_id,display_name,slack_handle,birthday_day,birthday_month,birthday_year,hire_date,public_celebration_opt_in,reminders_mode\n" +
+		"U1,\"Alice, A.\",alice,14,3,,2021-06-01,false,week_before\n"
+	if buf.String() != want {
+		t.Fatalf("WritePeopleCSV() = %q, want %q", buf.String(), want)
+	}
+
+	rows, rowErrors, err := ParsePeopleCSV(strings.NewReader(buf.String()), "ws-1", nil)
+	if err != nil || len(rowErrors) != 0 || len(rows) != 1 {
+		t.Fatalf("expected one clean row, got rows=%d errors=%v err=%v", len(rows), rowErrors, err)
+	}
+	got := rows[0]
+	if got.DisplayName != "Alice, A." || got.BirthdayYear != nil || got.PublicCelebrationOptIn || got.RemindersMode != RemindersModeWeekBefore {
+		t.Fatalf("unexpected round-tripped row: %#v", got)
+	}
+}
+
+func TestWritePeopleCSV_EmptyWritesHeaderOnly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePeopleCSV(&buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], "slack_user_id,") {
+		t.Fatalf("expected only the header row, got %q", buf.String())
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+const (
+	RemindersModeNone       = "none"
+	RemindersModeSameDay    = "same_day"
+	RemindersModeDayBefore  = "day_before"
+	RemindersModeWeekBefore = "week_before"
+)
+
+// defaultReminderTime is used when the workspace has no channel to borrow a
+// posting time and timezone from.
+const defaultReminderTime = "09:00"
+
+type ScheduledReminder struct {
+	Type        string    `json:"type"`
+	FiresAt     time.Time `json:"fires_at"`
+	Description string    `json:"description"`
+}
+
+type ReminderService struct {
+	workspaceRepo   *repository.WorkspaceRepository
+	peopleRepo      *repository.PeopleRepository
+	reminderLogRepo *repository.ReminderLogRepository
+	slackClient     slack.Client
+	logger          *slog.Logger
+}
+
+func NewReminderService(
+	workspaceRepo *repository.WorkspaceRepository,
+	peopleRepo *repository.PeopleRepository,
+	reminderLogRepo *repository.ReminderLogRepository,
+	slackClient slack.Client,
+	logger *slog.Logger,
+) *ReminderService {
+	return &ReminderService{
+		workspaceRepo:   workspaceRepo,
+		peopleRepo:      peopleRepo,
+		reminderLogRepo: reminderLogRepo,
+		slackClient:     slackClient,
+		logger:          logger,
+	}
+}
+
+// RunDueReminders sends personal reminder DMs for every connected workspace
+// whose reminder time has passed today. Each reminder is sent at most once per
+// person, event and year.
+func (s *ReminderService) RunDueReminders(ctx context.Context, now time.Time) error {
+	workspaceIDs, err := s.workspaceRepo.ListConnectedWorkspaceIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workspaceID := range workspaceIDs {
+		if err := s.runWorkspaceReminders(ctx, workspaceID, now); err != nil {
+			s.logger.ErrorContext(ctx, "failed workspace reminder run",
+				slog.String("workspace_id", workspaceID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *ReminderService) runWorkspaceReminders(ctx context.Context, workspaceID string, now time.Time) error {
+	loc, postingTime, err := s.reminderSchedule(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	at, err := time.Parse("15:04", postingTime)
+	if err != nil {
+		return fmt.Errorf("invalid posting time %q: %w", postingTime, err)
+	}
+
+	localNow := now.In(loc)
+	if localNow.Before(time.Date(localNow.Year(), localNow.Month(), localNow.Day(), at.Hour(), at.Minute(), 0, 0, loc)) {
+		return nil
+	}
+
+	for _, mode := range []string{RemindersModeSameDay, RemindersModeDayBefore, RemindersModeWeekBefore} {
+		offsetDays, _, _ := reminderOffset(mode)
+		target := localNow.AddDate(0, 0, offsetDays)
+
+		people, err := s.peopleRepo.FindReminderCandidates(ctx, workspaceID, mode, int(target.Month()), target.Day())
+		if err != nil {
+			return err
+		}
+
+		for _, person := range people {
+			for _, event := range dueReminderEvents(person, mode, target) {
+				s.sendReminder(ctx, person, event, offsetDays)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ReminderService) sendReminder(ctx context.Context, person domain.Person, event reminderEvent, offsetDays int) {
+	claimed, err := s.reminderLogRepo.Claim(ctx, person.ID, event.Type, event.Year)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "claim reminder failed",
+			slog.String("workspace_id", person.WorkspaceID),
+			slog.String("slack_user_id", person.SlackUserID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if err := s.slackClient.SendDirectMessage(ctx, person.WorkspaceID, person.SlackUserID, reminderMessage(person, event, offsetDays)); err != nil {
+		s.logger.WarnContext(ctx, "send reminder dm failed",
+			slog.String("workspace_id", person.WorkspaceID),
+			slog.String("slack_user_id", person.SlackUserID),
+			slog.String("event_type", event.Type),
+			slog.String("error", err.Error()),
+		)
+		if err := s.reminderLogRepo.Release(ctx, person.ID, event.Type, event.Year); err != nil {
+			s.logger.ErrorContext(ctx, "release reminder failed",
+				slog.String("workspace_id", person.WorkspaceID),
+				slog.String("slack_user_id", person.SlackUserID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+type reminderEvent struct {
+	Type  string
+	Year  int
+	Years int
+}
+
+// dueReminderEvents lists the person's events on target that a reminder in
+// mode should announce. People using any other mode, including none, get none.
+func dueReminderEvents(person domain.Person, mode string, target time.Time) []reminderEvent {
+	if person.RemindersMode != mode || mode == RemindersModeNone {
+		return nil
+	}
+
+	events := make([]reminderEvent, 0, 2)
+	if person.BirthdayMonth != nil && person.BirthdayDay != nil &&
+		*person.BirthdayMonth == int(target.Month()) && *person.BirthdayDay == target.Day() {
+		events = append(events, reminderEvent{Type: domain.CelebrationTypeBirthday, Year: target.Year()})
+	}
+	if person.HireDate != nil {
+		hire := *person.HireDate
+		if hire.Month() == target.Month() && hire.Day() == target.Day() && target.Year() > hire.Year() {
+			events = append(events, reminderEvent{
+				Type:  domain.CelebrationTypeAnniversary,
+				Year:  target.Year(),
+				Years: target.Year() - hire.Year(),
+			})
+		}
+	}
+	return events
+}
+
+func reminderMessage(person domain.Person, event reminderEvent, offsetDays int) string {
+	when := "today"
+	switch offsetDays {
+	case 1:
+		when = "tomorrow"
+	case 7:
+		when = "one week from today"
+	}
+
+	name := fallbackString(person.DisplayName, person.SlackHandle, "there")
+	if event.Type == domain.CelebrationTypeAnniversary {
+		unit := "years"
+		if event.Years == 1 {
+			unit = "year"
+		}
+		return fmt.Sprintf("Hi %s! Your %d %s work anniversary is %s. :tada:", name, event.Years, unit, when)
+	}
+	return fmt.Sprintf("Hi %s! Your birthday is %s. :birthday:", name, when)
+}
+
+// reminderSchedule returns the timezone and posting time reminders follow:
+// the workspace's first channel, or UTC at the default time.
+func (s *ReminderService) reminderSchedule(ctx context.Context, workspaceID string) (*time.Location, string, error) {
+	channels, err := s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	loc := time.UTC
+	postingTime := defaultReminderTime
+	if len(channels) > 0 {
+		if l, err := time.LoadLocation(channels[0].Timezone); err == nil {
+			loc = l
+		}
+		postingTime = channels[0].PostingTime
+	}
+	return loc, postingTime, nil
+}
+
+// ListScheduledReminders computes when the person's next birthday and work
+// anniversary reminders would fire. Reminders follow the workspace's first
+// channel posting time and timezone. Nothing is persisted.
+func (s *ReminderService) ListScheduledReminders(ctx context.Context, workspaceID, slackUserID string) ([]ScheduledReminder, error) {
+	person, err := s.peopleRepo.GetByWorkspaceAndSlackUserID(ctx, workspaceID, slackUserID)
+	if err != nil {
+		return nil, err
+	}
+	if person.RemindersMode == RemindersModeNone {
+		return []ScheduledReminder{}, nil
+	}
+
+	loc, postingTime, err := s.reminderSchedule(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledReminders(person, loc, postingTime, time.Now())
+}
+
+func scheduledReminders(person domain.Person, loc *time.Location, postingTime string, now time.Time) ([]ScheduledReminder, error) {
+	offsetDays, label, ok := reminderOffset(person.RemindersMode)
+	if !ok {
+		return []ScheduledReminder{}, nil
+	}
+
+	at, err := time.Parse("15:04", postingTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid posting time %q: %w", postingTime, err)
+	}
+
+	now = now.In(loc)
+	reminders := make([]ScheduledReminder, 0, 2)
+
+	if person.BirthdayMonth != nil && person.BirthdayDay != nil {
+		firesAt := nextReminderTime(*person.BirthdayMonth, *person.BirthdayDay, 0, offsetDays, at, loc, now)
+		reminders = append(reminders, ScheduledReminder{
+			Type:        "birthday_reminder",
+			FiresAt:     firesAt,
+			Description: label + " your birthday",
+		})
+	}
+
+	if person.HireDate != nil {
+		hire := *person.HireDate
+		firesAt := nextReminderTime(int(hire.Month()), hire.Day(), hire.Year()+1, offsetDays, at, loc, now)
+		reminders = append(reminders, ScheduledReminder{
+			Type:        "anniversary_reminder",
+			FiresAt:     firesAt,
+			Description: label + " your work anniversary",
+		})
+	}
+
+	sort.Slice(reminders, func(i, j int) bool { return reminders[i].FiresAt.Before(reminders[j].FiresAt) })
+	return reminders, nil
+}
+
+func reminderOffset(mode string) (days int, label string, ok bool) {
+	switch mode {
+	case RemindersModeSameDay:
+		return 0, "On", true
+	case RemindersModeDayBefore:
+		return 1, "Day before", true
+	case RemindersModeWeekBefore:
+		return 7, "Week before", true
+	default:
+		return 0, "", false
+	}
+}
+
+// nextReminderTime finds the first yearly occurrence of month/day, in minYear
+// or later, whose reminder (offsetDays earlier at the posting time) is still
+// ahead of now. Feb 29 falls back to Feb 28 in common years.
+func nextReminderTime(month, day, minYear, offsetDays int, at time.Time, loc *time.Location, now time.Time) time.Time {
+	year := now.Year()
+	if year < minYear {
+		year = minYear
+	}
+
+	for {
+		d := day
+		if month == int(time.February) && day == 29 && !isLeapYear(year) {
+			d = 28
+		}
+		event := time.Date(year, time.Month(month), d, at.Hour(), at.Minute(), 0, 0, loc)
+		firesAt := event.AddDate(0, 0, -offsetDays)
+		if firesAt.After(now) {
+			return firesAt
+		}
+		year++
+	}
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestScheduledReminders(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	hire := time.Date(2022, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		person domain.Person
+		want   []ScheduledReminder
+	}{
+		{
+			name: "day before birthday later this year",
+			person: domain.Person{
+				RemindersMode: RemindersModeDayBefore,
+				BirthdayMonth: intPtr(3),
+				BirthdayDay:   intPtr(25),
+			},
+			want: []ScheduledReminder{{
+				Type:        "birthday_reminder",
+				FiresAt:     time.Date(2025, time.March, 24, 9, 0, 0, 0, time.UTC),
+				Description: "Day before your birthday",
+			}},
+		},
+		{
+			name: "week before already passed rolls to next year",
+			person: domain.Person{
+				RemindersMode: RemindersModeWeekBefore,
+				BirthdayMonth: intPtr(3),
+				BirthdayDay:   intPtr(12),
+			},
+			want: []ScheduledReminder{{
+				Type:        "birthday_reminder",
+				FiresAt:     time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC),
+				Description: "Week before your birthday",
+			}},
+		},
+		{
+			name: "same day birthday and anniversary sorted by time",
+			person: domain.Person{
+				RemindersMode: RemindersModeSameDay,
+				BirthdayMonth: intPtr(6),
+				BirthdayDay:   intPtr(1),
+				HireDate:      &hire,
+			},
+			want: []ScheduledReminder{
+				{
+					Type:        "anniversary_reminder",
+					FiresAt:     time.Date(2025, time.March, 20, 9, 0, 0, 0, time.UTC),
+					Description: "On your work anniversary",
+				},
+				{
+					Type:        "birthday_reminder",
+					FiresAt:     time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC),
+					Description: "On your birthday",
+				},
+			},
+		},
+		{
+			name: "leap day birthday in a common year",
+			person: domain.Person{
+				RemindersMode: RemindersModeSameDay,
+				BirthdayMonth: intPtr(2),
+				BirthdayDay:   intPtr(29),
+			},
+			want: []ScheduledReminder{{
+				Type:        "birthday_reminder",
+				FiresAt:     time.Date(2026, time.February, 28, 9, 0, 0, 0, time.UTC),
+				Description: "On your birthday",
+			}},
+		},
+		{
+			name:   "none mode has no reminders",
+			person: domain.Person{RemindersMode: RemindersModeNone, BirthdayMonth: intPtr(3), BirthdayDay: intPtr(25)},
+			want:   []ScheduledReminder{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := scheduledReminders(tc.person, time.UTC, "09:00", now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d reminders, want %d: %+v", len(got), len(tc.want), got)
+			}
+			for i := range got {
+				if got[i].Type != tc.want[i].Type || !got[i].FiresAt.Equal(tc.want[i].FiresAt) || got[i].Description != tc.want[i].Description {
+					t.Fatalf("reminder %d = %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScheduledReminders_AnniversarySkipsHireYear(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	hire := time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	got, err := scheduledReminders(domain.Person{RemindersMode: RemindersModeSameDay, HireDate: &hire}, time.UTC, "09:00", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	if len(got) != 1 || !got[0].FiresAt.Equal(want) {
+		t.Fatalf("got %+v, want first anniversary reminder at %s", got, want)
+	}
+}
+
+func TestDueReminderEvents(t *testing.T) {
+	target := time.Date(2025, time.March, 25, 9, 0, 0, 0, time.UTC)
+	hire := time.Date(2021, time.March, 25, 0, 0, 0, 0, time.UTC)
+
+	birthday := domain.Person{RemindersMode: RemindersModeSameDay, BirthdayMonth: intPtr(3), BirthdayDay: intPtr(25), HireDate: &hire}
+	events := dueReminderEvents(birthday, RemindersModeSameDay, target)
+	if len(events) != 2 {
+		t.Fatalf("expected birthday and anniversary events, got %+v", events)
+	}
+	if events[0].Type != domain.CelebrationTypeBirthday || events[1].Type != domain.CelebrationTypeAnniversary || events[1].Years != 4 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if events := dueReminderEvents(birthday, RemindersModeDayBefore, target); len(events) != 0 {
+		t.Fatalf("expected no events for a different mode, got %+v", events)
+	}
+}
+
+func TestDueReminderEvents_NoneModeNeverReminds(t *testing.T) {
+	target := time.Date(2025, time.March, 25, 9, 0, 0, 0, time.UTC)
+	person := domain.Person{RemindersMode: RemindersModeNone, BirthdayMonth: intPtr(3), BirthdayDay: intPtr(25)}
+
+	for _, mode := range []string{RemindersModeNone, RemindersModeSameDay, RemindersModeDayBefore, RemindersModeWeekBefore} {
+		if events := dueReminderEvents(person, mode, target); len(events) != 0 {
+			t.Fatalf("mode %q: expected no events, got %+v", mode, events)
+		}
+	}
+}
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -11,6 +16,7 @@ import (
 
 	"slackcheers/internal/config"
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
 const slackOAuthAccessURL = "https://slack.com/api/oauth.v2.access"
@@ -18,9 +24,16 @@ const slackOAuthAccessURL = "https://slack.com/api/oauth.v2.access"
 type SlackAuthService struct {
 	cfg           config.SlackConfig
 	workspaceRepo *repository.WorkspaceRepository
+	stateStore    StateStore
+	memberSync    *SlackMemberSyncService
+	slackClient   slack.Client
 	httpClient    *http.Client
+	logger        *slog.Logger
 }
 
+// installMemberSyncTimeout bounds the member sync started by a new install.
+const installMemberSyncTimeout = 5 * time.Minute
+
 type SlackOAuthResult struct {
 	WorkspaceID string `json:"workspace_id"`
 	TeamID      string `json:"team_id"`
@@ -44,31 +57,55 @@ type slackOAuthAccessResponse struct {
 	} `json:"authed_user"`
 }
 
-func NewSlackAuthService(cfg config.SlackConfig, workspaceRepo *repository.WorkspaceRepository) *SlackAuthService {
+func NewSlackAuthService(
+	cfg config.SlackConfig,
+	workspaceRepo *repository.WorkspaceRepository,
+	stateStore StateStore,
+	memberSync *SlackMemberSyncService,
+	slackClient slack.Client,
+	logger *slog.Logger,
+) *SlackAuthService {
 	return &SlackAuthService{
 		cfg:           cfg,
 		workspaceRepo: workspaceRepo,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		stateStore:    stateStore,
+		memberSync:    memberSync,
+		slackClient:   slackClient,
+		httpClient:    slack.NewHTTPClient(10*time.Second, logger),
+		logger:        logger,
 	}
 }
 
-func (s *SlackAuthService) InstallURL(state string) (string, error) {
+// RedirectURL is the configured OAuth callback URL.
+func (s *SlackAuthService) RedirectURL() string {
+	return s.cfg.RedirectURL
+}
+
+// InstallURL builds the Slack consent URL and records a freshly generated
+// state for the callback. The state is returned alongside the URL so the
+// caller can bind it to the browser with StateCookie.
+func (s *SlackAuthService) InstallURL() (string, string, error) {
 	if strings.TrimSpace(s.cfg.ClientID) == "" {
-		return "", fmt.Errorf("SLACK_CLIENT_ID is required")
+		return "", "", fmt.Errorf("SLACK_CLIENT_ID is required")
+	}
+	if strings.TrimSpace(s.cfg.ClientSecret) == "" {
+		return "", "", fmt.Errorf("SLACK_CLIENT_SECRET is required")
 	}
 	if strings.TrimSpace(s.cfg.RedirectURL) == "" {
-		return "", fmt.Errorf("SLACK_REDIRECT_URL is required")
+		return "", "", fmt.Errorf("SLACK_REDIRECT_URL is required")
 	}
 
-	if strings.TrimSpace(state) == "" {
-		state = fmt.Sprintf("state-%d", time.Now().UnixNano())
+	state, err := newOAuthState()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.stateStore.Save(state); err != nil {
+		return "", "", fmt.Errorf("save oauth state: %w", err)
 	}
 
 	botScopes := strings.TrimSpace(s.cfg.BotScopes)
 	if botScopes == "" {
-		botScopes = "chat:write,channels:read,users:read"
+		botScopes = "chat:write,channels:read,users:read,reactions:write,app_mentions:read"
 	}
 
 	q := url.Values{}
@@ -80,10 +117,58 @@ func (s *SlackAuthService) InstallURL(state string) (string, error) {
 		q.Set("user_scope", strings.TrimSpace(s.cfg.UserScopes))
 	}
 
-	return "https://slack.com/oauth/v2/authorize?" + q.Encode(), nil
+	return "https://slack.com/oauth/v2/authorize?" + q.Encode(), state, nil
 }
 
-func (s *SlackAuthService) ExchangeCode(ctx context.Context, code string) (SlackOAuthResult, error) {
+func newOAuthState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate oauth state: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// StateCookie returns the cookie value that binds state to the browser that
+// started the install: the state and its HMAC under the client secret.
+func (s *SlackAuthService) StateCookie(state string) string {
+	return state + "." + s.signState(state)
+}
+
+func (s *SlackAuthService) signState(state string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.ClientSecret))
+	_, _ = mac.Write([]byte(state))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// stateMatchesCookie reports whether cookie was issued by StateCookie for
+// state.
+func (s *SlackAuthService) stateMatchesCookie(state, cookie string) bool {
+	cookieState, signature, ok := strings.Cut(cookie, ".")
+	if !ok || state == "" || strings.TrimSpace(s.cfg.ClientSecret) == "" {
+		return false
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signState(cookieState))) {
+		return false
+	}
+	return hmac.Equal([]byte(cookieState), []byte(state))
+}
+
+// ExchangeCode completes the OAuth flow. The state must match the signed
+// cookie set by the install request and must have been issued by InstallURL.
+func (s *SlackAuthService) ExchangeCode(ctx context.Context, code, state, stateCookie string) (SlackOAuthResult, error) {
+	state = strings.TrimSpace(state)
+	if !s.stateMatchesCookie(state, stateCookie) {
+		return SlackOAuthResult{}, ErrInvalidOAuthState
+	}
+
+	valid, err := s.stateStore.Validate(state)
+	if err != nil {
+		return SlackOAuthResult{}, fmt.Errorf("validate oauth state: %w", err)
+	}
+	if !valid {
+		return SlackOAuthResult{}, ErrInvalidOAuthState
+	}
+
 	if strings.TrimSpace(s.cfg.ClientID) == "" {
 		return SlackOAuthResult{}, fmt.Errorf("SLACK_CLIENT_ID is required")
 	}
@@ -139,6 +224,11 @@ func (s *SlackAuthService) ExchangeCode(ctx context.Context, code string) (Slack
 	if err != nil {
 		return SlackOAuthResult{}, err
 	}
+	// A reinstall issues a new bot token; drop the old one so calls do not keep
+	// using it until the cache expires.
+	s.slackClient.InvalidateTokenCache(workspace.ID)
+
+	s.syncMembersInBackground(workspace.ID)
 
 	return SlackOAuthResult{
 		WorkspaceID: workspace.ID,
@@ -148,3 +238,22 @@ func (s *SlackAuthService) ExchangeCode(ctx context.Context, code string) (Slack
 		Scope:       payload.Scope,
 	}, nil
 }
+
+// syncMembersInBackground fills people for a fresh install so listings are not
+// empty until the scheduler's next member sync. It outlives the callback
+// request.
+func (s *SlackAuthService) syncMembersInBackground(workspaceID string) {
+	if s.memberSync == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), installMemberSyncTimeout)
+		defer cancel()
+		if err := s.memberSync.SyncWorkspaceMembers(ctx, workspaceID); err != nil {
+			s.logger.ErrorContext(ctx, "initial member sync failed",
+				slog.String("workspace_id", workspaceID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"slackcheers/internal/config"
+)
+
+func newTestSlackAuthService() *SlackAuthService {
+	cfg := config.SlackConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/auth/slack/callback",
+	}
+	return NewSlackAuthService(cfg, nil, NewMemoryStateStore(), nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestSlackAuthService_StateCookieBindsState(t *testing.T) {
+	svc := newTestSlackAuthService()
+
+	_, state, err := svc.InstallURL()
+	if err != nil {
+		t.Fatalf("install url: %v", err)
+	}
+	cookie := svc.StateCookie(state)
+
+	if !svc.stateMatchesCookie(state, cookie) {
+		t.Fatal("expected the issued cookie to match its state")
+	}
+
+	_, other, err := svc.InstallURL()
+	if err != nil {
+		t.Fatalf("install url: %v", err)
+	}
+	if svc.stateMatchesCookie(other, cookie) {
+		t.Fatal("expected a cookie from another install to be rejected")
+	}
+	if svc.stateMatchesCookie(other, other+".forged") {
+		t.Fatal("expected an unsigned cookie to be rejected")
+	}
+}
+
+func TestSlackAuthService_ExchangeCodeRejectsMissingCookie(t *testing.T) {
+	svc := newTestSlackAuthService()
+
+	_, state, err := svc.InstallURL()
+	if err != nil {
+		t.Fatalf("install url: %v", err)
+	}
+
+	_, err = svc.ExchangeCode(context.Background(), "code", state, "")
+	if !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("got %v, want ErrInvalidOAuthState", err)
+	}
+	if ok, _ := svc.stateStore.Validate(state); !ok {
+		t.Fatal("expected a rejected callback to leave the state unused")
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
 
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
 type SlackChannelCleanupService struct {
@@ -30,12 +33,10 @@ type ChannelCleanupResult struct {
 	FailedDetails  map[string]string `json:"failed_details"`
 }
 
-func NewSlackChannelCleanupService(workspaceRepo *repository.WorkspaceRepository) *SlackChannelCleanupService {
+func NewSlackChannelCleanupService(workspaceRepo *repository.WorkspaceRepository, logger *slog.Logger) *SlackChannelCleanupService {
 	return &SlackChannelCleanupService{
 		workspaceRepo: workspaceRepo,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
+		httpClient:    slack.NewHTTPClient(15*time.Second, logger),
 	}
 }
 
@@ -45,7 +46,7 @@ func (s *SlackChannelCleanupService) CleanupBirthdayMessages(
 ) (ChannelCleanupResult, error) {
 	channelID = strings.TrimSpace(channelID)
 	if channelID == "" {
-		return ChannelCleanupResult{}, fmt.Errorf("channel_id is required")
+		return ChannelCleanupResult{}, fmt.Errorf("%w: channel_id is required", ErrInvalidInput)
 	}
 
 	match = strings.TrimSpace(match)
@@ -58,7 +59,7 @@ func (s *SlackChannelCleanupService) CleanupBirthdayMessages(
 		return ChannelCleanupResult{}, err
 	}
 	if strings.TrimSpace(install.BotToken) == "" {
-		return ChannelCleanupResult{}, fmt.Errorf("workspace is not connected to Slack yet")
+		return ChannelCleanupResult{}, ErrNotConnected
 	}
 
 	slackChannelID, err := s.resolveSlackChannelID(ctx, workspaceID, channelID)
@@ -81,7 +82,7 @@ func (s *SlackChannelCleanupService) CleanupBirthdayMessages(
 	}
 
 	for _, msg := range messages {
-		if !isBotAuthoredDMMessage(msg, install.BotUserID) {
+		if !isOwnBotChannelMessage(msg, install.BotUserID) {
 			continue
 		}
 		if !strings.Contains(strings.ToLower(msg.Text), strings.ToLower(match)) {
@@ -102,16 +103,34 @@ func (s *SlackChannelCleanupService) CleanupBirthdayMessages(
 	return result, nil
 }
 
+// isOwnBotChannelMessage narrows isBotAuthoredDMMessage for shared channels,
+// where other apps' messages also carry a bot_id and must not be deleted.
+func isOwnBotChannelMessage(msg slackDMMessage, botUserID string) bool {
+	if !isBotAuthoredDMMessage(msg, botUserID) {
+		return false
+	}
+	botUserID = strings.TrimSpace(botUserID)
+	if botUserID == "" {
+		return true
+	}
+	return strings.TrimSpace(msg.User) == botUserID
+}
+
 func (s *SlackChannelCleanupService) resolveSlackChannelID(ctx context.Context, workspaceID, channelID string) (string, error) {
-	channels, err := s.workspaceRepo.ListChannelsByWorkspace(ctx, workspaceID)
-	if err != nil {
+	ch, err := s.workspaceRepo.FindChannelBySlackID(ctx, workspaceID, channelID)
+	if err == nil {
+		return ch.SlackChannelID, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
 		return "", err
 	}
 
-	for _, ch := range channels {
-		if ch.ID == channelID || ch.SlackChannelID == channelID {
-			return ch.SlackChannelID, nil
-		}
+	ch, err = s.workspaceRepo.GetChannel(ctx, workspaceID, channelID)
+	if err == nil {
+		return ch.SlackChannelID, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return "", err
 	}
 
 	// If no configured channel match is found, assume caller passed a raw Slack channel ID.
@@ -167,7 +186,7 @@ func (s *SlackChannelCleanupService) listChannelHistoryPage(ctx context.Context,
 		if parsed.Error == "" {
 			parsed.Error = "conversations.history failed"
 		}
-		return nil, "", fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return nil, "", &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	messages := make([]slackDMMessage, 0, len(parsed.Messages))
@@ -212,7 +231,7 @@ func (s *SlackChannelCleanupService) deleteMessage(ctx context.Context, botToken
 		if parsed.Error == "" {
 			parsed.Error = "chat.delete failed"
 		}
-		return fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	return nil
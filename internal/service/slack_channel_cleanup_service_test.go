@@ -43,3 +43,52 @@ func TestChannelCleanupMatchLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestIsOwnBotChannelMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       slackDMMessage
+		botUserID string
+		want      bool
+	}{
+		{
+			name:      "own bot message",
+			msg:       slackDMMessage{TS: "1.1", User: "U_BOT", BotID: "B_OWN", Text: "Happy birthday"},
+			botUserID: "U_BOT",
+			want:      true,
+		},
+		{
+			name:      "other app message in shared channel",
+			msg:       slackDMMessage{TS: "1.2", User: "U_OTHER_BOT", BotID: "B_OTHER", Text: "Happy birthday"},
+			botUserID: "U_BOT",
+			want:      false,
+		},
+		{
+			name:      "legacy bot message without user",
+			msg:       slackDMMessage{TS: "1.3", BotID: "B_OTHER", Subtype: "bot_message", Text: "Happy birthday"},
+			botUserID: "U_BOT",
+			want:      false,
+		},
+		{
+			name:      "any bot message when bot user unknown",
+			msg:       slackDMMessage{TS: "1.4", User: "U_OTHER_BOT", BotID: "B_OTHER", Text: "Happy birthday"},
+			botUserID: "",
+			want:      true,
+		},
+		{
+			name:      "human message",
+			msg:       slackDMMessage{TS: "1.5", User: "U_USER", Text: "happy birthday everyone"},
+			botUserID: "U_BOT",
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isOwnBotChannelMessage(tt.msg, tt.botUserID)
+			if got != tt.want {
+				t.Fatalf("unexpected own bot result: got=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,28 +1,39 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
 
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
-const slackConversationsListURL = "https://slack.com/api/conversations.list"
+const (
+	slackConversationsListURL = "https://slack.com/api/conversations.list"
+	slackConversationsInfoURL = "https://slack.com/api/conversations.info"
+	slackConversationsJoinURL = "https://slack.com/api/conversations.join"
+)
 
 type SlackChannelsService struct {
 	workspaceRepo *repository.WorkspaceRepository
 	httpClient    *http.Client
+	paging        slackPaging
+	logger        *slog.Logger
 }
 
 type SlackChannel struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	IsPrivate bool   `json:"is_private"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPrivate  bool   `json:"is_private"`
+	IsMember   bool   `json:"is_member"`
+	IsArchived bool   `json:"is_archived"`
 }
 
 type slackConversationsListResponse struct {
@@ -34,6 +45,7 @@ type slackConversationsListResponse struct {
 		ID         string `json:"id"`
 		Name       string `json:"name"`
 		IsPrivate  bool   `json:"is_private"`
+		IsMember   bool   `json:"is_member"`
 		IsArchived bool   `json:"is_archived"`
 	} `json:"channels"`
 	ResponseMetadata struct {
@@ -41,12 +53,26 @@ type slackConversationsListResponse struct {
 	} `json:"response_metadata"`
 }
 
-func NewSlackChannelsService(workspaceRepo *repository.WorkspaceRepository) *SlackChannelsService {
+type slackConversationsInfoResponse struct {
+	OK       bool   `json:"ok"`
+	Error    string `json:"error"`
+	Needed   string `json:"needed"`
+	Provided string `json:"provided"`
+	Channel  struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		IsPrivate  bool   `json:"is_private"`
+		IsMember   bool   `json:"is_member"`
+		IsArchived bool   `json:"is_archived"`
+	} `json:"channel"`
+}
+
+func NewSlackChannelsService(workspaceRepo *repository.WorkspaceRepository, pageSize, maxPages int, logger *slog.Logger) *SlackChannelsService {
 	return &SlackChannelsService{
 		workspaceRepo: workspaceRepo,
-		httpClient: &http.Client{
-			Timeout: 12 * time.Second,
-		},
+		httpClient:    slack.NewHTTPClient(12*time.Second, logger),
+		paging:        newSlackPaging(pageSize, maxPages),
+		logger:        logger,
 	}
 }
 
@@ -56,22 +82,29 @@ func (s *SlackChannelsService) ListChannels(ctx context.Context, workspaceID str
 		return nil, err
 	}
 	if strings.TrimSpace(installation.BotToken) == "" {
-		return nil, fmt.Errorf("workspace is not connected to Slack yet")
+		return nil, ErrNotConnected
 	}
 
 	channels := make([]SlackChannel, 0)
 	cursor := ""
-	for i := 0; i < 10; i++ {
+	for i := 0; i < s.paging.maxPages; i++ {
 		page, nextCursor, err := s.listChannelsPage(ctx, installation.BotToken, cursor)
 		if err != nil {
 			return nil, err
 		}
 		channels = append(channels, page...)
 
-		if strings.TrimSpace(nextCursor) == "" {
+		cursor = strings.TrimSpace(nextCursor)
+		if cursor == "" {
 			break
 		}
-		cursor = nextCursor
+	}
+	if cursor != "" {
+		s.logger.WarnContext(ctx, "Slack channel page limit reached; results may be incomplete.",
+			slog.String("workspace_id", workspaceID),
+			slog.Int("max_pages", s.paging.maxPages),
+			slog.Int("channels", len(channels)),
+		)
 	}
 
 	sort.Slice(channels, func(i, j int) bool {
@@ -90,7 +123,7 @@ func (s *SlackChannelsService) listChannelsPage(ctx context.Context, botToken, c
 	q := req.URL.Query()
 	q.Set("types", "public_channel")
 	q.Set("exclude_archived", "true")
-	q.Set("limit", "200")
+	q.Set("limit", s.paging.limit())
 	if strings.TrimSpace(cursor) != "" {
 		q.Set("cursor", cursor)
 	}
@@ -113,7 +146,7 @@ func (s *SlackChannelsService) listChannelsPage(ctx context.Context, botToken, c
 		if payload.Error == "" {
 			payload.Error = "conversations.list failed"
 		}
-		return nil, "", fmt.Errorf("slack api error: %s%s", payload.Error, slackScopeHint(payload.Needed, payload.Provided))
+		return nil, "", &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
 	}
 
 	channels := make([]SlackChannel, 0, len(payload.Channels))
@@ -125,8 +158,123 @@ func (s *SlackChannelsService) listChannelsPage(ctx context.Context, botToken, c
 			ID:        ch.ID,
 			Name:      ch.Name,
 			IsPrivate: ch.IsPrivate,
+			IsMember:  ch.IsMember,
 		})
 	}
 
 	return channels, payload.ResponseMetadata.NextCursor, nil
 }
+
+func (s *SlackChannelsService) GetChannelInfo(ctx context.Context, workspaceID, slackChannelID string) (SlackChannel, error) {
+	botToken, err := s.botToken(ctx, workspaceID)
+	if err != nil {
+		return SlackChannel{}, err
+	}
+	return s.channelInfo(ctx, botToken, slackChannelID)
+}
+
+// EnsureBotInChannel verifies the bot can post to the channel, joining it when
+// possible. It returns ErrBotNotInChannel when the bot is not a member and the
+// join fails (for example private or archived channels).
+func (s *SlackChannelsService) EnsureBotInChannel(ctx context.Context, workspaceID, slackChannelID string) (SlackChannel, error) {
+	botToken, err := s.botToken(ctx, workspaceID)
+	if err != nil {
+		return SlackChannel{}, err
+	}
+
+	channel, err := s.channelInfo(ctx, botToken, slackChannelID)
+	if err != nil {
+		return SlackChannel{}, err
+	}
+	if channel.IsMember {
+		return channel, nil
+	}
+	if channel.IsArchived {
+		return SlackChannel{}, ErrBotNotInChannel
+	}
+
+	if err := s.joinChannel(ctx, botToken, slackChannelID); err != nil {
+		return SlackChannel{}, fmt.Errorf("%w: %v", ErrBotNotInChannel, err)
+	}
+
+	channel.IsMember = true
+	return channel, nil
+}
+
+func (s *SlackChannelsService) botToken(ctx context.Context, workspaceID string) (string, error) {
+	installation, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(installation.BotToken) == "" {
+		return "", ErrNotConnected
+	}
+	return installation.BotToken, nil
+}
+
+func (s *SlackChannelsService) channelInfo(ctx context.Context, botToken, slackChannelID string) (SlackChannel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, slackConversationsInfoURL, nil)
+	if err != nil {
+		return SlackChannel{}, fmt.Errorf("build slack conversations.info request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Set("channel", slackChannelID)
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return SlackChannel{}, fmt.Errorf("call slack conversations.info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload slackConversationsInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return SlackChannel{}, fmt.Errorf("decode slack conversations.info response: %w", err)
+	}
+	if !payload.OK {
+		if payload.Error == "" {
+			payload.Error = "conversations.info failed"
+		}
+		return SlackChannel{}, &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
+	}
+
+	return SlackChannel{
+		ID:         payload.Channel.ID,
+		Name:       payload.Channel.Name,
+		IsPrivate:  payload.Channel.IsPrivate,
+		IsMember:   payload.Channel.IsMember,
+		IsArchived: payload.Channel.IsArchived,
+	}, nil
+}
+
+func (s *SlackChannelsService) joinChannel(ctx context.Context, botToken, slackChannelID string) error {
+	body, _ := json.Marshal(map[string]string{"channel": slackChannelID})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackConversationsJoinURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build slack conversations.join request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+botToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("call slack conversations.join: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload slackConversationsInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("decode slack conversations.join response: %w", err)
+	}
+	if !payload.OK {
+		if payload.Error == "" {
+			payload.Error = "conversations.join failed"
+		}
+		return &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
+	}
+
+	return nil
+}
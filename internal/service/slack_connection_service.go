@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
+)
+
+const (
+	slackAuthTestURL = "https://slack.com/api/auth.test"
+
+	connectionStatusTTL = 60 * time.Second
+)
+
+// ConnectionStatus reports whether a workspace's stored bot token still works.
+// Error carries the Slack error code (for example token_revoked) when it does
+// not.
+type ConnectionStatus struct {
+	Connected bool
+	BotUserID string
+	TeamID    string
+	Error     string
+}
+
+type slackAuthTestResponse struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error"`
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id"`
+}
+
+type cachedConnectionStatus struct {
+	status    ConnectionStatus
+	expiresAt time.Time
+}
+
+// SlackConnectionService checks bot tokens with auth.test. Results are cached
+// in-process so dashboards polling the status do not hammer the Slack API.
+type SlackConnectionService struct {
+	workspaceRepo *repository.WorkspaceRepository
+	httpClient    *http.Client
+	ttl           time.Duration
+	now           func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedConnectionStatus
+}
+
+func NewSlackConnectionService(workspaceRepo *repository.WorkspaceRepository, logger *slog.Logger) *SlackConnectionService {
+	return &SlackConnectionService{
+		workspaceRepo: workspaceRepo,
+		httpClient:    slack.NewHTTPClient(12*time.Second, logger),
+		ttl:           connectionStatusTTL,
+		now:           time.Now,
+		cache:         make(map[string]cachedConnectionStatus),
+	}
+}
+
+func (s *SlackConnectionService) ConnectionStatus(ctx context.Context, workspaceID string) (ConnectionStatus, error) {
+	if status, ok := s.cached(workspaceID); ok {
+		return status, nil
+	}
+
+	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return ConnectionStatus{}, err
+	}
+
+	status := ConnectionStatus{TeamID: install.SlackTeamID, Error: "no bot token"}
+	if token := strings.TrimSpace(install.BotToken); token != "" {
+		status, err = s.authTest(ctx, token)
+		if err != nil {
+			return ConnectionStatus{}, err
+		}
+	}
+
+	s.store(workspaceID, status)
+	return status, nil
+}
+
+func (s *SlackConnectionService) authTest(ctx context.Context, botToken string) (ConnectionStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackAuthTestURL, nil)
+	if err != nil {
+		return ConnectionStatus{}, fmt.Errorf("build slack auth.test request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return ConnectionStatus{}, fmt.Errorf("call slack auth.test: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload slackAuthTestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return ConnectionStatus{}, fmt.Errorf("decode slack auth.test response: %w", err)
+	}
+	if !payload.OK {
+		if payload.Error == "" {
+			payload.Error = "auth.test failed"
+		}
+		return ConnectionStatus{Error: payload.Error}, nil
+	}
+
+	return ConnectionStatus{
+		Connected: true,
+		BotUserID: payload.UserID,
+		TeamID:    payload.TeamID,
+	}, nil
+}
+
+func (s *SlackConnectionService) cached(workspaceID string) (ConnectionStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[workspaceID]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return ConnectionStatus{}, false
+	}
+	return entry.status, true
+}
+
+func (s *SlackConnectionService) store(workspaceID string, status ConnectionStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, id)
+		}
+	}
+	s.cache[workspaceID] = cachedConnectionStatus{status: status, expiresAt: now.Add(s.ttl)}
+}
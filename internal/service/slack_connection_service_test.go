@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func authTestClient(body string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+}
+
+func TestAuthTest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want ConnectionStatus
+	}{
+		{
+			name: "valid token",
+			body: `{"ok":true,"team_id":"T1","user_id":"U_BOT"}`,
+			want: ConnectionStatus{Connected: true, BotUserID: "U_BOT", TeamID: "T1"},
+		},
+		{
+			name: "revoked token",
+			body: `{"ok":false,"error":"token_revoked"}`,
+			want: ConnectionStatus{Error: "token_revoked"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &SlackConnectionService{httpClient: authTestClient(tc.body)}
+			got, err := s.authTest(context.Background(), "xoxb-test")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("authTest() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConnectionStatusCache_ExpiresAfterTTL(t *testing.T) {
+	now := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	s := &SlackConnectionService{
+		ttl:   connectionStatusTTL,
+		now:   func() time.Time { return now },
+		cache: make(map[string]cachedConnectionStatus),
+	}
+
+	s.store("W1", ConnectionStatus{Connected: true, TeamID: "T1"})
+
+	now = now.Add(59 * time.Second)
+	if status, ok := s.cached("W1"); !ok || !status.Connected {
+		t.Fatalf("expected a cached status within the TTL, got %#v (ok=%v)", status, ok)
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := s.cached("W1"); ok {
+		t.Fatal("expected the cached status to expire after 60 seconds")
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// slackCallTimeout bounds a single Slack Web API call so one slow call cannot
+// consume the whole budget of a multi-call operation.
+const slackCallTimeout = 5 * time.Second
+
+func withSlackDeadline(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
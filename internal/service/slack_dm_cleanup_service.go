@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
 
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
 const (
@@ -66,19 +68,17 @@ type slackDMMessage struct {
 	Text    string
 }
 
-func NewSlackDMCleanupService(workspaceRepo *repository.WorkspaceRepository) *SlackDMCleanupService {
+func NewSlackDMCleanupService(workspaceRepo *repository.WorkspaceRepository, logger *slog.Logger) *SlackDMCleanupService {
 	return &SlackDMCleanupService{
 		workspaceRepo: workspaceRepo,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
+		httpClient:    slack.NewHTTPClient(15*time.Second, logger),
 	}
 }
 
 func (s *SlackDMCleanupService) CleanupBotDirectMessages(ctx context.Context, workspaceID, userID string) (DMCleanupResult, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" {
-		return DMCleanupResult{}, fmt.Errorf("user_id is required")
+		return DMCleanupResult{}, fmt.Errorf("%w: user_id is required", ErrInvalidInput)
 	}
 
 	install, err := s.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
@@ -86,7 +86,7 @@ func (s *SlackDMCleanupService) CleanupBotDirectMessages(ctx context.Context, wo
 		return DMCleanupResult{}, err
 	}
 	if strings.TrimSpace(install.BotToken) == "" {
-		return DMCleanupResult{}, fmt.Errorf("workspace is not connected to Slack yet")
+		return DMCleanupResult{}, ErrNotConnected
 	}
 
 	channelID, err := s.openDMChannel(ctx, install.BotToken, userID)
@@ -152,12 +152,12 @@ func (s *SlackDMCleanupService) openDMChannel(ctx context.Context, botToken, use
 		if parsed.Error == "" {
 			parsed.Error = "conversations.open failed"
 		}
-		return "", fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return "", &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	channelID := strings.TrimSpace(parsed.Channel.ID)
 	if channelID == "" {
-		return "", fmt.Errorf("slack api error: missing dm channel id")
+		return "", fmt.Errorf("%w: missing dm channel id", ErrSlackAPIError)
 	}
 
 	return channelID, nil
@@ -212,7 +212,7 @@ func (s *SlackDMCleanupService) listDMHistoryPage(ctx context.Context, botToken,
 		if parsed.Error == "" {
 			parsed.Error = "conversations.history failed"
 		}
-		return nil, "", fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return nil, "", &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	messages := make([]slackDMMessage, 0, len(parsed.Messages))
@@ -257,7 +257,7 @@ func (s *SlackDMCleanupService) deleteDMMessage(ctx context.Context, botToken, c
 		if parsed.Error == "" {
 			parsed.Error = "chat.delete failed"
 		}
-		return fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	return nil
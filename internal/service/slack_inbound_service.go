@@ -1,8 +1,10 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"slackcheers/internal/domain"
 	"slackcheers/internal/repository"
 	"slackcheers/internal/slack"
 )
@@ -18,11 +21,13 @@ import (
 const slackUsersInfoURL = "https://slack.com/api/users.info"
 
 type SlackInboundService struct {
-	workspaceRepo *repository.WorkspaceRepository
-	peopleRepo    *repository.PeopleRepository
-	slackClient   slack.Client
-	logger        *slog.Logger
-	httpClient    *http.Client
+	workspaceRepo  *repository.WorkspaceRepository
+	peopleRepo     *repository.PeopleRepository
+	onboardingRepo *repository.OnboardingRepository
+	dashboardSvc   *DashboardService
+	slackClient    slack.Client
+	logger         *slog.Logger
+	httpClient     *http.Client
 }
 
 type inboundEventEnvelope struct {
@@ -34,10 +39,59 @@ type inboundEventEnvelope struct {
 		BotID       string `json:"bot_id"`
 		User        string `json:"user"`
 		Text        string `json:"text"`
+		Channel     string `json:"channel"`
 		ChannelType string `json:"channel_type"`
+		TS          string `json:"ts"`
+		Tokens      struct {
+			Bot []string `json:"bot"`
+		} `json:"tokens"`
 	} `json:"event"`
 }
 
+type inboundEventAction int
+
+const (
+	inboundEventIgnore inboundEventAction = iota
+	inboundEventDirectMessage
+	inboundEventRevokeInstall
+	inboundEventAppMention
+)
+
+// classifyInboundEvent decides how ProcessEvent handles an envelope: user DMs
+// update profiles, @mentions get a thread reply, and an uninstall or bot token
+// revocation clears the workspace's Slack credentials.
+func classifyInboundEvent(envelope inboundEventEnvelope) inboundEventAction {
+	if envelope.Type != "event_callback" {
+		return inboundEventIgnore
+	}
+
+	ev := envelope.Event
+	switch ev.Type {
+	case "app_uninstalled":
+		return inboundEventRevokeInstall
+	case "tokens_revoked":
+		if len(ev.Tokens.Bot) > 0 {
+			return inboundEventRevokeInstall
+		}
+		return inboundEventIgnore
+	case "message":
+		if ev.ChannelType != "im" || strings.TrimSpace(ev.User) == "" {
+			return inboundEventIgnore
+		}
+		if strings.TrimSpace(ev.Subtype) != "" || strings.TrimSpace(ev.BotID) != "" {
+			return inboundEventIgnore
+		}
+		return inboundEventDirectMessage
+	case "app_mention":
+		if strings.TrimSpace(ev.User) == "" || strings.TrimSpace(ev.BotID) != "" {
+			return inboundEventIgnore
+		}
+		return inboundEventAppMention
+	default:
+		return inboundEventIgnore
+	}
+}
+
 type slackUsersInfoResponse struct {
 	OK       bool   `json:"ok"`
 	Error    string `json:"error"`
@@ -60,6 +114,10 @@ type parsedProfileInput struct {
 	BirthdayYr  *int
 	HasHireDate bool
 	HireDate    time.Time
+	// OptOut and OptIn come from the optout and optin commands, which set
+	// public_celebration_opt_in and carry no dates.
+	OptOut bool
+	OptIn  bool
 }
 
 var (
@@ -100,17 +158,19 @@ var monthNames = map[string]int{
 func NewSlackInboundService(
 	workspaceRepo *repository.WorkspaceRepository,
 	peopleRepo *repository.PeopleRepository,
+	onboardingRepo *repository.OnboardingRepository,
+	dashboardSvc *DashboardService,
 	slackClient slack.Client,
 	logger *slog.Logger,
 ) *SlackInboundService {
 	return &SlackInboundService{
-		workspaceRepo: workspaceRepo,
-		peopleRepo:    peopleRepo,
-		slackClient:   slackClient,
-		logger:        logger,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		workspaceRepo:  workspaceRepo,
+		peopleRepo:     peopleRepo,
+		onboardingRepo: onboardingRepo,
+		dashboardSvc:   dashboardSvc,
+		slackClient:    slackClient,
+		logger:         logger,
+		httpClient:     slack.NewHTTPClient(10*time.Second, logger),
 	}
 }
 
@@ -120,21 +180,49 @@ func (s *SlackInboundService) ProcessEvent(ctx context.Context, raw []byte) erro
 		return fmt.Errorf("decode inbound event payload: %w", err)
 	}
 
-	if envelope.Type != "event_callback" {
+	ev := envelope.Event
+	switch classifyInboundEvent(envelope) {
+	case inboundEventRevokeInstall:
+		return s.revokeInstallation(ctx, strings.TrimSpace(envelope.TeamID), ev.Type)
+	case inboundEventIgnore:
 		return nil
 	}
 
-	ev := envelope.Event
-	if ev.Type != "message" || ev.ChannelType != "im" || strings.TrimSpace(ev.User) == "" {
+	install, err := s.workspaceRepo.GetSlackInstallationByTeamID(ctx, strings.TrimSpace(envelope.TeamID))
+	if err != nil {
+		return fmt.Errorf("resolve workspace by team id: %w", err)
+	}
+
+	if ev.Type == "app_mention" {
+		return s.replyToMention(ctx, install, ev.Channel, ev.TS, ev.Text)
+	}
+
+	if isStatusCommand(ev.Text) {
+		reply, err := s.profileStatusReply(ctx, install.WorkspaceID, ev.User)
+		if err != nil {
+			return err
+		}
+		if err := s.slackClient.SendDirectMessage(ctx, install.WorkspaceID, ev.User, reply); err != nil {
+			s.logger.WarnContext(ctx, "failed to send profile status", slog.String("user_id", ev.User), slog.String("error", err.Error()))
+		}
+		return nil
+	}
+
+	if isRemoveBirthdayCommand(ev.Text) {
+		s.clearDateFromDM(ctx, install.WorkspaceID, ev.User, profileFieldBirthday)
 		return nil
 	}
-	if strings.TrimSpace(ev.Subtype) != "" || strings.TrimSpace(ev.BotID) != "" {
+	if isRemoveHireDateCommand(ev.Text) {
+		s.clearDateFromDM(ctx, install.WorkspaceID, ev.User, profileFieldHireDate)
 		return nil
 	}
 
-	install, err := s.workspaceRepo.GetSlackInstallationByTeamID(ctx, strings.TrimSpace(envelope.TeamID))
-	if err != nil {
-		return fmt.Errorf("resolve workspace by team id: %w", err)
+	state, err := s.onboardingRepo.GetOnboardingState(ctx, install.WorkspaceID, ev.User)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if state == domain.OnboardingStateAwaitingBirthday || state == domain.OnboardingStateAwaitingHireDate {
+		return s.continueOnboarding(ctx, install, ev.User, ev.Text, state)
 	}
 
 	parsed, err := parseProfileInput(ev.Text)
@@ -144,28 +232,469 @@ func (s *SlackInboundService) ProcessEvent(ctx context.Context, raw []byte) erro
 		return nil
 	}
 
-	profile, profileErr := s.fetchSlackUserProfile(ctx, install.BotToken, ev.User)
+	if err := s.saveParsedProfile(ctx, install, ev.User, parsed); err != nil {
+		return err
+	}
+
+	ack := buildSaveAckMessage(parsed)
+	if err := s.slackClient.SendDirectMessage(ctx, install.WorkspaceID, ev.User, ack); err != nil {
+		s.logger.WarnContext(ctx, "failed to send inbound save ack", slog.String("user_id", ev.User), slog.String("error", err.Error()))
+	}
+
+	return nil
+}
+
+func (s *SlackInboundService) saveParsedProfile(ctx context.Context, install repository.WorkspaceSlackInstallation, slackUserID string, parsed parsedProfileInput) error {
+	profile, profileErr := s.fetchSlackUserProfile(ctx, install.BotToken, slackUserID)
 	if profileErr != nil {
-		s.logger.WarnContext(ctx, "failed to fetch slack user profile", slog.String("user_id", ev.User), slog.String("error", profileErr.Error()))
+		s.logger.WarnContext(ctx, "failed to fetch slack user profile", slog.String("user_id", slackUserID), slog.String("error", profileErr.Error()))
 	}
 
-	mergedInput, _, err := s.buildPersonUpsert(ctx, install.WorkspaceID, ev.User, parsed, profile)
+	in, _, err := s.buildPersonUpsert(ctx, install.WorkspaceID, slackUserID, parsed, profile)
 	if err != nil {
 		return err
 	}
 
-	if _, err := s.peopleRepo.Upsert(ctx, mergedInput); err != nil {
+	_, err = s.peopleRepo.Upsert(ctx, in)
+	return err
+}
+
+const onboardingHireDatePrompt = "Now send your work start date (e.g. `january 15, 2020`). If you don't want to share, reply `skip`."
+
+// onboardingTurn is what one reply in the guided onboarding conversation
+// does: optionally save Parsed, move to NextState, and answer with Reply.
+type onboardingTurn struct {
+	Parsed    parsedProfileInput
+	Save      bool
+	NextState string
+	Reply     string
+}
+
+// nextOnboardingTurn asks for the birthday, then the hire date, one message
+// at a time. skip moves past either question; optout and optin work at any
+// step without moving it.
+func nextOnboardingTurn(state, text string) onboardingTurn {
+	skip := strings.EqualFold(strings.TrimSpace(text), "skip")
+	parsed, err := parseProfileInput(text)
+	if err == nil && (parsed.OptOut || parsed.OptIn) {
+		return onboardingTurn{Parsed: parsed, Save: true, NextState: state, Reply: buildSaveAckMessage(parsed)}
+	}
+
+	switch state {
+	case domain.OnboardingStateAwaitingBirthday:
+		if skip {
+			return onboardingTurn{NextState: domain.OnboardingStateAwaitingHireDate, Reply: "No problem. " + onboardingHireDatePrompt}
+		}
+		if err == nil && parsed.HasBirthday {
+			parsed.HasHireDate = false
+			return onboardingTurn{Parsed: parsed, Save: true, NextState: domain.OnboardingStateAwaitingHireDate, Reply: "Got it! " + onboardingHireDatePrompt}
+		}
+		return onboardingTurn{NextState: state, Reply: "Please send your birthday as `month day` (e.g. `march 25`). If you don't want to share, reply `skip`."}
+	default:
+		if skip {
+			return onboardingTurn{NextState: domain.OnboardingStateComplete, Reply: "No problem, you're all set! You can DM me `status` any time to see what I have saved."}
+		}
+		if err == nil && parsed.HasHireDate {
+			parsed.HasBirthday = false
+			return onboardingTurn{Parsed: parsed, Save: true, NextState: domain.OnboardingStateComplete, Reply: buildSaveAckMessage(parsed)}
+		}
+		return onboardingTurn{NextState: state, Reply: onboardingHireDatePrompt}
+	}
+}
+
+func (s *SlackInboundService) continueOnboarding(ctx context.Context, install repository.WorkspaceSlackInstallation, slackUserID, text, state string) error {
+	turn := nextOnboardingTurn(state, text)
+	if turn.Save {
+		if err := s.saveParsedProfile(ctx, install, slackUserID, turn.Parsed); err != nil {
+			return err
+		}
+	}
+	if turn.NextState != state {
+		if err := s.onboardingRepo.SetOnboardingState(ctx, install.WorkspaceID, slackUserID, turn.NextState); err != nil {
+			return err
+		}
+	}
+
+	if err := s.slackClient.SendDirectMessage(ctx, install.WorkspaceID, slackUserID, turn.Reply); err != nil {
+		s.logger.WarnContext(ctx, "failed to send onboarding reply", slog.String("user_id", slackUserID), slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+const (
+	ActionUpdateBirthday = "update_birthday"
+	ActionUpdateHireDate = "update_hire_date"
+
+	// The modal's date picker; view submissions carry the picked date under
+	// state.values[ProfileDateBlockID][ProfileDateActionID].
+	ProfileDateBlockID  = "profile_date"
+	ProfileDateActionID = "date"
+)
+
+// HandleInteractiveAction opens the date picker modal for an onboarding DM
+// button. Unknown actions are ignored.
+func (s *SlackInboundService) HandleInteractiveAction(ctx context.Context, slackTeamID, userID, actionID, triggerID string) error {
+	view, ok := profileDateModal(actionID)
+	if !ok {
+		return nil
+	}
+
+	install, err := s.workspaceRepo.GetSlackInstallationByTeamID(ctx, strings.TrimSpace(slackTeamID))
+	if err != nil {
+		return fmt.Errorf("resolve workspace by team id: %w", err)
+	}
+
+	if err := s.slackClient.OpenView(ctx, install.WorkspaceID, triggerID, view); err != nil {
+		return fmt.Errorf("open profile date modal for %s: %w", userID, err)
+	}
+	return nil
+}
+
+// HandleViewSubmission saves the date picked in a profile date modal. An
+// ErrInvalidInput error should be shown to the user on the modal.
+func (s *SlackInboundService) HandleViewSubmission(ctx context.Context, slackTeamID, userID, callbackID, selectedDate string) error {
+	parsed, err := profileInputFromDate(callbackID, selectedDate)
+	if err != nil {
 		return err
 	}
 
-	ack := buildSaveAckMessage(parsed)
-	if err := s.slackClient.SendDirectMessage(ctx, install.WorkspaceID, ev.User, ack); err != nil {
-		s.logger.WarnContext(ctx, "failed to send inbound save ack", slog.String("user_id", ev.User), slog.String("error", err.Error()))
+	install, err := s.workspaceRepo.GetSlackInstallationByTeamID(ctx, strings.TrimSpace(slackTeamID))
+	if err != nil {
+		return fmt.Errorf("resolve workspace by team id: %w", err)
+	}
+
+	profile, profileErr := s.fetchSlackUserProfile(ctx, install.BotToken, userID)
+	if profileErr != nil {
+		s.logger.WarnContext(ctx, "failed to fetch slack user profile", slog.String("user_id", userID), slog.String("error", profileErr.Error()))
+	}
+
+	in, _, err := s.buildPersonUpsert(ctx, install.WorkspaceID, userID, parsed, profile)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.peopleRepo.Upsert(ctx, in); err != nil {
+		return err
 	}
 
+	if err := s.slackClient.SendDirectMessage(ctx, install.WorkspaceID, userID, buildSaveAckMessage(parsed)); err != nil {
+		s.logger.WarnContext(ctx, "failed to send modal save ack", slog.String("user_id", userID), slog.String("error", err.Error()))
+	}
 	return nil
 }
 
+func profileDateModal(actionID string) (map[string]any, bool) {
+	var title, label string
+	switch actionID {
+	case ActionUpdateBirthday:
+		title, label = "Update birthday", "Your birthday"
+	case ActionUpdateHireDate:
+		title, label = "Update hire date", "Your hire date"
+	default:
+		return nil, false
+	}
+
+	return map[string]any{
+		"type":        "modal",
+		"callback_id": actionID,
+		"title":       map[string]any{"type": "plain_text", "text": title},
+		"submit":      map[string]any{"type": "plain_text", "text": "Save"},
+		"close":       map[string]any{"type": "plain_text", "text": "Cancel"},
+		"blocks": []map[string]any{
+			{
+				"type":     "input",
+				"block_id": ProfileDateBlockID,
+				"label":    map[string]any{"type": "plain_text", "text": label},
+				"element": map[string]any{
+					"type":      "datepicker",
+					"action_id": ProfileDateActionID,
+				},
+			},
+		},
+	}, true
+}
+
+func profileInputFromDate(callbackID, selectedDate string) (parsedProfileInput, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(selectedDate))
+	if err != nil {
+		return parsedProfileInput{}, fmt.Errorf("%w: pick a date", ErrInvalidInput)
+	}
+
+	switch callbackID {
+	case ActionUpdateBirthday:
+		if date.After(time.Now()) {
+			return parsedProfileInput{}, fmt.Errorf("%w: birthday cannot be in the future", ErrInvalidInput)
+		}
+		year := date.Year()
+		return parsedProfileInput{
+			HasBirthday: true,
+			BirthdayDay: date.Day(),
+			BirthdayMon: int(date.Month()),
+			BirthdayYr:  &year,
+		}, nil
+	case ActionUpdateHireDate:
+		if date.After(time.Now()) {
+			return parsedProfileInput{}, fmt.Errorf("%w: hire date cannot be in the future", ErrInvalidInput)
+		}
+		return parsedProfileInput{HasHireDate: true, HireDate: date}, nil
+	default:
+		return parsedProfileInput{}, fmt.Errorf("%w: unknown modal %q", ErrInvalidInput, callbackID)
+	}
+}
+
+// HandleSlashCommand runs a /birthday command and posts the ephemeral reply to
+// the command's response_url. "status" shows the saved dates; anything else is
+// parsed like a DM reply and saved.
+func (s *SlackInboundService) HandleSlashCommand(ctx context.Context, slackTeamID, userID, text, responseURL string) error {
+	reply, err := s.slashCommandReply(ctx, slackTeamID, userID, text)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "slash command failed", slog.String("user_id", userID), slog.String("error", err.Error()))
+		reply = "Sorry, I couldn't update your profile right now. Please try again later."
+	}
+	return s.postCommandResponse(ctx, responseURL, reply)
+}
+
+func (s *SlackInboundService) slashCommandReply(ctx context.Context, slackTeamID, userID, text string) (string, error) {
+	install, err := s.workspaceRepo.GetSlackInstallationByTeamID(ctx, strings.TrimSpace(slackTeamID))
+	if err != nil {
+		return "", fmt.Errorf("resolve workspace by team id: %w", err)
+	}
+
+	if isStatusCommand(text) {
+		return s.profileStatusReply(ctx, install.WorkspaceID, userID)
+	}
+
+	parsed, err := parseProfileInput(text)
+	if err != nil {
+		return buildProfileInputHelpMessage(err.Error()), nil
+	}
+
+	profile, profileErr := s.fetchSlackUserProfile(ctx, install.BotToken, userID)
+	if profileErr != nil {
+		s.logger.WarnContext(ctx, "failed to fetch slack user profile", slog.String("user_id", userID), slog.String("error", profileErr.Error()))
+	}
+
+	in, _, err := s.buildPersonUpsert(ctx, install.WorkspaceID, userID, parsed, profile)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.peopleRepo.Upsert(ctx, in); err != nil {
+		return "", err
+	}
+
+	return buildSaveAckMessage(parsed), nil
+}
+
+func (s *SlackInboundService) postCommandResponse(ctx context.Context, responseURL, text string) error {
+	body, _ := json.Marshal(map[string]any{
+		"response_type": "ephemeral",
+		"text":          text,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build command response request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post command response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("post command response: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+const profileStatusNoDataMessage = "I don't have any data for you yet. Send your birthday and hire date to get started."
+
+// profileStatusReply answers the status command from a DM or /birthday.
+func (s *SlackInboundService) profileStatusReply(ctx context.Context, workspaceID, userID string) (string, error) {
+	person, err := s.peopleRepo.GetByWorkspaceAndSlackUserID(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return profileStatusNoDataMessage, nil
+		}
+		return "", err
+	}
+	return buildProfileStatusMessage(person), nil
+}
+
+func isStatusCommand(text string) bool {
+	clean := strings.ToLower(strings.TrimSpace(text))
+	return clean == "status" || clean == "/status"
+}
+
+func buildProfileStatusMessage(person domain.Person) string {
+	birthday := "not set"
+	if person.BirthdayMonth != nil && person.BirthdayDay != nil {
+		birthday = fmt.Sprintf("%s %d", time.Month(*person.BirthdayMonth), *person.BirthdayDay)
+		if person.BirthdayYear != nil {
+			birthday = fmt.Sprintf("%s, %d", birthday, *person.BirthdayYear)
+		}
+	}
+
+	hireDate := "not set"
+	if person.HireDate != nil {
+		hireDate = person.HireDate.Format("January 2, 2006")
+	}
+
+	publicCelebrations := "yes"
+	if !person.PublicCelebrationOptIn {
+		publicCelebrations = "no"
+	}
+
+	return fmt.Sprintf("Your SlackCheers profile:\n• Birthday: %s\n• Hire date: %s\n• Reminders: %s\n• Public celebrations: %s",
+		birthday, hireDate, fallbackString(person.RemindersMode, "same_day"), publicCelebrations)
+}
+
+const (
+	mentionStatusDays = 7
+
+	mentionDefaultReply = "I'm SlackCheers! Use `/birthday` to update your profile."
+	mentionHelpReply    = "*SlackCheers help*\n" +
+		"• `@SlackCheers status` lists birthdays and work anniversaries in the next 7 days.\n" +
+		"• `/birthday march 25` saves your birthday; `/birthday january 23, 2024` saves your hire date.\n" +
+		"• `/birthday status` shows what I have saved for you.\n" +
+		"• DM me `delete birthday` or `delete hire date` to clear a saved date.\n" +
+		"• DM me `optout` to stop public celebration posts about you, or `optin` to turn them back on."
+)
+
+// replyToMention answers an @mention in the thread of the mentioning message.
+func (s *SlackInboundService) replyToMention(ctx context.Context, install repository.WorkspaceSlackInstallation, channelID, ts, text string) error {
+	reply, err := s.mentionReply(ctx, install.WorkspaceID, mentionText(text, install.BotUserID))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "app mention reply failed", slog.String("channel_id", channelID), slog.String("error", err.Error()))
+		reply = "Sorry, I couldn't look that up right now. Please try again later."
+	}
+
+	if _, err := s.slackClient.PostThreadReply(ctx, install.WorkspaceID, channelID, ts, reply); err != nil {
+		s.logger.WarnContext(ctx, "failed to send app mention reply", slog.String("channel_id", channelID), slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func (s *SlackInboundService) mentionReply(ctx context.Context, workspaceID, text string) (string, error) {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "status"):
+		upcoming, err := s.dashboardSvc.Overview(ctx, workspaceID, mentionStatusDays, "all")
+		if err != nil {
+			return "", err
+		}
+		return buildUpcomingCelebrationsMessage(upcoming), nil
+	case strings.Contains(lower, "help"):
+		return mentionHelpReply, nil
+	default:
+		return mentionDefaultReply, nil
+	}
+}
+
+// mentionText drops the bot's <@UBOTID> mentions from an app_mention's text.
+func mentionText(text, botUserID string) string {
+	if botUserID = strings.TrimSpace(botUserID); botUserID != "" {
+		text = strings.ReplaceAll(text, "<@"+botUserID+">", "")
+	}
+	return strings.TrimSpace(text)
+}
+
+func buildUpcomingCelebrationsMessage(upcoming []domain.UpcomingCelebration) string {
+	if len(upcoming) == 0 {
+		return fmt.Sprintf("No birthdays or work anniversaries in the next %d days.", mentionStatusDays)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Coming up in the next %d days:", mentionStatusDays)
+	for _, item := range upcoming {
+		name := fallbackString(item.Name, item.SlackUser, item.UserID)
+		what := "birthday"
+		if item.Type == "anniversary" {
+			what = "work anniversary"
+			if item.Years != nil {
+				unit := "years"
+				if *item.Years == 1 {
+					unit = "year"
+				}
+				what = fmt.Sprintf("%d %s work anniversary", *item.Years, unit)
+			}
+		}
+		fmt.Fprintf(&b, "\n• %s: %s's %s", item.Date.Format("Mon, Jan 2"), name, what)
+	}
+	return b.String()
+}
+
+func (s *SlackInboundService) revokeInstallation(ctx context.Context, slackTeamID, eventType string) error {
+	workspaceID, err := s.workspaceRepo.RevokeSlackInstallation(ctx, slackTeamID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("revoke slack installation: %w", err)
+	}
+	s.slackClient.InvalidateTokenCache(workspaceID)
+
+	s.logger.WarnContext(ctx, "slack installation revoked",
+		slog.String("slack_team_id", slackTeamID),
+		slog.String("event_type", eventType),
+	)
+	return nil
+}
+
+const (
+	profileFieldBirthday = "birthday"
+	profileFieldHireDate = "hire date"
+)
+
+// clearDateFromDM clears one saved date, leaving the other untouched, and
+// confirms by DM.
+func (s *SlackInboundService) clearDateFromDM(ctx context.Context, workspaceID, slackUserID, field string) {
+	var err error
+	if field == profileFieldHireDate {
+		_, err = s.peopleRepo.ClearHireDate(ctx, workspaceID, slackUserID)
+	} else {
+		_, err = s.peopleRepo.ClearBirthday(ctx, workspaceID, slackUserID)
+	}
+
+	reply := fmt.Sprintf("Your %s has been removed.", field)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		reply = fmt.Sprintf("I don't have a %s saved for you.", field)
+	case err != nil:
+		s.logger.ErrorContext(ctx, "failed to clear profile date", slog.String("user_id", slackUserID), slog.String("field", field), slog.String("error", err.Error()))
+		reply = fmt.Sprintf("Sorry, I couldn't remove your %s right now. Please try again later.", field)
+	default:
+		s.logger.InfoContext(ctx, "profile date cleared", slog.String("workspace_id", workspaceID), slog.String("user_id", slackUserID), slog.String("field", field))
+	}
+
+	if err := s.slackClient.SendDirectMessage(ctx, workspaceID, slackUserID, reply); err != nil {
+		s.logger.WarnContext(ctx, "failed to send profile date removal ack", slog.String("user_id", slackUserID), slog.String("error", err.Error()))
+	}
+}
+
+func isRemoveBirthdayCommand(text string) bool {
+	return isRemoveFieldCommand(text, profileFieldBirthday)
+}
+
+func isRemoveHireDateCommand(text string) bool {
+	return isRemoveFieldCommand(text, profileFieldHireDate)
+}
+
+// isRemoveFieldCommand matches "remove|delete|clear [my] <field>", ignoring
+// case, extra spaces, a leading slash and trailing punctuation.
+func isRemoveFieldCommand(text, field string) bool {
+	normalized := strings.ToLower(strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(text), "/")), " "))
+	normalized = strings.TrimRight(normalized, ".!")
+	for _, verb := range []string{"remove", "delete", "clear"} {
+		if normalized == verb+" "+field || normalized == verb+" my "+field {
+			return true
+		}
+	}
+	return false
+}
+
 type slackUserProfile struct {
 	SlackHandle string
 	DisplayName string
@@ -197,7 +726,7 @@ func (s *SlackInboundService) fetchSlackUserProfile(ctx context.Context, token,
 		if payload.Error == "" {
 			payload.Error = "users.info failed"
 		}
-		return slackUserProfile{}, fmt.Errorf("slack api error: %s%s", payload.Error, slackScopeHint(payload.Needed, payload.Provided))
+		return slackUserProfile{}, &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
 	}
 
 	handle := strings.TrimSpace(payload.User.Name)
@@ -245,6 +774,21 @@ func (s *SlackInboundService) buildPersonUpsert(
 		}
 	}
 
+	privacy, err := s.workspaceRepo.GetPrivacySettings(ctx, workspaceID)
+	if err != nil {
+		return repository.UpsertPersonInput{}, "", err
+	}
+	in, summary := applyParsedProfileInput(in, parsed, privacy.BirthdayYearPrivacy == domain.BirthdayYearPrivacyDiscard)
+	return in, summary, nil
+}
+
+// applyParsedProfileInput applies what the user sent on top of their saved
+// profile and summarizes the change for logs.
+func applyParsedProfileInput(in repository.UpsertPersonInput, parsed parsedProfileInput, discardYear bool) (repository.UpsertPersonInput, string) {
+	if discardYear {
+		in.BirthdayYear = nil
+	}
+
 	parts := make([]string, 0, 2)
 	if parsed.HasBirthday {
 		day := parsed.BirthdayDay
@@ -252,7 +796,10 @@ func (s *SlackInboundService) buildPersonUpsert(
 		in.BirthdayDay = &day
 		in.BirthdayMonth = &month
 		in.BirthdayYear = parsed.BirthdayYr
-		if parsed.BirthdayYr != nil {
+		if discardYear {
+			in.BirthdayYear = nil
+		}
+		if in.BirthdayYear != nil {
 			parts = append(parts, fmt.Sprintf("birthday=%02d/%02d/%d", day, month, *parsed.BirthdayYr))
 		} else {
 			parts = append(parts, fmt.Sprintf("birthday=%02d/%02d", day, month))
@@ -263,8 +810,16 @@ func (s *SlackInboundService) buildPersonUpsert(
 		in.HireDate = &d
 		parts = append(parts, "hire_date="+d.Format("2006-01-02"))
 	}
+	if parsed.OptOut {
+		in.PublicCelebrationOptIn = false
+		parts = append(parts, "public_celebration_opt_in=false")
+	}
+	if parsed.OptIn {
+		in.PublicCelebrationOptIn = true
+		parts = append(parts, "public_celebration_opt_in=true")
+	}
 
-	return in, strings.Join(parts, ", "), nil
+	return in, strings.Join(parts, ", ")
 }
 
 func parseProfileInput(text string) (parsedProfileInput, error) {
@@ -273,6 +828,13 @@ func parseProfileInput(text string) (parsedProfileInput, error) {
 		return parsedProfileInput{}, fmt.Errorf("empty message")
 	}
 
+	switch strings.ToLower(clean) {
+	case "optout":
+		return parsedProfileInput{OptOut: true}, nil
+	case "optin":
+		return parsedProfileInput{OptIn: true}, nil
+	}
+
 	// Preferred format:
 	// march 25             -> birthday
 	// january 23, 2024     -> hire date (year required)
@@ -486,6 +1048,12 @@ func buildProfileInputHelpMessage(reason string) string {
 }
 
 func buildSaveAckMessage(parsed parsedProfileInput) string {
+	if parsed.OptOut {
+		return "You've opted out of public celebrations. Your birthdays and anniversaries will no longer be announced publicly."
+	}
+	if parsed.OptIn {
+		return "You've opted in to public celebrations. Your birthdays and anniversaries will be announced publicly again."
+	}
 	if parsed.HasBirthday && parsed.HasHireDate {
 		return "Saved your birthday and hire date! Thank you for sharing with SlackCheers :yellow_heart::tada: We can't wait to celebrate you on your special day :birthday::partying_face: and your work anniversary!"
 	}
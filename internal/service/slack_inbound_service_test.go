@@ -1,8 +1,14 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
 )
 
 func TestParseProfileInput_SlashBirthdayOnly(t *testing.T) {
@@ -130,6 +136,73 @@ func TestParseProfileInput_LegacyBirthdayStillWorks(t *testing.T) {
 	}
 }
 
+func TestParseProfileInput_OptOutAndOptIn(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantOut bool
+		wantIn  bool
+	}{
+		{"optout", true, false},
+		{"  OptOut ", true, false},
+		{"optin", false, true},
+		{"OPTIN", false, true},
+	}
+
+	for _, tc := range tests {
+		parsed, err := parseProfileInput(tc.text)
+		if err != nil {
+			t.Fatalf("parseProfileInput(%q) error: %v", tc.text, err)
+		}
+		if parsed.OptOut != tc.wantOut || parsed.OptIn != tc.wantIn || parsed.HasBirthday || parsed.HasHireDate {
+			t.Fatalf("parseProfileInput(%q) = %#v", tc.text, parsed)
+		}
+	}
+
+	parsed, err := parseProfileInput("march 25")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if parsed.OptOut || parsed.OptIn || !parsed.HasBirthday {
+		t.Fatalf("expected date parsing to be unaffected, got %#v", parsed)
+	}
+}
+
+func TestApplyParsedProfileInput_TogglesPublicCelebrationOptIn(t *testing.T) {
+	day, month := 25, 3
+	saved := repository.UpsertPersonInput{
+		SlackUserID:            "U1",
+		BirthdayDay:            &day,
+		BirthdayMonth:          &month,
+		PublicCelebrationOptIn: true,
+	}
+
+	in, _ := applyParsedProfileInput(saved, parsedProfileInput{OptOut: true}, false)
+	if in.PublicCelebrationOptIn {
+		t.Fatal("expected optout to set public_celebration_opt_in to false")
+	}
+	if in.BirthdayDay == nil || *in.BirthdayDay != day || in.BirthdayMonth == nil || *in.BirthdayMonth != month {
+		t.Fatalf("expected the saved birthday to be kept, got %#v", in)
+	}
+
+	in, _ = applyParsedProfileInput(in, parsedProfileInput{OptIn: true}, false)
+	if !in.PublicCelebrationOptIn {
+		t.Fatal("expected optin to set public_celebration_opt_in back to true")
+	}
+
+	in, _ = applyParsedProfileInput(in, parsedProfileInput{HasBirthday: true, BirthdayDay: 1, BirthdayMon: 4}, false)
+	if !in.PublicCelebrationOptIn {
+		t.Fatal("expected a date update to leave public_celebration_opt_in alone")
+	}
+}
+
+func TestBuildSaveAckMessage_OptOut(t *testing.T) {
+	msg := buildSaveAckMessage(parsedProfileInput{OptOut: true})
+	want := "You've opted out of public celebrations. Your birthdays and anniversaries will no longer be announced publicly."
+	if msg != want {
+		t.Fatalf("unexpected message:\nwant: %s\ngot:  %s", want, msg)
+	}
+}
+
 func TestBuildSaveAckMessage_BirthdayOnly(t *testing.T) {
 	msg := buildSaveAckMessage(parsedProfileInput{HasBirthday: true})
 	want := "Saved your birthday! Thank you for sharing with SlackCheers :yellow_heart::tada: We can't wait to celebrate you on your special day :birthday::partying_face:"
@@ -145,3 +218,244 @@ func TestBuildSaveAckMessage_HireDateOnly(t *testing.T) {
 		t.Fatalf("unexpected message:\nwant: %s\ngot:  %s", want, msg)
 	}
 }
+
+func TestIsRemoveBirthdayCommand(t *testing.T) {
+	matches := []string{"remove birthday", "Delete Birthday", "  remove   my birthday! ", "/delete birthday"}
+	for _, text := range matches {
+		if !isRemoveBirthdayCommand(text) {
+			t.Fatalf("expected %q to be a remove birthday command", text)
+		}
+	}
+
+	nonMatches := []string{"march 25", "birthday: 25/03", "remove birthday please", ""}
+	for _, text := range nonMatches {
+		if isRemoveBirthdayCommand(text) {
+			t.Fatalf("did not expect %q to be a remove birthday command", text)
+		}
+	}
+}
+
+func TestIsRemoveHireDateCommand(t *testing.T) {
+	matches := []string{"delete hire date", "Clear Hire Date", "clear my hire date.", "/remove hire date"}
+	for _, text := range matches {
+		if !isRemoveHireDateCommand(text) {
+			t.Fatalf("expected %q to be a remove hire date command", text)
+		}
+		if isRemoveBirthdayCommand(text) {
+			t.Fatalf("did not expect %q to be a remove birthday command", text)
+		}
+	}
+
+	if !isRemoveBirthdayCommand("clear birthday") {
+		t.Fatal("expected clear birthday to be a remove birthday command")
+	}
+	if isRemoveHireDateCommand("delete birthday") || isRemoveHireDateCommand("january 23, 2024") {
+		t.Fatal("did not expect a remove hire date match")
+	}
+}
+
+func TestProfileInputFromDate(t *testing.T) {
+	parsed, err := profileInputFromDate(ActionUpdateBirthday, "1990-03-25")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !parsed.HasBirthday || parsed.BirthdayDay != 25 || parsed.BirthdayMon != 3 || parsed.BirthdayYr == nil || *parsed.BirthdayYr != 1990 {
+		t.Fatalf("unexpected birthday: %+v", parsed)
+	}
+
+	parsed, err = profileInputFromDate(ActionUpdateHireDate, "2021-01-23")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !parsed.HasHireDate || parsed.HasBirthday || parsed.HireDate.Format("2006-01-02") != "2021-01-23" {
+		t.Fatalf("unexpected hire date: %+v", parsed)
+	}
+
+	for _, tc := range []struct{ callbackID, date string }{
+		{ActionUpdateBirthday, ""},
+		{ActionUpdateHireDate, "2999-01-01"},
+		{"something_else", "2021-01-23"},
+	} {
+		if _, err := profileInputFromDate(tc.callbackID, tc.date); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("profileInputFromDate(%q, %q) error = %v, want ErrInvalidInput", tc.callbackID, tc.date, err)
+		}
+	}
+}
+
+func TestProfileDateModal(t *testing.T) {
+	view, ok := profileDateModal(ActionUpdateHireDate)
+	if !ok {
+		t.Fatalf("expected a modal for %s", ActionUpdateHireDate)
+	}
+	if view["callback_id"] != ActionUpdateHireDate {
+		t.Fatalf("callback_id = %v, want %s", view["callback_id"], ActionUpdateHireDate)
+	}
+	blocks := view["blocks"].([]map[string]any)
+	if len(blocks) != 1 || blocks[0]["block_id"] != ProfileDateBlockID {
+		t.Fatalf("unexpected modal blocks: %v", blocks)
+	}
+
+	if _, ok := profileDateModal("unknown"); ok {
+		t.Fatalf("expected no modal for an unknown action")
+	}
+}
+
+func TestBuildProfileStatusMessage(t *testing.T) {
+	day, month := 25, 3
+	hire := time.Date(2021, time.January, 23, 0, 0, 0, 0, time.UTC)
+
+	msg := buildProfileStatusMessage(domain.Person{BirthdayDay: &day, BirthdayMonth: &month, HireDate: &hire})
+	if !strings.Contains(msg, "Birthday: March 25") || !strings.Contains(msg, "Hire date: January 23, 2021") {
+		t.Fatalf("unexpected status message: %q", msg)
+	}
+
+	empty := buildProfileStatusMessage(domain.Person{})
+	if strings.Count(empty, "not set") != 2 {
+		t.Fatalf("expected both dates unset, got %q", empty)
+	}
+}
+
+func TestBuildProfileStatusMessage_MissingFields(t *testing.T) {
+	day, month, year := 14, 6, 1990
+	hire := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		person domain.Person
+		want   string
+	}{
+		{
+			name:   "everything set",
+			person: domain.Person{BirthdayDay: &day, BirthdayMonth: &month, BirthdayYear: &year, HireDate: &hire, RemindersMode: "day_before", PublicCelebrationOptIn: true},
+			want:   "Your SlackCheers profile:\n• Birthday: June 14, 1990\n• Hire date: March 1, 2021\n• Reminders: day_before\n• Public celebrations: yes",
+		},
+		{
+			name:   "no hire date",
+			person: domain.Person{BirthdayDay: &day, BirthdayMonth: &month, RemindersMode: "same_day", PublicCelebrationOptIn: true},
+			want:   "Your SlackCheers profile:\n• Birthday: June 14\n• Hire date: not set\n• Reminders: same_day\n• Public celebrations: yes",
+		},
+		{
+			name:   "no birthday",
+			person: domain.Person{HireDate: &hire, RemindersMode: "none"},
+			want:   "Your SlackCheers profile:\n• Birthday: not set\n• Hire date: March 1, 2021\n• Reminders: none\n• Public celebrations: no",
+		},
+		{
+			name:   "no dates",
+			person: domain.Person{RemindersMode: "same_day", PublicCelebrationOptIn: true},
+			want:   "Your SlackCheers profile:\n• Birthday: not set\n• Hire date: not set\n• Reminders: same_day\n• Public celebrations: yes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := buildProfileStatusMessage(tc.person); got != tc.want {
+				t.Fatalf("buildProfileStatusMessage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsStatusCommand(t *testing.T) {
+	if !isStatusCommand("  Status ") {
+		t.Fatalf("expected status to match")
+	}
+	if !isStatusCommand("/status") {
+		t.Fatalf("expected /status to match")
+	}
+	if isStatusCommand("march 25") {
+		t.Fatalf("expected a date not to match status")
+	}
+}
+
+func TestClassifyInboundEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want inboundEventAction
+	}{
+		{"user dm", `{"type":"event_callback","event":{"type":"message","channel_type":"im","user":"U1","text":"march 25"}}`, inboundEventDirectMessage},
+		{"bot dm", `{"type":"event_callback","event":{"type":"message","channel_type":"im","user":"U1","bot_id":"B1"}}`, inboundEventIgnore},
+		{"channel message", `{"type":"event_callback","event":{"type":"message","channel_type":"channel","user":"U1"}}`, inboundEventIgnore},
+		{"app uninstalled", `{"type":"event_callback","team_id":"T1","event":{"type":"app_uninstalled"}}`, inboundEventRevokeInstall},
+		{"bot tokens revoked", `{"type":"event_callback","team_id":"T1","event":{"type":"tokens_revoked","tokens":{"bot":["U0BOT"]}}}`, inboundEventRevokeInstall},
+		{"user tokens revoked", `{"type":"event_callback","team_id":"T1","event":{"type":"tokens_revoked","tokens":{"oauth":["U1"]}}}`, inboundEventIgnore},
+		{"app mention", `{"type":"event_callback","team_id":"T1","event":{"type":"app_mention","user":"U1","text":"<@U0BOT> status","channel":"C1","ts":"1.2"}}`, inboundEventAppMention},
+		{"bot app mention", `{"type":"event_callback","team_id":"T1","event":{"type":"app_mention","user":"U1","bot_id":"B1","text":"<@U0BOT> hi"}}`, inboundEventIgnore},
+		{"url verification", `{"type":"url_verification","challenge":"abc"}`, inboundEventIgnore},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var envelope inboundEventEnvelope
+			if err := json.Unmarshal([]byte(tc.raw), &envelope); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got := classifyInboundEvent(envelope); got != tc.want {
+				t.Fatalf("classifyInboundEvent() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMentionText(t *testing.T) {
+	if got := mentionText("<@U0BOT> status please", "U0BOT"); got != "status please" {
+		t.Fatalf("mentionText() = %q", got)
+	}
+	if got := mentionText("hey <@U0BOT>, help", "U0BOT"); got != "hey , help" {
+		t.Fatalf("mentionText() = %q", got)
+	}
+	if got := mentionText("  <@U0BOT>  ", "U0BOT"); got != "" {
+		t.Fatalf("mentionText() = %q, want empty", got)
+	}
+}
+
+func TestBuildUpcomingCelebrationsMessage(t *testing.T) {
+	if got := buildUpcomingCelebrationsMessage(nil); got != "No birthdays or work anniversaries in the next 7 days." {
+		t.Fatalf("unexpected empty message: %q", got)
+	}
+
+	years := 1
+	got := buildUpcomingCelebrationsMessage([]domain.UpcomingCelebration{
+		{Date: time.Date(2025, time.June, 16, 0, 0, 0, 0, time.UTC), Type: "birthday", UserID: "U1", Name: "Alice"},
+		{Date: time.Date(2025, time.June, 18, 0, 0, 0, 0, time.UTC), Type: "anniversary", UserID: "U2", SlackUser: "bob", Years: &years},
+	})
+	want := "Coming up in the next 7 days:\n• Mon, Jun 16: Alice's birthday\n• Wed, Jun 18: bob's 1 year work anniversary"
+	if got != want {
+		t.Fatalf("buildUpcomingCelebrationsMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestNextOnboardingTurn(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     string
+		text      string
+		save      bool
+		nextState string
+		reply     string
+	}{
+		{"birthday saved", domain.OnboardingStateAwaitingBirthday, "march 25", true, domain.OnboardingStateAwaitingHireDate, "Got it! " + onboardingHireDatePrompt},
+		{"birthday skipped", domain.OnboardingStateAwaitingBirthday, " Skip ", false, domain.OnboardingStateAwaitingHireDate, "No problem. " + onboardingHireDatePrompt},
+		{"birthday step ignores hire date", domain.OnboardingStateAwaitingBirthday, "january 15, 2020", false, domain.OnboardingStateAwaitingBirthday, "Please send your birthday as `month day` (e.g. `march 25`). If you don't want to share, reply `skip`."},
+		{"hire date saved", domain.OnboardingStateAwaitingHireDate, "january 15, 2020", true, domain.OnboardingStateComplete, buildSaveAckMessage(parsedProfileInput{HasHireDate: true})},
+		{"hire date skipped", domain.OnboardingStateAwaitingHireDate, "skip", false, domain.OnboardingStateComplete, "No problem, you're all set! You can DM me `status` any time to see what I have saved."},
+		{"hire date step repeats prompt", domain.OnboardingStateAwaitingHireDate, "hello", false, domain.OnboardingStateAwaitingHireDate, onboardingHireDatePrompt},
+		{"optout keeps the step", domain.OnboardingStateAwaitingBirthday, "optout", true, domain.OnboardingStateAwaitingBirthday, buildSaveAckMessage(parsedProfileInput{OptOut: true})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			turn := nextOnboardingTurn(tc.state, tc.text)
+			if turn.Save != tc.save || turn.NextState != tc.nextState || turn.Reply != tc.reply {
+				t.Fatalf("nextOnboardingTurn() = %#v", turn)
+			}
+		})
+	}
+}
+
+func TestNextOnboardingTurn_SavesOnlyTheAskedDate(t *testing.T) {
+	turn := nextOnboardingTurn(domain.OnboardingStateAwaitingBirthday, "march 25\njanuary 15, 2020")
+	if !turn.Parsed.HasBirthday || turn.Parsed.HasHireDate {
+		t.Fatalf("expected only the birthday to be saved, got %#v", turn.Parsed)
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
 
+	"slackcheers/internal/metrics"
 	"slackcheers/internal/repository"
+	"slackcheers/internal/slack"
 )
 
 const (
@@ -23,6 +26,8 @@ type SlackOnboardingService struct {
 	workspaceRepo  *repository.WorkspaceRepository
 	onboardingRepo *repository.OnboardingRepository
 	httpClient     *http.Client
+	paging         slackPaging
+	logger         *slog.Logger
 }
 
 type OnboardingDispatchResult struct {
@@ -75,16 +80,23 @@ type slackPostMessageResponse struct {
 
 type slackMember struct {
 	ID          string
+	Handle      string
 	DisplayName string
+	AvatarURL   string
 }
 
-func NewSlackOnboardingService(workspaceRepo *repository.WorkspaceRepository, onboardingRepo *repository.OnboardingRepository) *SlackOnboardingService {
+func NewSlackOnboardingService(
+	workspaceRepo *repository.WorkspaceRepository,
+	onboardingRepo *repository.OnboardingRepository,
+	pageSize, maxPages int,
+	logger *slog.Logger,
+) *SlackOnboardingService {
 	return &SlackOnboardingService{
 		workspaceRepo:  workspaceRepo,
 		onboardingRepo: onboardingRepo,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
+		httpClient:     slack.NewHTTPClient(15*time.Second, logger),
+		paging:         newSlackPaging(pageSize, maxPages),
+		logger:         logger,
 	}
 }
 
@@ -94,7 +106,12 @@ func (s *SlackOnboardingService) SendOnboardingDMs(ctx context.Context, workspac
 		return OnboardingDispatchResult{}, err
 	}
 	if strings.TrimSpace(install.BotToken) == "" {
-		return OnboardingDispatchResult{}, fmt.Errorf("workspace is not connected to Slack yet")
+		return OnboardingDispatchResult{}, ErrNotConnected
+	}
+
+	template, err := s.workspaceRepo.GetOnboardingTemplate(ctx, workspaceID)
+	if err != nil {
+		return OnboardingDispatchResult{}, err
 	}
 
 	members, err := s.listWorkspaceMembers(ctx, install.BotToken)
@@ -122,7 +139,7 @@ func (s *SlackOnboardingService) SendOnboardingDMs(ctx context.Context, workspac
 			continue
 		}
 
-		message := buildOnboardingMessage(member.DisplayName)
+		message := renderOnboardingMessage(template, member.DisplayName)
 		if err := s.sendDirectMessage(ctx, install.BotToken, member.ID, message); err != nil {
 			result.Failed++
 			result.FailedUsers = append(result.FailedUsers, member.ID)
@@ -137,7 +154,17 @@ func (s *SlackOnboardingService) SendOnboardingDMs(ctx context.Context, workspac
 			continue
 		}
 
+		if err := s.onboardingRepo.StartOnboarding(ctx, workspaceID, repository.SlackMemberProfile{
+			SlackUserID: member.ID,
+			SlackHandle: fallbackString(member.Handle, member.ID),
+			DisplayName: fallbackString(member.DisplayName, member.Handle, member.ID),
+			AvatarURL:   member.AvatarURL,
+		}); err != nil {
+			s.logger.WarnContext(ctx, "failed to start guided onboarding", slog.String("user_id", member.ID), slog.String("error", err.Error()))
+		}
+
 		result.Sent++
+		metrics.OnboardingDMsSent.Inc()
 	}
 
 	sort.Strings(result.FailedUsers)
@@ -147,17 +174,25 @@ func (s *SlackOnboardingService) SendOnboardingDMs(ctx context.Context, workspac
 func (s *SlackOnboardingService) listWorkspaceMembers(ctx context.Context, botToken string) ([]slackMember, error) {
 	members := make([]slackMember, 0)
 	cursor := ""
-	for page := 0; page < 10; page++ {
-		pageMembers, nextCursor, err := s.listUsersPage(ctx, botToken, cursor)
+	for page := 0; page < s.paging.maxPages; page++ {
+		pageCtx, cancel := withSlackDeadline(ctx, slackCallTimeout)
+		pageMembers, nextCursor, err := s.listUsersPage(pageCtx, botToken, cursor)
+		cancel()
 		if err != nil {
 			return nil, err
 		}
 		members = append(members, pageMembers...)
 
-		if strings.TrimSpace(nextCursor) == "" {
+		cursor = strings.TrimSpace(nextCursor)
+		if cursor == "" {
 			break
 		}
-		cursor = nextCursor
+	}
+	if cursor != "" {
+		s.logger.WarnContext(ctx, "Slack member page limit reached; results may be incomplete.",
+			slog.Int("max_pages", s.paging.maxPages),
+			slog.Int("members", len(members)),
+		)
 	}
 	return members, nil
 }
@@ -169,7 +204,7 @@ func (s *SlackOnboardingService) listUsersPage(ctx context.Context, botToken, cu
 	}
 
 	q := req.URL.Query()
-	q.Set("limit", "200")
+	q.Set("limit", s.paging.limit())
 	if strings.TrimSpace(cursor) != "" {
 		q.Set("cursor", cursor)
 	}
@@ -190,7 +225,7 @@ func (s *SlackOnboardingService) listUsersPage(ctx context.Context, botToken, cu
 		if payload.Error == "" {
 			payload.Error = "users.list failed"
 		}
-		return nil, "", fmt.Errorf("slack api error: %s%s", payload.Error, slackScopeHint(payload.Needed, payload.Provided))
+		return nil, "", &slack.SlackAPIError{Code: payload.Error, Needed: payload.Needed, Provided: payload.Provided}
 	}
 
 	members := make([]slackMember, 0, len(payload.Members))
@@ -205,14 +240,21 @@ func (s *SlackOnboardingService) listUsersPage(ctx context.Context, botToken, cu
 		if name == "" {
 			name = strings.TrimSpace(m.Name)
 		}
-		members = append(members, slackMember{ID: m.ID, DisplayName: name})
+		members = append(members, slackMember{
+			ID:          m.ID,
+			Handle:      strings.TrimSpace(m.Name),
+			DisplayName: name,
+			AvatarURL:   strings.TrimSpace(m.Profile.Image192),
+		})
 	}
 
 	return members, payload.ResponseMetadata.NextCursor, nil
 }
 
 func (s *SlackOnboardingService) sendDirectMessage(ctx context.Context, botToken, userID, text string) error {
-	channelID, err := s.openDMChannel(ctx, botToken, userID)
+	openCtx, cancelOpen := withSlackDeadline(ctx, slackCallTimeout)
+	channelID, err := s.openDMChannel(openCtx, botToken, userID)
+	cancelOpen()
 	if err != nil {
 		return err
 	}
@@ -220,10 +262,14 @@ func (s *SlackOnboardingService) sendDirectMessage(ctx context.Context, botToken
 	payload := map[string]any{
 		"channel": channelID,
 		"text":    text,
+		"blocks":  onboardingBlocks(text),
 	}
 	body, _ := json.Marshal(payload)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackChatPostMessageURL, bytes.NewReader(body))
+	postCtx, cancelPost := withSlackDeadline(ctx, slackCallTimeout)
+	defer cancelPost()
+
+	req, err := http.NewRequestWithContext(postCtx, http.MethodPost, slackChatPostMessageURL, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("build chat.postMessage request: %w", err)
 	}
@@ -244,7 +290,7 @@ func (s *SlackOnboardingService) sendDirectMessage(ctx context.Context, botToken
 		if parsed.Error == "" {
 			parsed.Error = "chat.postMessage failed"
 		}
-		return fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 
 	return nil
@@ -275,24 +321,80 @@ func (s *SlackOnboardingService) openDMChannel(ctx context.Context, botToken, us
 		if parsed.Error == "" {
 			parsed.Error = "conversations.open failed"
 		}
-		return "", fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return "", &slack.SlackAPIError{Code: parsed.Error, Needed: parsed.Needed, Provided: parsed.Provided}
 	}
 	if strings.TrimSpace(parsed.Channel.ID) == "" {
-		return "", fmt.Errorf("slack api error: missing dm channel id")
+		return "", fmt.Errorf("%w: missing dm channel id", ErrSlackAPIError)
 	}
 
 	return parsed.Channel.ID, nil
 }
 
-func buildOnboardingMessage(name string) string {
+const maxOnboardingTemplateLength = 3000
+
+// UpdateOnboardingTemplate stores the workspace's onboarding DM text. {name} is
+// replaced with the member's display name. An empty template restores the
+// built-in message.
+func (s *SlackOnboardingService) UpdateOnboardingTemplate(ctx context.Context, workspaceID, template string) error {
+	template = strings.TrimSpace(template)
+	if len(template) > maxOnboardingTemplateLength {
+		return fmt.Errorf("%w: template must be at most %d characters", ErrInvalidInput, maxOnboardingTemplateLength)
+	}
+	return s.workspaceRepo.UpdateOnboardingTemplate(ctx, workspaceID, template)
+}
+
+func renderOnboardingMessage(template, name string) string {
+	if strings.TrimSpace(template) == "" {
+		return buildOnboardingMessage(name)
+	}
+	msg, _ := RenderTemplate(template, map[string]string{"name": onboardingDisplayName(name)})
+	return msg
+}
+
+func onboardingDisplayName(name string) string {
 	cleanName := strings.TrimSpace(name)
 	cleanName = strings.TrimRight(cleanName, ".!?,")
 	if cleanName == "" {
 		cleanName = "there"
 	}
+	return cleanName
+}
+
+// onboardingBlocks renders the onboarding text with buttons that open the
+// profile date modals handled by SlackInboundService.
+func onboardingBlocks(text string) []map[string]any {
+	return []map[string]any{
+		{
+			"type": "section",
+			"text": map[string]any{
+				"type": "mrkdwn",
+				"text": text,
+			},
+		},
+		{
+			"type": "actions",
+			"elements": []map[string]any{
+				{
+					"type":      "button",
+					"action_id": ActionUpdateBirthday,
+					"text":      map[string]any{"type": "plain_text", "text": "Update my birthday"},
+					"style":     "primary",
+				},
+				{
+					"type":      "button",
+					"action_id": ActionUpdateHireDate,
+					"text":      map[string]any{"type": "plain_text", "text": "Update my hire date"},
+				},
+			},
+		},
+	}
+}
+
+func buildOnboardingMessage(name string) string {
+	cleanName := onboardingDisplayName(name)
 
 	return fmt.Sprintf(
-		"Hi %s!\n\nSlackCheers is now active in your workspace to celebrate great moments.\n\nTell us your birthday: `month day` and hire date: `month day, year`\n\nYou can send only birthday or only hire date, and update later anytime.",
+		"Hi %s!\n\nSlackCheers is now active in your workspace to celebrate great moments.\n\nFirst, when is your birthday? Reply with `month day` (e.g. `march 25`), or `skip` if you'd rather not share.\n\nI'll ask for your work start date next, and you can update either one later anytime.",
 		cleanName,
 	)
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRenderOnboardingMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		member   string
+		want     string
+	}{
+		{
+			name:     "custom template replaces name",
+			template: "Bonjour {name} ! Envoie ta date d'anniversaire.",
+			member:   "Ada.",
+			want:     "Bonjour Ada ! Envoie ta date d'anniversaire.",
+		},
+		{
+			name:     "custom template with blank name",
+			template: "Hi {name}",
+			member:   "  ",
+			want:     "Hi there",
+		},
+		{
+			name:     "empty template falls back to built-in message",
+			template: "",
+			member:   "Grace",
+			want:     buildOnboardingMessage("Grace"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := renderOnboardingMessage(tc.template, tc.member); got != tc.want {
+				t.Fatalf("renderOnboardingMessage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestListWorkspaceMembers_StopsAtMaxPages(t *testing.T) {
+	calls := 0
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if got := req.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want %q", got, "2")
+		}
+		body := `{"ok":true,"members":[{"id":"U` + strings.Repeat("X", calls) + `","name":"member"}],"response_metadata":{"next_cursor":"more"}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+
+	s := &SlackOnboardingService{
+		httpClient: client,
+		paging:     newSlackPaging(2, 3),
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	members, err := s.listWorkspaceMembers(context.Background(), "xoxb-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("users.list called %d times, want 3", calls)
+	}
+	if len(members) != 3 {
+		t.Fatalf("got %d members, want 3", len(members))
+	}
+}
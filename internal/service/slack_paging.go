@@ -0,0 +1,30 @@
+package service
+
+import "strconv"
+
+const (
+	defaultSlackAPIPageSize = 200
+	defaultSlackAPIMaxPages = 50
+)
+
+// slackPaging bounds cursor-paginated Slack list calls (users.list,
+// conversations.list): how many items to ask for per page and how many pages
+// to follow before giving up.
+type slackPaging struct {
+	pageSize int
+	maxPages int
+}
+
+func newSlackPaging(pageSize, maxPages int) slackPaging {
+	if pageSize <= 0 {
+		pageSize = defaultSlackAPIPageSize
+	}
+	if maxPages <= 0 {
+		maxPages = defaultSlackAPIMaxPages
+	}
+	return slackPaging{pageSize: pageSize, maxPages: maxPages}
+}
+
+func (p slackPaging) limit() string {
+	return strconv.Itoa(p.pageSize)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"slackcheers/internal/domain"
+	"slackcheers/internal/repository"
+)
+
+const (
+	archivePeopleFile    = "people.csv"
+	archiveChannelsFile  = "channels.json"
+	archiveTemplatesFile = "templates.json"
+
+	// maxArchiveEntryBytes bounds how much one archive entry may inflate to.
+	maxArchiveEntryBytes = 32 << 20
+)
+
+// ChannelTemplates is one channel's entry in templates.json.
+type ChannelTemplates struct {
+	BirthdayTemplate    string `json:"birthday_template"`
+	AnniversaryTemplate string `json:"anniversary_template"`
+	BrandingEmoji       string `json:"branding_emoji"`
+}
+
+// WorkspaceArchive is the parsed content of a workspace export. PeopleErrors
+// holds one message per people.csv row that could not be parsed.
+type WorkspaceArchive struct {
+	People       []repository.UpsertPersonInput
+	PeopleErrors []string
+	Channels     []domain.WorkspaceChannel
+	Templates    map[string]ChannelTemplates
+}
+
+// WriteWorkspaceArchive writes a ZIP with people.csv (the import schema),
+// channels.json and templates.json (keyed by channel ID).
+func WriteWorkspaceArchive(w io.Writer, people []domain.Person, channels []domain.WorkspaceChannel) error {
+	zw := zip.NewWriter(w)
+
+	pw, err := zw.Create(archivePeopleFile)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", archivePeopleFile, err)
+	}
+	if err := WritePeopleCSV(pw, people); err != nil {
+		return err
+	}
+
+	templates := make(map[string]ChannelTemplates, len(channels))
+	for _, ch := range channels {
+		templates[ch.ID] = ChannelTemplates{
+			BirthdayTemplate:    ch.BirthdayTemplate,
+			AnniversaryTemplate: ch.AnniversaryTemplate,
+			BrandingEmoji:       ch.BrandingEmoji,
+		}
+	}
+
+	for _, entry := range []struct {
+		name string
+		v    any
+	}{
+		{archiveChannelsFile, channels},
+		{archiveTemplatesFile, templates},
+	} {
+		ew, err := zw.Create(entry.name)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", entry.name, err)
+		}
+		enc := json.NewEncoder(ew)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entry.v); err != nil {
+			return fmt.Errorf("write %s: %w", entry.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close workspace archive: %w", err)
+	}
+	return nil
+}
+
+// ReadWorkspaceArchive parses an archive written by WriteWorkspaceArchive.
+// Every entry is optional; a missing file imports nothing of that kind.
+func ReadWorkspaceArchive(r io.ReaderAt, size int64, workspaceID string) (WorkspaceArchive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return WorkspaceArchive{}, fmt.Errorf("%w: not a zip archive: %v", ErrInvalidInput, err)
+	}
+
+	archive := WorkspaceArchive{
+		People:       make([]repository.UpsertPersonInput, 0),
+		PeopleErrors: make([]string, 0),
+		Channels:     make([]domain.WorkspaceChannel, 0),
+		Templates:    make(map[string]ChannelTemplates),
+	}
+	for _, f := range zr.File {
+		switch f.Name {
+		case archivePeopleFile, archiveChannelsFile, archiveTemplatesFile:
+		default:
+			continue
+		}
+
+		content, err := readArchiveEntry(f)
+		if err != nil {
+			return WorkspaceArchive{}, err
+		}
+
+		switch f.Name {
+		case archivePeopleFile:
+			archive.People, archive.PeopleErrors, err = ParsePeopleCSV(bytes.NewReader(content), workspaceID, nil)
+		case archiveChannelsFile:
+			err = json.Unmarshal(content, &archive.Channels)
+		case archiveTemplatesFile:
+			err = json.Unmarshal(content, &archive.Templates)
+		}
+		if err != nil {
+			return WorkspaceArchive{}, fmt.Errorf("%w: %s: %v", ErrInvalidInput, f.Name, err)
+		}
+	}
+
+	return archive, nil
+}
+
+func readArchiveEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: open %s: %v", ErrInvalidInput, f.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxArchiveEntryBytes+1))
+	if err != nil {
+		if errors.Is(err, zip.ErrChecksum) || errors.Is(err, zip.ErrFormat) {
+			return nil, fmt.Errorf("%w: read %s: %v", ErrInvalidInput, f.Name, err)
+		}
+		return nil, fmt.Errorf("read %s: %w", f.Name, err)
+	}
+	if len(content) > maxArchiveEntryBytes {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrInvalidInput, f.Name, maxArchiveEntryBytes)
+	}
+	return content, nil
+}
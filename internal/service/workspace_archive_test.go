@@ -0,0 +1,67 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"slackcheers/internal/domain"
+)
+
+func TestWorkspaceArchive_RoundTrip(t *testing.T) {
+	day, month := 14, 3
+	people := []domain.Person{{
+		SlackUserID:            "U1",
+		DisplayName:            "Ada",
+		SlackHandle:            "ada",
+		BirthdayDay:            &day,
+		BirthdayMonth:          &month,
+		PublicCelebrationOptIn: true,
+		RemindersMode:          "same_day",
+	}}
+	channels := []domain.WorkspaceChannel{{
+		ID:                    "ch-1",
+		SlackChannelID:        "C1",
+		SlackChannelName:      "celebrations",
+		PostingTime:           "09:30",
+		Timezone:              "Africa/Lagos",
+		BirthdaysEnabled:      true,
+		BirthdayTemplate:      "Happy birthday {users}!",
+		AnniversaryTemplate:   "Happy {years} years {users}!",
+		BrandingEmoji:         ":tada:",
+		AnniversaryMilestones: []int{1, 5},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteWorkspaceArchive(&buf, people, channels); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+
+	archive, err := ReadWorkspaceArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), "W2")
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+
+	if len(archive.People) != 1 || len(archive.PeopleErrors) != 0 {
+		t.Fatalf("people = %#v, errors = %v", archive.People, archive.PeopleErrors)
+	}
+	if p := archive.People[0]; p.WorkspaceID != "W2" || p.SlackUserID != "U1" || p.BirthdayDay == nil || *p.BirthdayDay != 14 {
+		t.Fatalf("unexpected person %#v", p)
+	}
+
+	if len(archive.Channels) != 1 || archive.Channels[0].SlackChannelID != "C1" || archive.Channels[0].Timezone != "Africa/Lagos" {
+		t.Fatalf("unexpected channels %#v", archive.Channels)
+	}
+	want := ChannelTemplates{BirthdayTemplate: "Happy birthday {users}!", AnniversaryTemplate: "Happy {years} years {users}!", BrandingEmoji: ":tada:"}
+	if got := archive.Templates["ch-1"]; got != want {
+		t.Fatalf("templates[ch-1] = %#v, want %#v", got, want)
+	}
+}
+
+func TestReadWorkspaceArchive_RejectsNonZip(t *testing.T) {
+	content := []byte("slack_user_id\nU1\n")
+	_, err := ReadWorkspaceArchive(bytes.NewReader(content), int64(len(content)), "W1")
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
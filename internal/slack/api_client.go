@@ -8,31 +8,60 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
+	"slackcheers/internal/metrics"
 	"slackcheers/internal/repository"
 )
 
 const (
 	slackChatPostMessageURL   = "https://slack.com/api/chat.postMessage"
+	slackChatPostEphemeralURL = "https://slack.com/api/chat.postEphemeral"
+	slackChatScheduleURL      = "https://slack.com/api/chat.scheduleMessage"
+	slackChatDeleteSchedURL   = "https://slack.com/api/chat.deleteScheduledMessage"
+	slackChatGetPermalinkURL  = "https://slack.com/api/chat.getPermalink"
 	slackConversationsOpenURL = "https://slack.com/api/conversations.open"
 	slackConversationsJoinURL = "https://slack.com/api/conversations.join"
+	slackViewsOpenURL         = "https://slack.com/api/views.open"
+	slackReactionsAddURL      = "https://slack.com/api/reactions.add"
 )
 
+// botTokenCacheTTL bounds how long a workspace bot token is reused without
+// reading it from the database again.
+const botTokenCacheTTL = 5 * time.Minute
+
 type APIClient struct {
 	workspaceRepo   *repository.WorkspaceRepository
 	defaultBotToken string
 	logger          *slog.Logger
 	httpClient      *http.Client
+
+	// getInstallation reads a workspace's stored installation; it is
+	// workspaceRepo.GetSlackInstallationByWorkspaceID outside tests.
+	getInstallation func(ctx context.Context, workspaceID string) (repository.WorkspaceSlackInstallation, error)
+	tokenCache      *sync.Map
+}
+
+type cachedToken struct {
+	token     string
+	expiresAt time.Time
 }
 
 type slackAPIResponse struct {
-	OK       bool            `json:"ok"`
-	Error    string          `json:"error"`
-	Needed   string          `json:"needed"`
-	Provided string          `json:"provided"`
-	Channel  json.RawMessage `json:"channel"`
+	OK               bool            `json:"ok"`
+	Error            string          `json:"error"`
+	Needed           string          `json:"needed"`
+	Provided         string          `json:"provided"`
+	Channel          json.RawMessage `json:"channel"`
+	TS               string          `json:"ts"`
+	Permalink        string          `json:"permalink"`
+	ScheduledID      string          `json:"scheduled_message_id"`
+	Warning          string          `json:"warning,omitempty"`
+	ResponseMetadata map[string]any  `json:"response_metadata,omitempty"`
 }
 
 func NewClient(workspaceRepo *repository.WorkspaceRepository, defaultBotToken string, logger *slog.Logger) (Client, error) {
@@ -44,61 +73,203 @@ func NewClient(workspaceRepo *repository.WorkspaceRepository, defaultBotToken st
 		workspaceRepo:   workspaceRepo,
 		defaultBotToken: strings.TrimSpace(defaultBotToken),
 		logger:          logger,
-		httpClient: &http.Client{
-			Timeout: 12 * time.Second,
-		},
+		httpClient:      NewHTTPClient(12*time.Second, logger),
+		getInstallation: workspaceRepo.GetSlackInstallationByWorkspaceID,
+		tokenCache:      &sync.Map{},
 	}, nil
 }
 
-func (c *APIClient) PostMessage(ctx context.Context, workspaceID, channelID, text string, avatarURLs []string) error {
+func (c *APIClient) PostMessage(ctx context.Context, workspaceID, channelID, text string, avatarURLs []string) (string, error) {
 	token, err := c.resolveBotToken(ctx, workspaceID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	payload := map[string]any{
 		"channel": channelID,
 		"text":    text,
 	}
+	if blocks := messageBlocks(text, avatarURLs); blocks != nil {
+		payload["blocks"] = blocks
+	}
 
-	if len(avatarURLs) > 0 {
-		blocks := make([]map[string]any, 0, 1+len(avatarURLs))
-		blocks = append(blocks, map[string]any{
-			"type": "section",
-			"text": map[string]any{
-				"type": "mrkdwn",
-				"text": text,
-			},
-		})
+	resp := slackAPIResponse{}
+	if err := c.callSlackJSON(ctx, token, slackChatPostMessageURL, payload, &resp, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack post message failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))...)
+		return "", err
+	}
 
-		for i, avatar := range avatarURLs {
-			if i >= 8 {
-				break
-			}
-			avatar = strings.TrimSpace(avatar)
-			if avatar == "" {
-				continue
-			}
-			blocks = append(blocks, map[string]any{
-				"type":      "image",
-				"image_url": avatar,
-				"alt_text":  "celebrant_avatar",
-			})
-		}
+	return resp.TS, nil
+}
+
+// PostMessageBlocks posts a prebuilt Block Kit layout and returns the message
+// timestamp. The first text found in the blocks doubles as the notification
+// fallback text.
+func (c *APIClient) PostMessageBlocks(ctx context.Context, workspaceID, channelID string, blocks []map[string]any) (string, error) {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
 
-		if len(blocks) > 1 {
-			payload["blocks"] = blocks
+	payload := map[string]any{
+		"channel": channelID,
+		"text":    blocksFallbackText(blocks),
+		"blocks":  blocks,
+	}
+
+	resp := slackAPIResponse{}
+	if err := c.callSlackJSON(ctx, token, slackChatPostMessageURL, payload, &resp, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack post message blocks failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))...)
+		return "", err
+	}
+
+	return resp.TS, nil
+}
+
+// PostThreadReply posts text as a reply in the thread of the message at
+// threadTS and returns the reply's timestamp.
+func (c *APIClient) PostThreadReply(ctx context.Context, workspaceID, channelID, threadTS, text string) (string, error) {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	payload := map[string]any{
+		"channel":   channelID,
+		"thread_ts": threadTS,
+		"text":      text,
+	}
+
+	resp := slackAPIResponse{}
+	if err := c.callSlackJSON(ctx, token, slackChatPostMessageURL, payload, &resp, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack post thread reply failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))...)
+		return "", err
+	}
+
+	return resp.TS, nil
+}
+
+// ScheduleMessage asks Slack to post the message at postAt and returns the
+// scheduled message ID, which can later be used to delete it.
+func (c *APIClient) ScheduleMessage(ctx context.Context, workspaceID, channelID, text string, postAt time.Time, avatarURLs []string) (string, error) {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	payload := map[string]any{
+		"channel": channelID,
+		"text":    text,
+		"post_at": postAt.Unix(),
+	}
+	if blocks := messageBlocks(text, avatarURLs); blocks != nil {
+		payload["blocks"] = blocks
+	}
+
+	resp := slackAPIResponse{}
+	if err := c.callSlackJSON(ctx, token, slackChatScheduleURL, payload, &resp, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack schedule message failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))...)
+		return "", err
+	}
+
+	return resp.ScheduledID, nil
+}
+
+// DeleteScheduledMessages cancels scheduled messages in a channel. IDs Slack
+// no longer knows (already posted or deleted) are skipped; other failures are
+// joined into the returned error after every ID has been tried.
+func (c *APIClient) DeleteScheduledMessages(ctx context.Context, workspaceID, channelID string, scheduledMessageIDs []string) error {
+	if len(scheduledMessageIDs) == 0 {
+		return nil
+	}
+
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range scheduledMessageIDs {
+		payload := map[string]any{
+			"channel":              channelID,
+			"scheduled_message_id": id,
+		}
+		err := c.callSlackJSON(ctx, token, slackChatDeleteSchedURL, payload, nil, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))
+		var apiErr *SlackAPIError
+		if errors.As(err, &apiErr) && apiErr.Code == "invalid_scheduled_message_id" {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete scheduled message %s: %w", id, err))
 		}
 	}
+	return errors.Join(errs...)
+}
 
-	if err := c.callSlackJSON(ctx, token, slackChatPostMessageURL, payload, nil); err != nil {
-		c.logger.ErrorContext(ctx, "slack post message failed", slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID), slog.String("error", err.Error()))
+// PostEphemeral posts a message in the channel that only userID can see.
+func (c *APIClient) PostEphemeral(ctx context.Context, workspaceID, channelID, userID, text string, avatarURLs []string) error {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"channel": channelID,
+		"user":    userID,
+		"text":    text,
+	}
+	if blocks := messageBlocks(text, avatarURLs); blocks != nil {
+		payload["blocks"] = blocks
+	}
+
+	if err := c.callSlackJSON(ctx, token, slackChatPostEphemeralURL, payload, nil, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack post ephemeral failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID), slog.String("user_id", userID))...)
 		return err
 	}
 
 	return nil
 }
 
+func (c *APIClient) GetPermalink(ctx context.Context, workspaceID, channelID, messageTS string) (string, error) {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("channel", channelID)
+	query.Set("message_ts", messageTS)
+
+	resp := slackAPIResponse{}
+	if err := c.callSlackGet(ctx, token, slackChatGetPermalinkURL, query, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Permalink, nil
+}
+
+// AddReaction reacts to the message at ts with emoji, given by name without
+// colons. A message that already carries the reaction is not an error.
+func (c *APIClient) AddReaction(ctx context.Context, workspaceID, channelID, ts, emoji string) error {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"channel":   channelID,
+		"timestamp": ts,
+		"name":      strings.Trim(strings.TrimSpace(emoji), ":"),
+	}
+
+	err = c.callSlackJSON(ctx, token, slackReactionsAddURL, payload, nil, slog.String("workspace_id", workspaceID), slog.String("channel_id", channelID))
+	var apiErr *SlackAPIError
+	if errors.As(err, &apiErr) && apiErr.Code == "already_reacted" {
+		return nil
+	}
+	return err
+}
+
 func (c *APIClient) SendDirectMessage(ctx context.Context, workspaceID, userID, text string) error {
 	token, err := c.resolveBotToken(ctx, workspaceID)
 	if err != nil {
@@ -106,7 +277,7 @@ func (c *APIClient) SendDirectMessage(ctx context.Context, workspaceID, userID,
 	}
 
 	dmResp := slackAPIResponse{}
-	if err := c.callSlackJSON(ctx, token, slackConversationsOpenURL, map[string]any{"users": userID}, &dmResp); err != nil {
+	if err := c.callSlackJSON(ctx, token, slackConversationsOpenURL, map[string]any{"users": userID}, &dmResp, slog.String("workspace_id", workspaceID), slog.String("user_id", userID)); err != nil {
 		return err
 	}
 
@@ -115,23 +286,57 @@ func (c *APIClient) SendDirectMessage(ctx context.Context, workspaceID, userID,
 		return err
 	}
 	if channelID == "" {
-		return fmt.Errorf("slack api error: missing dm channel id")
+		return fmt.Errorf("%w: missing dm channel id", ErrAPIError)
 	}
 
 	if err := c.callSlackJSON(ctx, token, slackChatPostMessageURL, map[string]any{
 		"channel": channelID,
 		"text":    text,
-	}, nil); err != nil {
+	}, nil, slog.String("workspace_id", workspaceID), slog.String("user_id", userID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OpenView opens a modal in response to an interaction. triggerID comes from
+// the interaction payload and expires after three seconds.
+func (c *APIClient) OpenView(ctx context.Context, workspaceID, triggerID string, view map[string]any) error {
+	token, err := c.resolveBotToken(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.callSlackJSON(ctx, token, slackViewsOpenURL, map[string]any{
+		"trigger_id": triggerID,
+		"view":       view,
+	}, nil, slog.String("workspace_id", workspaceID)); err != nil {
+		c.logger.ErrorContext(ctx, "slack views open failed", append(slackErrorAttrs(err), slog.String("workspace_id", workspaceID))...)
 		return err
 	}
 
 	return nil
 }
 
+// InvalidateTokenCache drops the cached bot token for a workspace, so the next
+// call reads it from the database again.
+func (c *APIClient) InvalidateTokenCache(workspaceID string) {
+	c.tokenCache.Delete(strings.TrimSpace(workspaceID))
+}
+
+// resolveBotToken returns the workspace's stored bot token, cached for
+// botTokenCacheTTL, falling back to the default token. Only workspace tokens
+// are cached, so a workspace installing the app is picked up right away.
 func (c *APIClient) resolveBotToken(ctx context.Context, workspaceID string) (string, error) {
 	workspaceID = strings.TrimSpace(workspaceID)
 	if workspaceID != "" {
-		install, err := c.workspaceRepo.GetSlackInstallationByWorkspaceID(ctx, workspaceID)
+		if v, ok := c.tokenCache.Load(workspaceID); ok {
+			if cached := v.(cachedToken); time.Now().Before(cached.expiresAt) {
+				return cached.token, nil
+			}
+		}
+
+		install, err := c.getInstallation(ctx, workspaceID)
 		if err != nil {
 			if !errors.Is(err, repository.ErrNotFound) {
 				return "", fmt.Errorf("resolve workspace bot token: %w", err)
@@ -139,30 +344,70 @@ func (c *APIClient) resolveBotToken(ctx context.Context, workspaceID string) (st
 		} else {
 			token := strings.TrimSpace(install.BotToken)
 			if token != "" {
+				c.tokenCache.Store(workspaceID, cachedToken{token: token, expiresAt: time.Now().Add(botTokenCacheTTL)})
 				return token, nil
 			}
 		}
+		c.tokenCache.Delete(workspaceID)
 	}
 
 	if c.defaultBotToken != "" {
 		return c.defaultBotToken, nil
 	}
 
-	return "", fmt.Errorf("no Slack bot token configured for workspace %q", workspaceID)
+	return "", fmt.Errorf("%w: no bot token for workspace %q", ErrNotConnected, workspaceID)
 }
 
-func (c *APIClient) callSlackJSON(ctx context.Context, token, endpoint string, payload any, out any) error {
+// callSlackJSON posts payload to endpoint, retrying transient failures; see
+// withRetry for which. A 429 is retried once after Retry-After; logAttrs
+// identify the workspace and channel in the rate limit warning.
+func (c *APIClient) callSlackJSON(ctx context.Context, token, endpoint string, payload any, out any, logAttrs ...any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("marshal slack payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	call := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build slack request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		return c.doSlackRequest(req, out)
+	}
+
+	err = withRetry(ctx, call, slackRetryAttempts, slackRetryBase, !nonIdempotentSlackMethods[path.Base(endpoint)])
+	var rateErr *rateLimitError
+	if !errors.As(err, &rateErr) {
+		return err
+	}
+
+	c.logger.WarnContext(ctx, "slack rate limited", append([]any{slog.String("endpoint", endpoint), slog.Duration("retry_after", rateErr.RetryAfter)}, logAttrs...)...)
+	if sleepErr := sleepContext(ctx, rateErr.RetryAfter); sleepErr != nil {
+		return errors.Join(err, sleepErr)
+	}
+	return call()
+}
+
+// callSlackGet is for read methods such as chat.getPermalink that take their
+// arguments as query parameters rather than a JSON body.
+func (c *APIClient) callSlackGet(ctx context.Context, token, endpoint string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
 	if err != nil {
 		return fmt.Errorf("build slack request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return c.doSlackRequest(req, out)
+}
+
+func (c *APIClient) doSlackRequest(req *http.Request, out any) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveSlackAPICall(path.Base(req.URL.Path), slackCallStatus(err), time.Since(start))
+	}()
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -170,6 +415,13 @@ func (c *APIClient) callSlackJSON(ctx context.Context, token, endpoint string, p
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &rateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpStatusError{StatusCode: resp.StatusCode}
+	}
+
 	var parsed slackAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
 		return fmt.Errorf("decode slack response: %w", err)
@@ -179,7 +431,13 @@ func (c *APIClient) callSlackJSON(ctx context.Context, token, endpoint string, p
 		if parsed.Error == "" {
 			parsed.Error = "unknown_error"
 		}
-		return fmt.Errorf("slack api error: %s%s", parsed.Error, slackScopeHint(parsed.Needed, parsed.Provided))
+		return &SlackAPIError{
+			Code:             parsed.Error,
+			Needed:           parsed.Needed,
+			Provided:         parsed.Provided,
+			Warning:          parsed.Warning,
+			ResponseMetadata: parsed.ResponseMetadata,
+		}
 	}
 
 	if out != nil {
@@ -192,11 +450,84 @@ func (c *APIClient) callSlackJSON(ctx context.Context, token, endpoint string, p
 	return nil
 }
 
-func ValidatePlaceholders(template string) error {
-	if template == "" {
-		return fmt.Errorf("template cannot be empty")
+// messageBlocks renders the text plus up to eight celebrant avatars. It returns
+// nil when there are no avatars so the plain text is used.
+func messageBlocks(text string, avatarURLs []string) []map[string]any {
+	if len(avatarURLs) == 0 {
+		return nil
 	}
-	return nil
+
+	blocks := make([]map[string]any, 0, 1+len(avatarURLs))
+	blocks = append(blocks, map[string]any{
+		"type": "section",
+		"text": map[string]any{
+			"type": "mrkdwn",
+			"text": text,
+		},
+	})
+
+	for i, avatar := range avatarURLs {
+		if i >= 8 {
+			break
+		}
+		avatar = strings.TrimSpace(avatar)
+		if avatar == "" {
+			continue
+		}
+		blocks = append(blocks, map[string]any{
+			"type":      "image",
+			"image_url": avatar,
+			"alt_text":  "celebrant_avatar",
+		})
+	}
+
+	if len(blocks) == 1 {
+		return nil
+	}
+	return blocks
+}
+
+func blocksFallbackText(blocks []map[string]any) string {
+	for _, block := range blocks {
+		if block["type"] != "section" {
+			continue
+		}
+		if text, ok := block["text"].(map[string]any); ok {
+			if s, ok := text["text"].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// slackCallStatus is the status label recorded for a Slack API call.
+func slackCallStatus(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	default:
+		return "error"
+	}
+}
+
+// slackErrorAttrs returns log attributes for err, adding Slack's warning and
+// response_metadata when the error came back from the Slack API.
+func slackErrorAttrs(err error) []any {
+	attrs := []any{slog.String("error", err.Error())}
+
+	var apiErr *SlackAPIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Warning != "" {
+			attrs = append(attrs, slog.String("slack_warning", apiErr.Warning))
+		}
+		if len(apiErr.ResponseMetadata) > 0 {
+			attrs = append(attrs, slog.Any("slack_response_metadata", apiErr.ResponseMetadata))
+		}
+	}
+	return attrs
 }
 
 func parseSlackChannelID(raw json.RawMessage) (string, error) {
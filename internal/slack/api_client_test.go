@@ -0,0 +1,38 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"slackcheers/internal/repository"
+)
+
+func TestResolveBotToken_CachesWorkspaceToken(t *testing.T) {
+	lookups := 0
+	c := newTestAPIClient()
+	c.getInstallation = func(ctx context.Context, workspaceID string) (repository.WorkspaceSlackInstallation, error) {
+		lookups++
+		return repository.WorkspaceSlackInstallation{WorkspaceID: workspaceID, BotToken: "xoxb-workspace"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := c.resolveBotToken(context.Background(), "W1")
+		if err != nil {
+			t.Fatalf("resolve bot token: %v", err)
+		}
+		if token != "xoxb-workspace" {
+			t.Fatalf("token = %q, want xoxb-workspace", token)
+		}
+	}
+	if lookups != 1 {
+		t.Fatalf("installation looked up %d times, want 1", lookups)
+	}
+
+	c.InvalidateTokenCache("W1")
+	if _, err := c.resolveBotToken(context.Background(), "W1"); err != nil {
+		t.Fatalf("resolve bot token: %v", err)
+	}
+	if lookups != 2 {
+		t.Fatalf("expected a fresh lookup after invalidation, got %d lookups", lookups)
+	}
+}
@@ -1,8 +1,20 @@
 package slack
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Client interface {
-	PostMessage(ctx context.Context, workspaceID, channelID, text string, avatarURLs []string) error
+	PostMessage(ctx context.Context, workspaceID, channelID, text string, avatarURLs []string) (string, error)
+	PostMessageBlocks(ctx context.Context, workspaceID, channelID string, blocks []map[string]any) (string, error)
+	PostThreadReply(ctx context.Context, workspaceID, channelID, threadTS, text string) (string, error)
+	ScheduleMessage(ctx context.Context, workspaceID, channelID, text string, postAt time.Time, avatarURLs []string) (string, error)
+	DeleteScheduledMessages(ctx context.Context, workspaceID, channelID string, scheduledMessageIDs []string) error
+	GetPermalink(ctx context.Context, workspaceID, channelID, messageTS string) (string, error)
+	AddReaction(ctx context.Context, workspaceID, channelID, ts, emoji string) error
+	PostEphemeral(ctx context.Context, workspaceID, channelID, userID, text string, avatarURLs []string) error
 	SendDirectMessage(ctx context.Context, workspaceID, userID, text string) error
+	OpenView(ctx context.Context, workspaceID, triggerID string, view map[string]any) error
+	InvalidateTokenCache(workspaceID string)
 }
@@ -0,0 +1,35 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAPIError matches any error Slack reported with ok=false.
+var ErrAPIError = errors.New("slack api error")
+
+// ErrRateLimited matches a Slack HTTP 429 that was still rate limited after
+// waiting out Retry-After once.
+var ErrRateLimited = errors.New("slack rate limited")
+
+// ErrNotConnected matches a call made for a workspace that has no bot token and
+// no default token to fall back on.
+var ErrNotConnected = errors.New("workspace is not connected to Slack yet")
+
+// SlackAPIError keeps the full Slack error response so callers can log the
+// warning and response_metadata details that the error code alone hides.
+type SlackAPIError struct {
+	Code             string
+	Needed           string
+	Provided         string
+	Warning          string
+	ResponseMetadata map[string]any
+}
+
+func (e *SlackAPIError) Error() string {
+	return fmt.Sprintf("slack api error: %s%s", e.Code, slackScopeHint(e.Needed, e.Provided))
+}
+
+func (e *SlackAPIError) Is(target error) bool {
+	return target == ErrAPIError
+}
@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSlackAPIError_ErrorKeepsLegacyFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *SlackAPIError
+		want string
+	}{
+		{name: "code only", err: &SlackAPIError{Code: "channel_not_found"}, want: "slack api error: channel_not_found"},
+		{name: "with scopes", err: &SlackAPIError{Code: "missing_scope", Needed: "chat:write", Provided: "users:read"}, want: "slack api error: missing_scope (needed=chat:write provided=users:read)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.want {
+				t.Fatalf("Error() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSlackAPIError_MatchesSentinelAndAs(t *testing.T) {
+	var err error = fmt.Errorf("post message: %w", &SlackAPIError{
+		Code:             "invalid_blocks",
+		Warning:          "missing_charset",
+		ResponseMetadata: map[string]any{"messages": []any{"[ERROR] invalid block"}},
+	})
+
+	if !errors.Is(err, ErrAPIError) {
+		t.Fatal("expected errors.Is(err, ErrAPIError)")
+	}
+
+	var apiErr *SlackAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("expected errors.As to find *SlackAPIError")
+	}
+	if apiErr.Warning != "missing_charset" || apiErr.ResponseMetadata["messages"] == nil {
+		t.Fatalf("details not preserved: %+v", apiErr)
+	}
+}
+
+func TestSlackCallStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{&rateLimitError{RetryAfter: time.Second}, "rate_limited"},
+		{&SlackAPIError{Code: "channel_not_found"}, "error"},
+		{&httpStatusError{StatusCode: 502}, "error"},
+	}
+
+	for _, tc := range tests {
+		if got := slackCallStatus(tc.err); got != tc.want {
+			t.Fatalf("slackCallStatus(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
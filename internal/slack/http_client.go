@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// NewHTTPClient returns the client used for every Slack Web API call. Requests
+// are logged at debug level.
+func NewHTTPClient(timeout time.Duration, logger *slog.Logger) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &loggingRoundTripper{
+			next:   http.DefaultTransport,
+			logger: logger,
+		},
+	}
+}
+
+type loggingRoundTripper struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status_code", resp.StatusCode))
+	}
+	t.logger.LogAttrs(req.Context(), slog.LevelDebug, "slack http call", attrs...)
+
+	return resp, err
+}
@@ -0,0 +1,35 @@
+package slack
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient_LogsCalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client := NewHTTPClient(time.Second, slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	resp, err := client.Get(srv.URL + "/api/auth.test?token=secret")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+
+	logged := buf.String()
+	if !strings.Contains(logged, "slack http call") || !strings.Contains(logged, "status_code=200") {
+		t.Fatalf("expected the call to be logged, got %q", logged)
+	}
+	if strings.Contains(logged, "secret") {
+		t.Fatalf("query string leaked into the log: %q", logged)
+	}
+}
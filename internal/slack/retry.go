@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	slackRetryAttempts = 3
+
+	defaultRetryAfter = 5 * time.Second
+	maxRetryAfter     = 60 * time.Second
+)
+
+// slackRetryBase is the first backoff delay; tests shorten it.
+var slackRetryBase = 500 * time.Millisecond
+
+// httpStatusError is returned when Slack answers with a non-2xx status before
+// any JSON body can be read.
+type httpStatusError struct {
+	StatusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("slack http status %d", e.StatusCode)
+}
+
+// rateLimitError is an HTTP 429 with the wait Slack asked for, already capped
+// at maxRetryAfter.
+type rateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("slack rate limited: retry after %s", e.RetryAfter)
+}
+
+func (e *rateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// parseRetryAfter reads Retry-After as whole seconds, falling back to
+// defaultRetryAfter when the header is missing or unparseable.
+func parseRetryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return defaultRetryAfter
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
+
+// nonIdempotentSlackMethods must not be sent twice: a retry after Slack
+// accepted the first request would post the message again.
+var nonIdempotentSlackMethods = map[string]bool{
+	"chat.postMessage":     true,
+	"chat.postEphemeral":   true,
+	"chat.scheduleMessage": true,
+	"reactions.add":        true,
+}
+
+// withRetry runs fn up to maxAttempts times, sleeping base*2^n ±10% between
+// attempts. Slack API errors, rate limits and 4xx responses are returned
+// immediately, as is the context error once ctx is done. Unless idempotent,
+// only failures to connect are retried.
+func withRetry(ctx context.Context, fn func() error, maxAttempts int, base time.Duration, idempotent bool) error {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = fn(); err == nil || !retryable(err, idempotent) {
+			return err
+		}
+		if attempt == maxAttempts-1 {
+			break
+		}
+
+		if sleepErr := sleepContext(ctx, backoffDelay(base, attempt)); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+	}
+	return err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryable reports whether err is worth another attempt. A request that may
+// have reached Slack, shown by a 5xx, a reset or a failed body read, is only
+// retried when idempotent.
+func retryable(err error, idempotent bool) bool {
+	if errors.Is(err, ErrAPIError) || errors.Is(err, ErrRateLimited) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if notSent(err) {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// notSent reports whether err happened while connecting, before any of the
+// request was written.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << attempt
+	jitter := (rand.Float64()*0.2 - 0.1) * float64(delay)
+	return delay + time.Duration(jitter)
+}
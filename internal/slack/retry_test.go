@@ -0,0 +1,253 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestAPIClient() *APIClient {
+	return &APIClient{
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		httpClient: http.DefaultClient,
+		tokenCache: &sync.Map{},
+	}
+}
+
+func shortenRetryBase(t *testing.T, d time.Duration) {
+	t.Helper()
+	previous := slackRetryBase
+	slackRetryBase = d
+	t.Cleanup(func() { slackRetryBase = previous })
+}
+
+func TestCallSlackJSON_RetriesServerErrors(t *testing.T) {
+	shortenRetryBase(t, time.Millisecond)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL, map[string]any{}, nil)
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected a 502 status error, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
+func TestCallSlackJSON_SucceedsAfterTransientFailure(t *testing.T) {
+	shortenRetryBase(t, time.Millisecond)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"ts":"123.456"}`))
+	}))
+	defer srv.Close()
+
+	var resp slackAPIResponse
+	if err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL, map[string]any{}, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.TS != "123.456" || calls.Load() != 2 {
+		t.Fatalf("expected success on the second attempt, got ts=%q attempts=%d", resp.TS, calls.Load())
+	}
+}
+
+func TestCallSlackJSON_DoesNotResendPosts(t *testing.T) {
+	shortenRetryBase(t, time.Millisecond)
+
+	tests := map[string]http.HandlerFunc{
+		"502": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		},
+		"reset": func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			if tcp, ok := conn.(*net.TCPConn); ok {
+				_ = tcp.SetLinger(0)
+			}
+			_ = conn.Close()
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				handler(w, r)
+			}))
+			defer srv.Close()
+
+			err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL+"/api/chat.postMessage", map[string]any{}, nil)
+			if err == nil {
+				t.Fatal("expected the failed post to be reported")
+			}
+			if got := calls.Load(); got != 1 {
+				t.Fatalf("attempts = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRetryable_RetriesPostsOnlyWhenNotSent(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	if !retryable(fmt.Errorf("call slack api: %w", dialErr), false) {
+		t.Fatal("expected a dial error to be retried for a post")
+	}
+	if retryable(fmt.Errorf("call slack api: %w", readErr), false) {
+		t.Fatal("expected a reset to not be retried for a post")
+	}
+	if !retryable(fmt.Errorf("call slack api: %w", readErr), true) {
+		t.Fatal("expected a reset to be retried for an idempotent call")
+	}
+}
+
+func TestCallSlackJSON_DoesNotRetrySlackErrors(t *testing.T) {
+	shortenRetryBase(t, time.Millisecond)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+	}))
+	defer srv.Close()
+
+	err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL, map[string]any{}, nil)
+	if !errors.Is(err, ErrAPIError) {
+		t.Fatalf("expected a Slack API error, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("attempts = %d, want 1", got)
+	}
+}
+
+func TestWithRetry_StopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := withRetry(ctx, func() error {
+		attempts++
+		cancel()
+		return &httpStatusError{StatusCode: http.StatusInternalServerError}
+	}, 3, time.Hour, true)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestWithRetry_DoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), func() error {
+		attempts++
+		return &httpStatusError{StatusCode: http.StatusTooManyRequests}
+	}, 3, time.Millisecond, true)
+
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected one failed attempt, got attempts=%d err=%v", attempts, err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"3":    3 * time.Second,
+		" 0 ":  0,
+		"120":  maxRetryAfter,
+		"":     defaultRetryAfter,
+		"soon": defaultRetryAfter,
+		"-1":   defaultRetryAfter,
+	}
+	for header, want := range tests {
+		if got := parseRetryAfter(header); got != want {
+			t.Fatalf("parseRetryAfter(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestCallSlackJSON_RetriesOnceAfterRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"ts":"123.456"}`))
+	}))
+	defer srv.Close()
+
+	var resp slackAPIResponse
+	if err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL, map[string]any{}, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.TS != "123.456" || calls.Load() != 2 {
+		t.Fatalf("expected success after one rate limited call, got ts=%q attempts=%d", resp.TS, calls.Load())
+	}
+}
+
+func TestCallSlackJSON_ReturnsErrRateLimitedWhenStillLimited(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	err := newTestAPIClient().callSlackJSON(context.Background(), "xoxb-test", srv.URL, map[string]any{}, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("attempts = %d, want 2", got)
+	}
+}
+
+func TestCallSlackJSON_RateLimitWaitHonoursContext(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := newTestAPIClient().callSlackJSON(ctx, "xoxb-test", srv.URL, map[string]any{}, nil)
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a rate limit joined with the context error, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("attempts = %d, want 1", got)
+	}
+}